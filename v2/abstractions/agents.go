@@ -10,8 +10,37 @@
 
 package abstractions
 
+import (
+	ref "reflect"
+)
+
+// TYPE DEFINITIONS
+
+type (
+	Handler   func(target ComponentLike, method string, arguments []ComponentLike) ComponentLike
+	Intrinsic func(arguments []ComponentLike) ComponentLike
+	Method    func(target ComponentLike, arguments []ComponentLike) ComponentLike
+)
+
 // INDIVIDUAL INTERFACES
 
+type Assemblable interface {
+	AssembleListing(listing string) BytecodeLike
+	DisassembleProgram(program ProgramLike) string
+}
+
+type Asynchronous interface {
+	SendMessage(target ComponentLike, method string, arguments []ComponentLike) FutureLike
+}
+
+type Checkable interface {
+	CheckProcedure(procedure ProcedureLike) []string
+}
+
+type Compilable interface {
+	CompileProcedure(procedure ProcedureLike) ProgramLike
+}
+
 type Custodial interface {
 	Exists() bool
 	Load() []byte
@@ -19,14 +48,67 @@ type Custodial interface {
 	Delete()
 }
 
+type Dispatchable interface {
+	RegisterType(type_ ref.Type)
+	RegisterMethod(type_ ref.Type, name string, method Method)
+	SupportsMethod(target ComponentLike, name string) bool
+	DispatchMethod(target ComponentLike, name string, arguments []ComponentLike) ComponentLike
+}
+
+type Evaluative interface {
+	GetEnvironment() ContextLike
+	GetDispatcher() DispatcherLike
+	EvaluateExpression(expression Expression) ComponentLike
+}
+
+type Executive interface {
+	GetEnvironment() ContextLike
+	GetDispatcher() DispatcherLike
+	SetIntrinsic(name string, intrinsic Intrinsic)
+	SetMethod(name string, method Method)
+	SetEventBus(bus EventBusLike)
+	SetRepository(repository RepositoryLike)
+	SetNotary(notary NotaryLike)
+	ExecuteProgram(program ProgramLike) ComponentLike
+}
+
+type Eventual interface {
+	IsDone() bool
+	GetResult() ComponentLike
+	GetFailure() ComponentLike
+}
+
+type Interpretive interface {
+	ExecuteProcedure(procedure ProcedureLike) ComponentLike
+}
+
 type Mechanized interface {
 	GetState() int
 	SetState(state int)
 	TransitionState(event int) int
 }
 
+type Notarial interface {
+	GetCertificate() CitationLike
+	GetPublicKey() BinaryLike
+	NotarizeDocument(document ComponentLike) ComponentLike
+	VerifyDocument(document ComponentLike) bool
+}
+
 // CONSOLIDATED INTERFACES
 
+type AssemblerLike interface {
+	Assemblable
+}
+
+type CheckerLike interface {
+	Checkable
+}
+
+type CompilerLike interface {
+	Compilable
+}
+
 type ConfiguratorLike interface {
 	Custodial
 }
@@ -34,3 +116,32 @@ type ConfiguratorLike interface {
 type ControllerLike interface {
 	Mechanized
 }
+
+type DispatcherLike interface {
+	Dispatchable
+}
+
+type EvaluatorLike interface {
+	Evaluative
+}
+
+type FutureLike interface {
+	Eventual
+}
+
+type InterpreterLike interface {
+	Evaluative
+	Interpretive
+}
+
+type NotaryLike interface {
+	Notarial
+}
+
+type ProcessorLike interface {
+	Executive
+}
+
+type SchedulerLike interface {
+	Asynchronous
+}
@@ -0,0 +1,34 @@
+/*******************************************************************************
+ *   Copyright (c) 2009-2023 Crater Dog Technologies™.  All Rights Reserved.   *
+ *******************************************************************************
+ * DO NOT ALTER OR REMOVE COPYRIGHT NOTICES OR THIS FILE HEADER.               *
+ *                                                                             *
+ * This code is free software; you can redistribute it and/or modify it under  *
+ * the terms of The MIT License (MIT), as published by the Open Source         *
+ * Initiative. (See http://opensource.org/licenses/MIT)                        *
+ *******************************************************************************/
+
+package abstractions
+
+// INDIVIDUAL INTERFACES
+
+type Leasable interface {
+	PostMessage(message ComponentLike)
+	RetrieveMessage() ComponentLike
+	AcceptMessage(message ComponentLike)
+	RejectMessage(message ComponentLike)
+}
+
+type Limited interface {
+	GetCapacity() int
+	GetLease() DurationLike
+	GetSize() int
+	GetAvailable() int
+}
+
+// CONSOLIDATED INTERFACES
+
+type MessageBagLike interface {
+	Limited
+	Leasable
+}
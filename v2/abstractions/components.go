@@ -38,6 +38,7 @@ type Encapsulated interface {
 	ExtractAngle() AngleLike
 	ExtractBinary() BinaryLike
 	ExtractBoolean() BooleanLike
+	ExtractBytecode() BytecodeLike
 	ExtractCatalog() CatalogLike
 	ExtractCitation() CitationLike
 	ExtractContinuum() ContinuumLike
@@ -0,0 +1,45 @@
+/*******************************************************************************
+ *   Copyright (c) 2009-2023 Crater Dog Technologies™.  All Rights Reserved.   *
+ *******************************************************************************
+ * DO NOT ALTER OR REMOVE COPYRIGHT NOTICES OR THIS FILE HEADER.               *
+ *                                                                             *
+ * This code is free software; you can redistribute it and/or modify it under  *
+ * the terms of The MIT License (MIT), as published by the Open Source         *
+ * Initiative. (See http://opensource.org/licenses/MIT)                        *
+ *******************************************************************************/
+
+package abstractions
+
+// TYPE DEFINITIONS
+
+type (
+	Subscriber     func(event ComponentLike)
+	FailureHandler func(event ComponentLike, failure any)
+)
+
+// INDIVIDUAL INTERFACES
+
+type Publishable interface {
+	PublishEvent(publisher string, event ComponentLike)
+	AwaitDelivery()
+}
+
+type Replayable interface {
+	IsLogged() bool
+	ReplayEvents(subscriber Subscriber)
+}
+
+type Subscribable interface {
+	SubscribeToType(type_ string, subscriber Subscriber) int
+	SubscribeToPattern(pattern PatternLike, subscriber Subscriber) int
+	Unsubscribe(subscription int)
+	SetFailureHandler(handler FailureHandler)
+}
+
+// CONSOLIDATED INTERFACES
+
+type EventBusLike interface {
+	Publishable
+	Subscribable
+	Replayable
+}
@@ -0,0 +1,39 @@
+/*******************************************************************************
+ *   Copyright (c) 2009-2023 Crater Dog Technologies™.  All Rights Reserved.   *
+ *******************************************************************************
+ * DO NOT ALTER OR REMOVE COPYRIGHT NOTICES OR THIS FILE HEADER.               *
+ *                                                                             *
+ * This code is free software; you can redistribute it and/or modify it under  *
+ * the terms of The MIT License (MIT), as published by the Open Source         *
+ * Initiative. (See http://opensource.org/licenses/MIT)                        *
+ *******************************************************************************/
+
+package abstractions
+
+// INDIVIDUAL INTERFACES
+
+type Registered interface {
+	RegisterIntrinsic(name string, signature SignatureLike, intrinsic Intrinsic)
+	IsRegistered(name string) bool
+	GetNames() []string
+	GetSignature(name string) SignatureLike
+	InvokeIntrinsic(name string, arguments []ComponentLike) ComponentLike
+}
+
+type Typed interface {
+	GetArity() int
+	GetParameters() []string
+	GetResult() string
+	AcceptsArguments(arguments []ComponentLike) bool
+}
+
+// CONSOLIDATED INTERFACES
+
+type RegistryLike interface {
+	Registered
+}
+
+type SignatureLike interface {
+	Lexical
+	Typed
+}
@@ -0,0 +1,45 @@
+/*******************************************************************************
+ *   Copyright (c) 2009-2023 Crater Dog Technologies™.  All Rights Reserved.   *
+ *******************************************************************************
+ * DO NOT ALTER OR REMOVE COPYRIGHT NOTICES OR THIS FILE HEADER.               *
+ *                                                                             *
+ * This code is free software; you can redistribute it and/or modify it under  *
+ * the terms of The MIT License (MIT), as published by the Open Source         *
+ * Initiative. (See http://opensource.org/licenses/MIT)                        *
+ *******************************************************************************/
+
+package abstractions
+
+// TYPE DEFINITIONS
+
+// This type defines a location in the source bytes of a BDN document. The line
+// and column numbers are one based and the column counts runes rather than
+// bytes. The offset is the zero based index of the first byte at the location.
+type Location struct {
+	Line   int
+	Column int
+	Offset int
+}
+
+// This type defines the span of source bytes from which a node in an abstract
+// syntax tree was parsed. The end location is just past the last rune of the
+// node.
+type Span struct {
+	Start Location
+	End   Location
+}
+
+// This method determines whether or not this span has been set.
+func (v Span) IsValid() bool {
+	return v.Start.Line > 0
+}
+
+// INDIVIDUAL INTERFACES
+
+// This interface is supported by the nodes of an abstract syntax tree that
+// record the span of source bytes from which they were parsed. Nodes that were
+// not parsed from source bytes have a zero span.
+type Located interface {
+	GetSpan() Span
+	SetSpan(span Span)
+}
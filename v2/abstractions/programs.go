@@ -0,0 +1,116 @@
+/*******************************************************************************
+ *   Copyright (c) 2009-2023 Crater Dog Technologies™.  All Rights Reserved.   *
+ *******************************************************************************
+ * DO NOT ALTER OR REMOVE COPYRIGHT NOTICES OR THIS FILE HEADER.               *
+ *                                                                             *
+ * This code is free software; you can redistribute it and/or modify it under  *
+ * the terms of The MIT License (MIT), as published by the Open Source         *
+ * Initiative. (See http://opensource.org/licenses/MIT)                        *
+ *******************************************************************************/
+
+package abstractions
+
+// TYPE DEFINITIONS
+
+type (
+	Modifier  int
+	Operation int
+)
+
+// CONSTANT DEFINITIONS
+
+// Each bytecode instruction is a 16 bit value with the following layout:
+//
+//	 15 14 13   12 11   10 ... 0
+//	[operation][modifier][operand]
+//
+// The 3 bit operation selects one of the eight operations defined below, the
+// 2 bit modifier refines what that operation does, and the 11 bit operand is
+// an ordinal (1 based) address, literal index or symbol index depending on the
+// operation. The instruction 0x0000 ("JUMP ALWAYS 0") does nothing and is
+// known as SKIP.
+const (
+	JUMP   Operation = iota // Jumps to the operand address if the modifier condition holds.
+	PUSH                    // Pushes a handler address or literal value onto a stack.
+	PULL                    // Pulls a handler or component off of a stack.
+	LOAD                    // Loads a component using the symbol named by the operand.
+	STORE                   // Stores a component using the symbol named by the operand.
+	INVOKE                  // Invokes the intrinsic function named by the operand.
+	CALL                    // Synchronously calls the method named by the operand.
+	SEND                    // Asynchronously sends the method named by the operand.
+)
+
+// These constants define the modifiers for the JUMP operation. Each conditional
+// jump pulls the component on the top of the component stack and tests it.
+const (
+	ALWAYS  Modifier = iota // Jumps unconditionally.
+	ONNONE                  // Jumps if the component is none.
+	ONFALSE                 // Jumps if the component is false.
+	ONTRUE                  // Jumps if the component is true.
+)
+
+// These constants define the modifiers for the PUSH operation.
+const (
+	HANDLER Modifier = iota // Pushes the handler address onto the handler stack.
+	LITERAL                 // Pushes the literal value onto the component stack.
+)
+
+// These constants define the modifiers for the PULL operation. The HANDLER
+// modifier pulls the top handler address off of the handler stack.
+const (
+	_         Modifier = iota // HANDLER
+	COMPONENT                 // Pulls the top component and discards it.
+	RESULT                    // Pulls the top component and returns it as the result.
+	EXCEPTION                 // Pulls the top component and throws it as an exception.
+)
+
+// These constants define the modifiers for the LOAD and STORE operations. The
+// operand names a variable that holds either the component itself or, for the
+// other modifiers, the bag, draft or document citation that is being accessed.
+const (
+	VARIABLE Modifier = iota // Loads or stores the value of the variable.
+	MESSAGE                  // Retrieves a message from, or posts a message to, a bag.
+	DRAFT                    // Retrieves or saves a draft document.
+	DOCUMENT                 // Retrieves or notarizes a document.
+)
+
+// The modifier for the INVOKE, CALL and SEND operations is the number of
+// arguments (0..3) that are on the component stack. The CALL and SEND
+// operations expect the target component to be below the arguments.
+
+// These constants define the limits of each instruction field.
+const (
+	MaximumModifier = 0x3
+	MaximumOperand  = 0x7FF
+)
+
+// This function returns a new instruction made up of the specified operation,
+// modifier and operand.
+func InstructionFromOperation(operation Operation, modifier Modifier, operand int) Instruction {
+	var instruction = uint16(operation)<<13 | uint16(modifier&MaximumModifier)<<11 | uint16(operand&MaximumOperand)
+	return Instruction(instruction)
+}
+
+func (v Instruction) GetOperation() Operation {
+	return Operation(v >> 13)
+}
+
+func (v Instruction) GetModifier() Modifier {
+	return Modifier((v >> 11) & MaximumModifier)
+}
+
+func (v Instruction) GetOperand() int {
+	return int(v & MaximumOperand)
+}
+
+// CONSOLIDATED INTERFACES
+
+type ProgramLike interface {
+	GetBytecode() BytecodeLike
+	GetLiterals() Sequential[ComponentLike]
+	GetLiteral(index int) ComponentLike
+	GetSymbols() Sequential[SymbolLike]
+	GetSymbol(index int) SymbolLike
+	GetSource(address int) string
+	AsCatalog() CatalogLike
+}
@@ -0,0 +1,34 @@
+/*******************************************************************************
+ *   Copyright (c) 2009-2023 Crater Dog Technologies™.  All Rights Reserved.   *
+ *******************************************************************************
+ * DO NOT ALTER OR REMOVE COPYRIGHT NOTICES OR THIS FILE HEADER.               *
+ *                                                                             *
+ * This code is free software; you can redistribute it and/or modify it under  *
+ * the terms of The MIT License (MIT), as published by the Open Source         *
+ * Initiative. (See http://opensource.org/licenses/MIT)                        *
+ *******************************************************************************/
+
+package abstractions
+
+// INDIVIDUAL INTERFACES
+
+type Archival interface {
+	GetLatestVersion(name NameLike) VersionLike
+	DocumentExists(name NameLike, version VersionLike) bool
+	RetrieveDocument(name NameLike, version VersionLike) ComponentLike
+	CommitDocument(name NameLike, version VersionLike, document ComponentLike) ComponentLike
+}
+
+type Drafting interface {
+	DraftExists(name NameLike, version VersionLike) bool
+	RetrieveDraft(name NameLike, version VersionLike) ComponentLike
+	SaveDraft(name NameLike, version VersionLike, draft ComponentLike) ComponentLike
+	DiscardDraft(name NameLike, version VersionLike)
+}
+
+// CONSOLIDATED INTERFACES
+
+type RepositoryLike interface {
+	Archival
+	Drafting
+}
@@ -0,0 +1,307 @@
+/*******************************************************************************
+ *   Copyright (c) 2009-2023 Crater Dog Technologies™.  All Rights Reserved.   *
+ *******************************************************************************
+ * DO NOT ALTER OR REMOVE COPYRIGHT NOTICES OR THIS FILE HEADER.               *
+ *                                                                             *
+ * This code is free software; you can redistribute it and/or modify it under  *
+ * the terms of The MIT License (MIT), as published by the Open Source         *
+ * Initiative. (See http://opensource.org/licenses/MIT)                        *
+ *******************************************************************************/
+
+package agents
+
+import (
+	fmt "fmt"
+	abs "github.com/bali-nebula/go-component-framework/v2/abstractions"
+	bal "github.com/bali-nebula/go-component-framework/v2/bali"
+	str "github.com/bali-nebula/go-component-framework/v2/strings"
+	stc "strconv"
+	sts "strings"
+)
+
+// ASSEMBLER IMPLEMENTATION
+
+// This constructor creates a new assembler that converts bytecode into a
+// human readable listing and back again. Each line of a listing contains the
+// address of an instruction followed by the instruction itself and, after a
+// semicolon, a comment describing its operand:
+//
+//	; let $x := 5
+//	   1: PUSH LITERAL 1              ; 5
+//	   2: STORE VARIABLE 1            ; $x
+//	; return x + 1
+//	   3: LOAD VARIABLE 1             ; $x
+//	   4: PUSH LITERAL 2              ; 1
+//	   5: INVOKE 2 WITH 2 ARGUMENTS   ; $sum-2
+//	   6: PULL RESULT
+//
+// A line that begins with a semicolon names the source line that the
+// instructions which follow it were compiled from. The instructions use the
+// following forms:
+//
+//	SKIP
+//	JUMP TO address [ON NONE | ON FALSE | ON TRUE]
+//	PUSH HANDLER address
+//	PUSH LITERAL literal
+//	PULL HANDLER | PULL COMPONENT | PULL RESULT | PULL EXCEPTION
+//	LOAD VARIABLE | MESSAGE | DRAFT | DOCUMENT symbol
+//	STORE VARIABLE | MESSAGE | DRAFT | DOCUMENT symbol
+//	INVOKE symbol [WITH 1 ARGUMENT | WITH n ARGUMENTS]
+//	CALL symbol [WITH 1 ARGUMENT | WITH n ARGUMENTS]
+//	SEND symbol [WITH 1 ARGUMENT | WITH n ARGUMENTS]
+//
+// The assembler ignores the addresses and comments in a listing.
+func Assembler() abs.AssemblerLike {
+	return &assembler{}
+}
+
+// This type defines the structure and methods associated with an assembler
+// agent.
+type assembler struct{}
+
+// These maps define the mnemonics for each operation and modifier.
+var (
+	operationNames = map[abs.Operation]string{
+		abs.JUMP:   "JUMP",
+		abs.PUSH:   "PUSH",
+		abs.PULL:   "PULL",
+		abs.LOAD:   "LOAD",
+		abs.STORE:  "STORE",
+		abs.INVOKE: "INVOKE",
+		abs.CALL:   "CALL",
+		abs.SEND:   "SEND",
+	}
+	conditionNames = []string{"", "NONE", "FALSE", "TRUE"}
+	pushNames      = []string{"HANDLER", "LITERAL"}
+	pullNames      = []string{"HANDLER", "COMPONENT", "RESULT", "EXCEPTION"}
+	accessNames    = []string{"VARIABLE", "MESSAGE", "DRAFT", "DOCUMENT"}
+)
+
+// PUBLIC INTERFACE
+
+// This method assembles the instructions in the specified listing into
+// bytecode.
+func (v *assembler) AssembleListing(listing string) abs.BytecodeLike {
+	var instructions []abs.Instruction
+	for index, line := range sts.Split(listing, bal.EOL) {
+		line, _, _ = sts.Cut(line, ";")
+		if address, text, ok := sts.Cut(line, ":"); ok {
+			if _, err := stc.Atoi(sts.TrimSpace(address)); err != nil {
+				var message = fmt.Sprintf("The assembler found an invalid address on line %v: %v", index+1, address)
+				panic(message)
+			}
+			line = text
+		}
+		var fields = sts.Fields(line)
+		if len(fields) == 0 {
+			// Skip any source and blank lines.
+			continue
+		}
+		var instruction, ok = v.assembleInstruction(fields)
+		if !ok {
+			var message = fmt.Sprintf("The assembler found an invalid instruction on line %v: %v", index+1, sts.Join(fields, " "))
+			panic(message)
+		}
+		instructions = append(instructions, instruction)
+	}
+	return str.BytecodeFromArray(instructions)
+}
+
+// This method returns a listing of the instructions in the specified program.
+func (v *assembler) DisassembleProgram(program abs.ProgramLike) string {
+	var builder sts.Builder
+	var source string
+	for index, instruction := range program.GetBytecode().AsArray() {
+		var address = index + 1
+		var line = program.GetSource(address)
+		if len(line) > 0 && line != source {
+			builder.WriteString("; " + line + bal.EOL)
+		}
+		source = line
+		var text = fmt.Sprintf("%4d: %v", address, v.disassembleInstruction(instruction))
+		var comment = v.describeOperand(program, instruction)
+		if len(comment) > 0 {
+			text = fmt.Sprintf("%-34v; %v", text, comment)
+		}
+		builder.WriteString(text + bal.EOL)
+	}
+	return builder.String()
+}
+
+// PRIVATE METHODS
+
+// This private method returns the mnemonic form of the specified instruction.
+func (v *assembler) disassembleInstruction(instruction abs.Instruction) string {
+	var operation = instruction.GetOperation()
+	var modifier = instruction.GetModifier()
+	var operand = instruction.GetOperand()
+	var name = operationNames[operation]
+	switch operation {
+	case abs.JUMP:
+		if modifier == abs.ALWAYS && operand == 0 {
+			return "SKIP"
+		}
+		var text = fmt.Sprintf("JUMP TO %v", operand)
+		if modifier != abs.ALWAYS {
+			text += " ON " + conditionNames[modifier]
+		}
+		return text
+	case abs.PUSH:
+		if int(modifier) >= len(pushNames) {
+			return fmt.Sprintf("PUSH %v %v", modifier, operand)
+		}
+		return fmt.Sprintf("PUSH %v %v", pushNames[modifier], operand)
+	case abs.PULL:
+		return "PULL " + pullNames[modifier]
+	case abs.LOAD, abs.STORE:
+		return fmt.Sprintf("%v %v %v", name, accessNames[modifier], operand)
+	default:
+		switch modifier {
+		case 0:
+			return fmt.Sprintf("%v %v", name, operand)
+		case 1:
+			return fmt.Sprintf("%v %v WITH 1 ARGUMENT", name, operand)
+		default:
+			return fmt.Sprintf("%v %v WITH %v ARGUMENTS", name, operand, modifier)
+		}
+	}
+}
+
+// This private method returns a description of the literal or symbol that the
+// operand of the specified instruction refers to in the specified program.
+func (v *assembler) describeOperand(program abs.ProgramLike, instruction abs.Instruction) string {
+	var operand = instruction.GetOperand()
+	switch instruction.GetOperation() {
+	case abs.PUSH:
+		if instruction.GetModifier() != abs.LITERAL || operand < 1 || operand > program.GetLiterals().GetSize() {
+			return ""
+		}
+		var literal = bal.FormatComponent(program.GetLiteral(operand))
+		var first, _, multiline = sts.Cut(literal, bal.EOL)
+		if multiline {
+			first += " ..."
+		}
+		return first
+	case abs.LOAD, abs.STORE, abs.INVOKE, abs.CALL, abs.SEND:
+		if operand < 1 || operand > program.GetSymbols().GetSize() {
+			return ""
+		}
+		return "$" + program.GetSymbol(operand).AsString()
+	default:
+		return ""
+	}
+}
+
+// This private method assembles the instruction described by the specified
+// fields. It returns the instruction and whether or not the fields describe a
+// valid instruction.
+func (v *assembler) assembleInstruction(fields []string) (abs.Instruction, bool) {
+	var instruction abs.Instruction
+	switch fields[0] {
+	case "SKIP":
+		return instruction, len(fields) == 1
+	case "JUMP":
+		if len(fields) < 3 || fields[1] != "TO" {
+			return instruction, false
+		}
+		var modifier = abs.ALWAYS
+		switch {
+		case len(fields) == 5 && fields[3] == "ON":
+			var index = v.lookupName(conditionNames, fields[4])
+			if index < 1 {
+				return instruction, false
+			}
+			modifier = abs.Modifier(index)
+		case len(fields) != 3:
+			return instruction, false
+		}
+		return v.formInstruction(abs.JUMP, modifier, fields[2])
+	case "PUSH":
+		if len(fields) != 3 {
+			return instruction, false
+		}
+		var index = v.lookupName(pushNames, fields[1])
+		if index < 0 {
+			// Unnamed modifiers are disassembled as numbers.
+			var err error
+			index, err = stc.Atoi(fields[1])
+			if err != nil || index < len(pushNames) || index > abs.MaximumModifier {
+				return instruction, false
+			}
+		}
+		return v.formInstruction(abs.PUSH, abs.Modifier(index), fields[2])
+	case "PULL":
+		if len(fields) != 2 {
+			return instruction, false
+		}
+		var index = v.lookupName(pullNames, fields[1])
+		if index < 0 {
+			return instruction, false
+		}
+		return v.formInstruction(abs.PULL, abs.Modifier(index), "0")
+	case "LOAD", "STORE":
+		if len(fields) != 3 {
+			return instruction, false
+		}
+		var index = v.lookupName(accessNames, fields[1])
+		if index < 0 {
+			return instruction, false
+		}
+		var operation = abs.LOAD
+		if fields[0] == "STORE" {
+			operation = abs.STORE
+		}
+		return v.formInstruction(operation, abs.Modifier(index), fields[2])
+	case "INVOKE", "CALL", "SEND":
+		var operation = map[string]abs.Operation{
+			"INVOKE": abs.INVOKE,
+			"CALL":   abs.CALL,
+			"SEND":   abs.SEND,
+		}[fields[0]]
+		var count int
+		switch len(fields) {
+		case 2:
+			count = 0
+		case 5:
+			var err error
+			count, err = stc.Atoi(fields[3])
+			if err != nil || fields[2] != "WITH" || count < 1 || count > abs.MaximumModifier {
+				return instruction, false
+			}
+			if (count == 1 && fields[4] != "ARGUMENT") || (count > 1 && fields[4] != "ARGUMENTS") {
+				return instruction, false
+			}
+		default:
+			return instruction, false
+		}
+		return v.formInstruction(operation, abs.Modifier(count), fields[1])
+	default:
+		return instruction, false
+	}
+}
+
+// This private method returns the instruction made up of the specified
+// operation, modifier and operand. It returns whether or not the operand is a
+// valid number.
+func (v *assembler) formInstruction(
+	operation abs.Operation,
+	modifier abs.Modifier,
+	operand string,
+) (abs.Instruction, bool) {
+	var number, err = stc.Atoi(operand)
+	if err != nil || number < 0 || number > abs.MaximumOperand {
+		return 0, false
+	}
+	return abs.InstructionFromOperation(operation, modifier, number), true
+}
+
+// This private method returns the index of the specified name in the specified
+// list of names, or -1 if it is not there.
+func (v *assembler) lookupName(names []string, name string) int {
+	for index, candidate := range names {
+		if len(candidate) > 0 && candidate == name {
+			return index
+		}
+	}
+	return -1
+}
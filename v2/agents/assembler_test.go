@@ -0,0 +1,122 @@
+/*******************************************************************************
+ *   Copyright (c) 2009-2023 Crater Dog Technologies™.  All Rights Reserved.   *
+ *******************************************************************************
+ * DO NOT ALTER OR REMOVE COPYRIGHT NOTICES OR THIS FILE HEADER.               *
+ *                                                                             *
+ * This code is free software; you can redistribute it and/or modify it under  *
+ * the terms of The MIT License (MIT), as published by the Open Source         *
+ * Initiative. (See http://opensource.org/licenses/MIT)                        *
+ *******************************************************************************/
+
+package agents_test
+
+import (
+	abs "github.com/bali-nebula/go-component-framework/v2/abstractions"
+	age "github.com/bali-nebula/go-component-framework/v2/agents"
+	exp "github.com/bali-nebula/go-component-framework/v2/expressions"
+	pro "github.com/bali-nebula/go-component-framework/v2/procedures"
+	prg "github.com/bali-nebula/go-component-framework/v2/programs"
+	str "github.com/bali-nebula/go-component-framework/v2/strings"
+	cox "github.com/craterdog/go-collection-framework/v2"
+	ass "github.com/stretchr/testify/assert"
+	tes "testing"
+)
+
+const listing = `; throw "boom"
+   1: PUSH HANDLER 6
+   2: PUSH LITERAL 1              ; "boom"
+   3: PULL EXCEPTION
+   4: PULL HANDLER
+   5: JUMP TO 16
+   6: STORE VARIABLE 1            ; $failure
+   7: LOAD VARIABLE 1             ; $failure
+   8: PUSH LITERAL 2              ; "boom"
+   9: INVOKE 2 WITH 2 ARGUMENTS   ; $matches-2
+  10: JUMP TO 14 ON FALSE
+; return failure
+  11: LOAD VARIABLE 1             ; $failure
+  12: PULL RESULT
+; throw "boom"
+  13: JUMP TO 16
+  14: LOAD VARIABLE 1             ; $failure
+  15: PULL EXCEPTION
+`
+
+func TestDisassembleProgram(t *tes.T) {
+	var compiler = age.Compiler()
+	var assembler = age.Assembler()
+
+	// throw "boom"
+	// on $failure matching "boom" do {
+	//     return $failure
+	// }
+	var blocks = cox.List[abs.BlockLike]()
+	blocks.AddValue(pro.Block(quote("boom"), procedure(
+		statement(pro.ReturnClause(exp.Variable("failure"))),
+	)))
+	var handler = pro.OnClause(str.SymbolFromString("failure"), blocks)
+	var code = procedure(pro.StatementWithHandler(pro.ThrowClause(quote("boom")), handler))
+	var program = compiler.CompileProcedure(code)
+	ass.Equal(t, "throw \"boom\"", program.GetSource(1))
+	ass.Equal(t, "return failure", program.GetSource(12))
+	ass.Equal(t, "", program.GetSource(16))
+	ass.Equal(t, listing, assembler.DisassembleProgram(program))
+
+	var bytecode = assembler.AssembleListing(listing)
+	ass.Equal(t, program.GetBytecode().AsString(), bytecode.AsString())
+}
+
+func TestAssembleListing(t *tes.T) {
+	var assembler = age.Assembler()
+	var bytecode = assembler.AssembleListing(`
+SKIP
+JUMP TO 1 ON NONE
+JUMP TO 1 ON TRUE
+PUSH LITERAL 2047
+PULL COMPONENT
+LOAD MESSAGE 3
+STORE DOCUMENT 4
+CALL 5 WITH 1 ARGUMENT
+SEND 6
+`)
+	ass.Equal(t, []abs.Instruction{
+		instruction(abs.JUMP, abs.ALWAYS, 0),
+		instruction(abs.JUMP, abs.ONNONE, 1),
+		instruction(abs.JUMP, abs.ONTRUE, 1),
+		instruction(abs.PUSH, abs.LITERAL, 2047),
+		instruction(abs.PULL, abs.COMPONENT, 0),
+		instruction(abs.LOAD, abs.MESSAGE, 3),
+		instruction(abs.STORE, abs.DOCUMENT, 4),
+		instruction(abs.CALL, 1, 5),
+		instruction(abs.SEND, 0, 6),
+	}, bytecode.AsArray())
+
+	defer func() {
+		if e := recover(); e != nil {
+			ass.Equal(t, "The assembler found an invalid instruction on line 2: INVOKE 1 WITH 4 ARGUMENTS", e)
+		} else {
+			ass.Fail(t, "Test should result in recovered panic.")
+		}
+	}()
+	assembler.AssembleListing("SKIP\nINVOKE 1 WITH 4 ARGUMENTS") // This should panic.
+}
+
+func TestDisassemblyRoundTrip(t *tes.T) {
+	var assembler = age.Assembler()
+	var instructions = []abs.Instruction{
+		instruction(abs.JUMP, abs.ALWAYS, 0),
+		instruction(abs.JUMP, abs.ONNONE, 0),
+		instruction(abs.JUMP, abs.ONFALSE, 0),
+		instruction(abs.JUMP, abs.ONTRUE, 0),
+		instruction(abs.JUMP, abs.ALWAYS, 5),
+		instruction(abs.PUSH, abs.HANDLER, 1),
+		instruction(abs.PUSH, abs.LITERAL, 2),
+		instruction(abs.PUSH, 2, 3),
+		instruction(abs.PUSH, 3, 4),
+		instruction(abs.INVOKE, 3, 5),
+	}
+	var bytecode = str.BytecodeFromArray(instructions)
+	var program = prg.Program(bytecode, cox.List[abs.ComponentLike](), cox.List[abs.SymbolLike]())
+	var listing = assembler.DisassembleProgram(program)
+	ass.Equal(t, instructions, assembler.AssembleListing(listing).AsArray())
+}
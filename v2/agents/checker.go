@@ -0,0 +1,197 @@
+/*******************************************************************************
+ *   Copyright (c) 2009-2023 Crater Dog Technologies™.  All Rights Reserved.   *
+ *******************************************************************************
+ * DO NOT ALTER OR REMOVE COPYRIGHT NOTICES OR THIS FILE HEADER.               *
+ *                                                                             *
+ * This code is free software; you can redistribute it and/or modify it under  *
+ * the terms of The MIT License (MIT), as published by the Open Source         *
+ * Initiative. (See http://opensource.org/licenses/MIT)                        *
+ *******************************************************************************/
+
+package agents
+
+import (
+	fmt "fmt"
+	abs "github.com/bali-nebula/go-component-framework/v2/abstractions"
+	exp "github.com/bali-nebula/go-component-framework/v2/expressions"
+	pro "github.com/bali-nebula/go-component-framework/v2/procedures"
+)
+
+// CHECKER IMPLEMENTATION
+
+// This constructor creates a new checker that verifies that each intrinsic
+// function named in a procedure is defined in the specified registry and is
+// passed the number of arguments that its signature requires.
+func Checker(registry abs.RegistryLike) abs.CheckerLike {
+	if registry == nil {
+		panic("A checker requires a registry of intrinsic functions.")
+	}
+	return &checker{registry: registry}
+}
+
+// This type defines the structure and methods associated with a checker agent.
+type checker struct {
+	registry abs.RegistryLike
+	problems []string
+}
+
+// PUBLIC INTERFACE
+
+// This method checks the specified procedure and returns a description of each
+// problem that was found, in the order that they occur in the procedure.
+func (v *checker) CheckProcedure(procedure abs.ProcedureLike) []string {
+	v.problems = nil
+	v.checkProcedure(procedure)
+	return v.problems
+}
+
+// PRIVATE METHODS
+
+// This private method checks each statement in the specified procedure.
+func (v *checker) checkProcedure(procedure abs.ProcedureLike) {
+	for _, statement := range procedure.AsArray() {
+		if statement == nil {
+			// Skip any blank lines in the procedure.
+			continue
+		}
+		v.checkClause(statement.GetMainClause())
+		var onClause = statement.GetOnClause()
+		if onClause != nil {
+			v.checkBlocks(onClause.GetBlocks())
+		}
+	}
+}
+
+// This private method checks the expressions and procedures in the specified
+// blocks.
+func (v *checker) checkBlocks(blocks abs.Sequential[abs.BlockLike]) {
+	for _, block := range blocks.AsArray() {
+		v.checkBlock(block)
+	}
+}
+
+// This private method checks the expression and procedure in the specified
+// block.
+func (v *checker) checkBlock(block abs.BlockLike) {
+	v.checkExpression(block.GetExpression())
+	v.checkProcedure(block.GetProcedure())
+}
+
+// This private method checks the expressions and procedures in the specified
+// clause.
+func (v *checker) checkClause(clause abs.Clause) {
+	switch pro.GetType(clause) {
+	case "AcceptClause":
+		v.checkExpression(clause.(abs.AcceptClauseLike).GetMessage())
+	case "CheckoutClause":
+		var checkout = clause.(abs.CheckoutClauseLike)
+		v.checkRecipient(checkout.GetRecipient())
+		v.checkExpression(checkout.GetLevel())
+		v.checkExpression(checkout.GetName())
+	case "DiscardClause":
+		v.checkExpression(clause.(abs.DiscardClauseLike).GetDocument())
+	case "IfClause":
+		v.checkBlock(clause.(abs.IfClauseLike).GetBlock())
+	case "LetClause":
+		var let = clause.(abs.LetClauseLike)
+		if let.HasRecipient() {
+			var recipient, _ = let.GetRecipient()
+			v.checkRecipient(recipient)
+		}
+		v.checkExpression(let.GetExpression())
+	case "NotarizeClause":
+		var notarize = clause.(abs.NotarizeClauseLike)
+		v.checkExpression(notarize.GetDocument())
+		v.checkExpression(notarize.GetName())
+	case "PostClause":
+		var post = clause.(abs.PostClauseLike)
+		v.checkExpression(post.GetMessage())
+		v.checkExpression(post.GetBag())
+	case "PublishClause":
+		v.checkExpression(clause.(abs.PublishClauseLike).GetEvent())
+	case "RejectClause":
+		v.checkExpression(clause.(abs.RejectClauseLike).GetMessage())
+	case "RetrieveClause":
+		var retrieve = clause.(abs.RetrieveClauseLike)
+		v.checkRecipient(retrieve.GetRecipient())
+		v.checkExpression(retrieve.GetBag())
+	case "ReturnClause":
+		v.checkExpression(clause.(abs.ReturnClauseLike).GetResult())
+	case "SaveClause":
+		var save = clause.(abs.SaveClauseLike)
+		v.checkExpression(save.GetDocument())
+		v.checkRecipient(save.GetRecipient())
+	case "SelectClause":
+		var selectClause = clause.(abs.SelectClauseLike)
+		v.checkExpression(selectClause.GetTarget())
+		v.checkBlocks(selectClause.GetBlocks())
+	case "ThrowClause":
+		v.checkExpression(clause.(abs.ThrowClauseLike).GetException())
+	case "WhileClause":
+		v.checkBlock(clause.(abs.WhileClauseLike).GetBlock())
+	case "WithClause":
+		v.checkBlock(clause.(abs.WithClauseLike).GetBlock())
+	}
+}
+
+// This private method checks the indices of the specified recipient if it is
+// an attribute.
+func (v *checker) checkRecipient(recipient abs.Recipient) {
+	if attribute, ok := recipient.(abs.AttributeLike); ok {
+		v.checkExpressions(attribute.GetIndices())
+	}
+}
+
+// This private method checks each of the specified expressions.
+func (v *checker) checkExpressions(expressions abs.Sequential[abs.Expression]) {
+	for _, expression := range expressions.AsArray() {
+		v.checkExpression(expression)
+	}
+}
+
+// This private method checks the specified expression and all of its
+// subexpressions.
+func (v *checker) checkExpression(expression abs.Expression) {
+	if expression == nil {
+		return
+	}
+	switch exp.GetType(expression) {
+	case "IntrinsicExpression":
+		var intrinsic = expression.(abs.IntrinsicLike)
+		v.checkIntrinsic(intrinsic)
+		v.checkExpressions(intrinsic.GetArguments())
+	case "InvocationExpression":
+		var invocation = expression.(abs.InvocationLike)
+		v.checkExpression(invocation.GetTarget())
+		v.checkExpressions(invocation.GetArguments())
+	case "SubcomponentExpression":
+		var subcomponent = expression.(abs.SubcomponentLike)
+		v.checkExpression(subcomponent.GetComposite())
+		v.checkExpressions(subcomponent.GetIndices())
+	case "PrecedenceExpression", "DereferenceExpression", "InversionExpression",
+		"MagnitudeExpression", "ComplementExpression":
+		v.checkExpression(expression.(abs.UnaryOperationLike).GetExpression())
+	case "ChainingExpression", "ExponentialExpression", "ArithmeticExpression",
+		"ComparisonExpression", "LogicalExpression":
+		var operation = expression.(abs.BinaryOperationLike)
+		v.checkExpression(operation.GetFirst())
+		v.checkExpression(operation.GetSecond())
+	}
+}
+
+// This private method verifies that the specified intrinsic function is
+// defined and is passed the right number of arguments.
+func (v *checker) checkIntrinsic(intrinsic abs.IntrinsicLike) {
+	var name = intrinsic.GetFunction()
+	var signature = v.registry.GetSignature(name)
+	if signature == nil {
+		var problem = fmt.Sprintf("The intrinsic function $%v is not defined.", name)
+		v.problems = append(v.problems, problem)
+		return
+	}
+	var count = intrinsic.GetArguments().GetSize()
+	if count != signature.GetArity() {
+		var problem = fmt.Sprintf("The intrinsic function $%v requires %v arguments but was passed %v.", name, signature.GetArity(), count)
+		v.problems = append(v.problems, problem)
+	}
+}
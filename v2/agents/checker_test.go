@@ -0,0 +1,53 @@
+/*******************************************************************************
+ *   Copyright (c) 2009-2023 Crater Dog Technologies™.  All Rights Reserved.   *
+ *******************************************************************************
+ * DO NOT ALTER OR REMOVE COPYRIGHT NOTICES OR THIS FILE HEADER.               *
+ *                                                                             *
+ * This code is free software; you can redistribute it and/or modify it under  *
+ * the terms of The MIT License (MIT), as published by the Open Source         *
+ * Initiative. (See http://opensource.org/licenses/MIT)                        *
+ *******************************************************************************/
+
+package agents_test
+
+import (
+	abs "github.com/bali-nebula/go-component-framework/v2/abstractions"
+	age "github.com/bali-nebula/go-component-framework/v2/agents"
+	com "github.com/bali-nebula/go-component-framework/v2/components"
+	exp "github.com/bali-nebula/go-component-framework/v2/expressions"
+	ins "github.com/bali-nebula/go-component-framework/v2/intrinsics"
+	pro "github.com/bali-nebula/go-component-framework/v2/procedures"
+	ass "github.com/stretchr/testify/assert"
+	tes "testing"
+)
+
+func TestCheckProcedure(t *tes.T) {
+	var checker = age.Checker(ins.StandardLibrary())
+
+	// let $x := sine(arctangent(1, 1))
+	// if cube(x) > 1 do {
+	//     return uppercase("a", "b")
+	// }
+	var code = procedure(
+		let("x", abs.ASSIGN, exp.Intrinsic("sine", arguments(
+			exp.Intrinsic("arctangent", arguments(number(1), number(1))),
+		))),
+		statement(pro.IfClause(pro.Block(
+			exp.Comparison(exp.Intrinsic("cube", arguments(exp.Variable("x"))), abs.MORE, number(1)),
+			procedure(statement(pro.ReturnClause(exp.Intrinsic("uppercase", arguments(quote("a"), quote("b")))))),
+		))),
+	)
+	ass.Equal(t, []string{
+		"The intrinsic function $cube is not defined.",
+		"The intrinsic function $uppercase requires 1 arguments but was passed 2.",
+	}, checker.CheckProcedure(code))
+	ass.Empty(t, checker.CheckProcedure(procedure(let("x", abs.ASSIGN, number(1)))))
+}
+
+func TestEvaluateIntrinsics(t *tes.T) {
+	var evaluator = age.Evaluator(com.Context())
+
+	// size("bali") + 1
+	var expression = exp.Arithmetic(exp.Intrinsic("size", arguments(quote("bali"))), abs.PLUS, number(1))
+	ass.Equal(t, 5.0, evaluator.EvaluateExpression(expression).ExtractNumber().GetReal())
+}
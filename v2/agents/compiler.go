@@ -0,0 +1,733 @@
+/*******************************************************************************
+ *   Copyright (c) 2009-2023 Crater Dog Technologies™.  All Rights Reserved.   *
+ *******************************************************************************
+ * DO NOT ALTER OR REMOVE COPYRIGHT NOTICES OR THIS FILE HEADER.               *
+ *                                                                             *
+ * This code is free software; you can redistribute it and/or modify it under  *
+ * the terms of The MIT License (MIT), as published by the Open Source         *
+ * Initiative. (See http://opensource.org/licenses/MIT)                        *
+ *******************************************************************************/
+
+package agents
+
+import (
+	fmt "fmt"
+	abs "github.com/bali-nebula/go-component-framework/v2/abstractions"
+	bal "github.com/bali-nebula/go-component-framework/v2/bali"
+	exp "github.com/bali-nebula/go-component-framework/v2/expressions"
+	pro "github.com/bali-nebula/go-component-framework/v2/procedures"
+	prg "github.com/bali-nebula/go-component-framework/v2/programs"
+	str "github.com/bali-nebula/go-component-framework/v2/strings"
+	cox "github.com/craterdog/go-collection-framework/v2"
+	sts "strings"
+)
+
+// COMPILER IMPLEMENTATION
+
+// This constructor creates a new compiler that lowers procedures into bytecode
+// programs. Each clause and expression is compiled into a sequence of the
+// instructions defined in the abstractions package. All operators are compiled
+// into invocations of the following built-in functions, which any virtual
+// machine executing the resulting program must provide:
+//
+//	chaining:     $concatenation-2
+//	exponential:  $power-2
+//	inversion:    $inverse-1  $reciprocal-1  $conjugate-1
+//	arithmetic:   $sum-2  $difference-2  $product-2  $quotient-2  $remainder-2
+//	magnitude:    $magnitude-1
+//	comparison:   $less-2  $equal-2  $unequal-2  $more-2  $is-2  $matches-2
+//	complement:   $not-1
+//	logical:      $and-2  $sans-2  $or-2  $xor-2
+//	dereference:  $dereference-1
+//	subcomponent: $attribute-2  $setAttribute-3
+//	iteration:    $iterator-1  $hasNext-1  $getNext-1
+//	messages:     $accept-1  $reject-1  $publish-1
+//	documents:    $checkout-1  $checkout-2  $discard-1
+//
+// Each built-in function is named by a reserved symbol of the form $name-N,
+// where N is the number of arguments it takes, so that it cannot collide with
+// an intrinsic function, whose name is always an identifier. Any intrinsic
+// functions that are named in the procedure are invoked directly.
+func Compiler() abs.CompilerLike {
+	return &compiler{}
+}
+
+// This type defines the structure and methods associated with a compiler
+// agent.
+type compiler struct {
+	instructions []abs.Instruction
+	source       []string
+	line         string
+	literals     cox.ListLike[abs.ComponentLike]
+	symbols      cox.ListLike[abs.SymbolLike]
+	indices      map[string]int
+	loops        []*loop
+	handlers     int
+	temporaries  int
+}
+
+// This type keeps track of the addresses needed to compile the break loop and
+// continue loop clauses within the body of a loop.
+type loop struct {
+	start    int
+	breaks   []int
+	handlers int
+}
+
+// These maps define the names of the built-in functions that implement each
+// operator.
+var (
+	inversions = map[abs.Operator]string{
+		abs.MINUS: "inverse",
+		abs.SLASH: "reciprocal",
+		abs.STAR:  "conjugate",
+	}
+	operations = map[abs.Operator]string{
+		abs.AMPERSAND: "concatenation",
+		abs.CARET:     "power",
+		abs.PLUS:      "sum",
+		abs.MINUS:     "difference",
+		abs.STAR:      "product",
+		abs.SLASH:     "quotient",
+		abs.MODULO:    "remainder",
+		abs.LESS:      "less",
+		abs.EQUAL:     "equal",
+		abs.UNEQUAL:   "unequal",
+		abs.MORE:      "more",
+		abs.IS:        "is",
+		abs.MATCHES:   "matches",
+		abs.AND:       "and",
+		abs.SANS:      "sans",
+		abs.OR:        "or",
+		abs.XOR:       "xor",
+	}
+	assignments = map[abs.Operator]string{
+		abs.SUM:        "sum",
+		abs.DIFFERENCE: "difference",
+		abs.PRODUCT:    "product",
+		abs.QUOTIENT:   "quotient",
+	}
+)
+
+// This function returns the reserved symbol that names the built-in function
+// with the specified name and number of arguments.
+func operatorSymbol(name string, count int) string {
+	return fmt.Sprintf("%v-%v", name, count)
+}
+
+// PUBLIC INTERFACE
+
+// This method compiles the specified procedure into a new program.
+func (v *compiler) CompileProcedure(procedure abs.ProcedureLike) abs.ProgramLike {
+	if procedure == nil {
+		panic("The compiler requires a procedure to compile.")
+	}
+	v.instructions = nil
+	v.source = nil
+	v.line = ""
+	v.literals = cox.List[abs.ComponentLike]()
+	v.symbols = cox.List[abs.SymbolLike]()
+	v.indices = make(map[string]int)
+	v.loops = nil
+	v.handlers = 0
+	v.temporaries = 0
+	v.compileProcedure(procedure)
+	if v.getAddress() > abs.MaximumOperand {
+		var message = fmt.Sprintf("The compiled procedure exceeds the maximum number of instructions: %v", abs.MaximumOperand-1)
+		panic(message)
+	}
+	var bytecode = str.BytecodeFromArray(v.instructions)
+	return prg.ProgramWithSource(bytecode, v.literals, v.symbols, v.source)
+}
+
+// PRIVATE METHODS
+
+// This private method returns the address of the next instruction that will be
+// emitted.
+func (v *compiler) getAddress() int {
+	return len(v.instructions) + 1
+}
+
+// This private method appends a new instruction to the bytecode and returns
+// its address.
+func (v *compiler) emit(operation abs.Operation, modifier abs.Modifier, operand int) int {
+	var address = v.getAddress()
+	var instruction = abs.InstructionFromOperation(operation, modifier, operand)
+	v.instructions = append(v.instructions, instruction)
+	v.source = append(v.source, v.line)
+	return address
+}
+
+// This private method sets the operand of the jump instruction at the
+// specified address to the specified target address.
+func (v *compiler) patch(address int, target int) {
+	var instruction = v.instructions[address-1]
+	v.instructions[address-1] = abs.InstructionFromOperation(
+		instruction.GetOperation(),
+		instruction.GetModifier(),
+		target,
+	)
+}
+
+// This private method adds the specified literal value to the literal table
+// and returns its index.
+func (v *compiler) addLiteral(literal abs.ComponentLike) int {
+	v.literals.AddValue(literal)
+	var index = v.literals.GetSize()
+	v.checkOperand(index, "literals")
+	return index
+}
+
+// This private method adds the specified identifier to the symbol table if it
+// is not already there and returns its index.
+func (v *compiler) addSymbol(identifier string) int {
+	var index, ok = v.indices[identifier]
+	if !ok {
+		v.symbols.AddValue(str.SymbolFromString(identifier))
+		index = v.symbols.GetSize()
+		v.checkOperand(index, "symbols")
+		v.indices[identifier] = index
+	}
+	return index
+}
+
+// This private method returns the identifier for a new temporary variable
+// using the specified prefix. Temporary identifiers have the form "prefix-N"
+// so they cannot collide with the identifier of any variable expression.
+func (v *compiler) newTemporary(prefix string) string {
+	v.temporaries++
+	return fmt.Sprintf("%v-%v", prefix, v.temporaries)
+}
+
+// This private method panics if the specified index does not fit in the
+// operand of an instruction.
+func (v *compiler) checkOperand(index int, table string) {
+	if index > abs.MaximumOperand {
+		var message = fmt.Sprintf("The compiled procedure exceeds the maximum number of %v: %v", table, abs.MaximumOperand)
+		panic(message)
+	}
+}
+
+// This private method emits the instruction that invokes the specified
+// intrinsic function with the specified number of arguments.
+func (v *compiler) emitInvoke(function string, count int) {
+	if count > abs.MaximumModifier {
+		var message = fmt.Sprintf("The intrinsic function $%v takes more than %v arguments: %v", function, abs.MaximumModifier, count)
+		panic(message)
+	}
+	v.emit(abs.INVOKE, abs.Modifier(count), v.addSymbol(function))
+}
+
+// This private method emits the instruction that invokes the built-in function
+// with the specified name and number of arguments.
+func (v *compiler) emitOperator(name string, count int) {
+	v.emit(abs.INVOKE, abs.Modifier(count), v.addSymbol(operatorSymbol(name, count)))
+}
+
+// This private method compiles each statement in the specified procedure.
+func (v *compiler) compileProcedure(procedure abs.ProcedureLike) {
+	for _, statement := range procedure.AsArray() {
+		if statement == nil {
+			// Skip any blank lines in the procedure.
+			continue
+		}
+		v.compileStatement(statement)
+	}
+}
+
+// This private method compiles the specified statement. A statement with an on
+// clause is compiled as follows:
+//
+//	    PUSH HANDLER handler
+//	    <main clause>
+//	    PULL HANDLER
+//	    JUMP ALWAYS done
+//	handler:
+//	    STORE VARIABLE $failure
+//	    <each matching block>
+//	    LOAD VARIABLE $failure
+//	    PULL EXCEPTION
+//	done:
+func (v *compiler) compileStatement(statement abs.StatementLike) {
+	// Each instruction records the first line of the canonical source for the
+	// innermost statement that it was compiled from.
+	var enclosing = v.line
+	defer func() { v.line = enclosing }()
+	var source = bal.FormatClause(statement.GetMainClause())
+	v.line, _, _ = sts.Cut(source, bal.EOL)
+	var onClause = statement.GetOnClause()
+	if onClause == nil {
+		v.compileClause(statement.GetMainClause())
+		return
+	}
+	var push = v.emit(abs.PUSH, abs.HANDLER, 0)
+	v.handlers++
+	v.compileClause(statement.GetMainClause())
+	v.handlers--
+	v.emit(abs.PULL, abs.HANDLER, 0)
+	var skip = v.emit(abs.JUMP, abs.ALWAYS, 0)
+	v.patch(push, v.getAddress())
+	var failure = onClause.GetFailure().AsString()
+	v.emit(abs.STORE, abs.VARIABLE, v.addSymbol(failure))
+	var done = v.compileMatchingBlocks(failure, onClause.GetBlocks())
+	v.emit(abs.LOAD, abs.VARIABLE, v.addSymbol(failure))
+	v.emit(abs.PULL, abs.EXCEPTION, 0)
+	done = append(done, skip)
+	for _, address := range done {
+		v.patch(address, v.getAddress())
+	}
+}
+
+// This private method compiles the specified blocks so that the procedure of
+// the first block whose pattern matches the value of the specified variable is
+// executed. It returns the addresses of the jumps that must be patched to skip
+// past the code that follows the blocks.
+func (v *compiler) compileMatchingBlocks(variable string, blocks abs.Sequential[abs.BlockLike]) []int {
+	var done []int
+	for _, block := range blocks.AsArray() {
+		v.emit(abs.LOAD, abs.VARIABLE, v.addSymbol(variable))
+		v.compileExpression(block.GetExpression())
+		v.emitOperator("matches", 2)
+		var next = v.emit(abs.JUMP, abs.ONFALSE, 0)
+		v.compileProcedure(block.GetProcedure())
+		done = append(done, v.emit(abs.JUMP, abs.ALWAYS, 0))
+		v.patch(next, v.getAddress())
+	}
+	return done
+}
+
+// This private method compiles the specified clause.
+func (v *compiler) compileClause(clause abs.Clause) {
+	switch pro.GetType(clause) {
+	case "AcceptClause":
+		v.compileExpression(clause.(abs.AcceptClauseLike).GetMessage())
+		v.emitOperator("accept", 1)
+		v.emit(abs.PULL, abs.COMPONENT, 0)
+	case "BreakClause":
+		v.compileBreak()
+	case "CheckoutClause":
+		v.compileCheckout(clause.(abs.CheckoutClauseLike))
+	case "ContinueClause":
+		v.compileContinue()
+	case "DiscardClause":
+		v.compileExpression(clause.(abs.DiscardClauseLike).GetDocument())
+		v.emitOperator("discard", 1)
+		v.emit(abs.PULL, abs.COMPONENT, 0)
+	case "IfClause":
+		v.compileIf(clause.(abs.IfClauseLike))
+	case "LetClause":
+		v.compileLet(clause.(abs.LetClauseLike))
+	case "NotarizeClause":
+		v.compileNotarize(clause.(abs.NotarizeClauseLike))
+	case "PostClause":
+		v.compilePost(clause.(abs.PostClauseLike))
+	case "PublishClause":
+		v.compileExpression(clause.(abs.PublishClauseLike).GetEvent())
+		v.emitOperator("publish", 1)
+		v.emit(abs.PULL, abs.COMPONENT, 0)
+	case "RejectClause":
+		v.compileExpression(clause.(abs.RejectClauseLike).GetMessage())
+		v.emitOperator("reject", 1)
+		v.emit(abs.PULL, abs.COMPONENT, 0)
+	case "RetrieveClause":
+		v.compileRetrieve(clause.(abs.RetrieveClauseLike))
+	case "ReturnClause":
+		v.compileExpression(clause.(abs.ReturnClauseLike).GetResult())
+		v.emit(abs.PULL, abs.RESULT, 0)
+	case "SaveClause":
+		v.compileSave(clause.(abs.SaveClauseLike))
+	case "SelectClause":
+		v.compileSelect(clause.(abs.SelectClauseLike))
+	case "ThrowClause":
+		v.compileExpression(clause.(abs.ThrowClauseLike).GetException())
+		v.emit(abs.PULL, abs.EXCEPTION, 0)
+	case "WhileClause":
+		v.compileWhile(clause.(abs.WhileClauseLike))
+	case "WithClause":
+		v.compileWith(clause.(abs.WithClauseLike))
+	default:
+		var message = fmt.Sprintf("An invalid clause type was found: %T", clause)
+		panic(message)
+	}
+}
+
+// This private method compiles a break loop clause. Any handlers that were
+// pushed within the loop are pulled before jumping out of it.
+func (v *compiler) compileBreak() {
+	if len(v.loops) == 0 {
+		panic("A break loop clause must be inside of a loop.")
+	}
+	var current = v.loops[len(v.loops)-1]
+	for i := current.handlers; i < v.handlers; i++ {
+		v.emit(abs.PULL, abs.HANDLER, 0)
+	}
+	current.breaks = append(current.breaks, v.emit(abs.JUMP, abs.ALWAYS, 0))
+}
+
+// This private method compiles a continue loop clause. Any handlers that were
+// pushed within the loop are pulled before jumping back to its start.
+func (v *compiler) compileContinue() {
+	if len(v.loops) == 0 {
+		panic("A continue loop clause must be inside of a loop.")
+	}
+	var current = v.loops[len(v.loops)-1]
+	for i := current.handlers; i < v.handlers; i++ {
+		v.emit(abs.PULL, abs.HANDLER, 0)
+	}
+	v.emit(abs.JUMP, abs.ALWAYS, current.start)
+}
+
+// This private method compiles the body of a loop that starts at the specified
+// address and then jumps back to that address.
+func (v *compiler) compileLoop(start int, procedure abs.ProcedureLike) []int {
+	var current = &loop{start: start, handlers: v.handlers}
+	v.loops = append(v.loops, current)
+	v.compileProcedure(procedure)
+	v.loops = v.loops[:len(v.loops)-1]
+	v.emit(abs.JUMP, abs.ALWAYS, start)
+	return current.breaks
+}
+
+// This private method compiles an if clause as follows:
+//
+//	    <condition>
+//	    JUMP ONFALSE done
+//	    <procedure>
+//	done:
+func (v *compiler) compileIf(clause abs.IfClauseLike) {
+	var block = clause.GetBlock()
+	v.compileExpression(block.GetExpression())
+	var done = v.emit(abs.JUMP, abs.ONFALSE, 0)
+	v.compileProcedure(block.GetProcedure())
+	v.patch(done, v.getAddress())
+}
+
+// This private method compiles a while clause as follows:
+//
+//	start:
+//	    <condition>
+//	    JUMP ONFALSE done
+//	    <procedure>
+//	    JUMP ALWAYS start
+//	done:
+func (v *compiler) compileWhile(clause abs.WhileClauseLike) {
+	var block = clause.GetBlock()
+	var start = v.getAddress()
+	v.compileExpression(block.GetExpression())
+	var done = []int{v.emit(abs.JUMP, abs.ONFALSE, 0)}
+	done = append(done, v.compileLoop(start, block.GetProcedure())...)
+	for _, address := range done {
+		v.patch(address, v.getAddress())
+	}
+}
+
+// This private method compiles a with each clause as follows:
+//
+//	    <sequence>
+//	    INVOKE $iterator-1 1
+//	    STORE VARIABLE $iterator-N
+//	start:
+//	    LOAD VARIABLE $iterator-N
+//	    INVOKE $hasNext-1 1
+//	    JUMP ONFALSE done
+//	    LOAD VARIABLE $iterator-N
+//	    INVOKE $getNext-1 1
+//	    STORE VARIABLE $item
+//	    <procedure>
+//	    JUMP ALWAYS start
+//	done:
+func (v *compiler) compileWith(clause abs.WithClauseLike) {
+	var block = clause.GetBlock()
+	var iterator = v.addSymbol(v.newTemporary("iterator"))
+	v.compileExpression(block.GetExpression())
+	v.emitOperator("iterator", 1)
+	v.emit(abs.STORE, abs.VARIABLE, iterator)
+	var start = v.emit(abs.LOAD, abs.VARIABLE, iterator)
+	v.emitOperator("hasNext", 1)
+	var done = []int{v.emit(abs.JUMP, abs.ONFALSE, 0)}
+	v.emit(abs.LOAD, abs.VARIABLE, iterator)
+	v.emitOperator("getNext", 1)
+	v.emit(abs.STORE, abs.VARIABLE, v.addSymbol(clause.GetItem().AsString()))
+	done = append(done, v.compileLoop(start, block.GetProcedure())...)
+	for _, address := range done {
+		v.patch(address, v.getAddress())
+	}
+}
+
+// This private method compiles a select clause by storing the value of its
+// target in a temporary variable and then matching it against each block.
+func (v *compiler) compileSelect(clause abs.SelectClauseLike) {
+	var target = v.newTemporary("target")
+	v.compileExpression(clause.GetTarget())
+	v.emit(abs.STORE, abs.VARIABLE, v.addSymbol(target))
+	var done = v.compileMatchingBlocks(target, clause.GetBlocks())
+	for _, address := range done {
+		v.patch(address, v.getAddress())
+	}
+}
+
+// This private method compiles a let clause. The default assignment operator
+// is compiled as follows:
+//
+//	    <recipient>
+//	    JUMP ONNONE assign
+//	    JUMP ALWAYS done
+//	assign:
+//	    <expression>
+//	    <store recipient>
+//	done:
+//
+// Since the default and compound assignment operators both load and store the
+// recipient, the indices of an attribute recipient are first evaluated into
+// temporary variables so that each index expression is evaluated only once.
+func (v *compiler) compileLet(clause abs.LetClauseLike) {
+	var expression = clause.GetExpression()
+	if !clause.HasRecipient() {
+		v.compileExpression(expression)
+		v.emit(abs.PULL, abs.COMPONENT, 0)
+		return
+	}
+	var recipient, operator = clause.GetRecipient()
+	if operator != abs.ASSIGN {
+		recipient = v.compileIndices(recipient)
+	}
+	switch operator {
+	case abs.ASSIGN:
+		v.compileStore(recipient, func() {
+			v.compileExpression(expression)
+		})
+	case abs.DEFAULT:
+		v.compileLoad(recipient)
+		var assign = v.emit(abs.JUMP, abs.ONNONE, 0)
+		var done = v.emit(abs.JUMP, abs.ALWAYS, 0)
+		v.patch(assign, v.getAddress())
+		v.compileStore(recipient, func() {
+			v.compileExpression(expression)
+		})
+		v.patch(done, v.getAddress())
+	default:
+		v.compileStore(recipient, func() {
+			v.compileLoad(recipient)
+			v.compileExpression(expression)
+			v.emitOperator(assignments[operator], 2)
+		})
+	}
+}
+
+// This private method compiles the instructions that evaluate each index of
+// the specified attribute recipient and store its value in a new temporary
+// variable. It returns an equivalent attribute whose indices are those
+// temporary variables. Any other recipient is returned unchanged.
+func (v *compiler) compileIndices(recipient abs.Recipient) abs.Recipient {
+	var attribute, ok = recipient.(abs.AttributeLike)
+	if !ok {
+		return recipient
+	}
+	var indices = cox.List[abs.Expression]()
+	for _, index := range attribute.GetIndices().AsArray() {
+		var temporary = v.newTemporary("index")
+		v.compileExpression(index)
+		v.emit(abs.STORE, abs.VARIABLE, v.addSymbol(temporary))
+		indices.AddValue(exp.Variable(temporary))
+	}
+	return pro.Attribute(attribute.GetVariable(), indices)
+}
+
+// This private method compiles the instructions that load the value of the
+// specified recipient onto the component stack.
+func (v *compiler) compileLoad(recipient abs.Recipient) {
+	switch value := recipient.(type) {
+	case abs.SymbolLike:
+		v.emit(abs.LOAD, abs.VARIABLE, v.addSymbol(value.AsString()))
+	case abs.AttributeLike:
+		v.emit(abs.LOAD, abs.VARIABLE, v.addSymbol(value.GetVariable()))
+		for _, index := range value.GetIndices().AsArray() {
+			v.compileExpression(index)
+			v.emitOperator("attribute", 2)
+		}
+	default:
+		var message = fmt.Sprintf("An invalid recipient type was found: %T", value)
+		panic(message)
+	}
+}
+
+// This private method compiles the instructions that store the value pushed by
+// the specified function into the specified recipient.
+func (v *compiler) compileStore(recipient abs.Recipient, compileValue func()) {
+	switch value := recipient.(type) {
+	case abs.SymbolLike:
+		compileValue()
+		v.emit(abs.STORE, abs.VARIABLE, v.addSymbol(value.AsString()))
+	case abs.AttributeLike:
+		var indices = value.GetIndices().AsArray()
+		var last = len(indices) - 1
+		v.emit(abs.LOAD, abs.VARIABLE, v.addSymbol(value.GetVariable()))
+		for _, index := range indices[:last] {
+			v.compileExpression(index)
+			v.emitOperator("attribute", 2)
+		}
+		v.compileExpression(indices[last])
+		compileValue()
+		v.emitOperator("setAttribute", 3)
+		v.emit(abs.PULL, abs.COMPONENT, 0)
+	default:
+		var message = fmt.Sprintf("An invalid recipient type was found: %T", value)
+		panic(message)
+	}
+}
+
+// This private method compiles the instructions that evaluate the specified
+// expression and store its value in a new temporary variable. It returns the
+// symbol index of the temporary variable.
+func (v *compiler) compileTemporary(prefix string, expression abs.Expression) int {
+	var temporary = v.addSymbol(v.newTemporary(prefix))
+	v.compileExpression(expression)
+	v.emit(abs.STORE, abs.VARIABLE, temporary)
+	return temporary
+}
+
+// This private method compiles a checkout clause as follows:
+//
+//	<name>
+//	STORE VARIABLE $name-N
+//	<store recipient>
+//	    LOAD DOCUMENT $name-N
+//	    [<level>]
+//	    INVOKE $checkout-1 1 | $checkout-2 2
+func (v *compiler) compileCheckout(clause abs.CheckoutClauseLike) {
+	var name = v.compileTemporary("name", clause.GetName())
+	v.compileStore(clause.GetRecipient(), func() {
+		v.emit(abs.LOAD, abs.DOCUMENT, name)
+		var level = clause.GetLevel()
+		if level == nil {
+			v.emitOperator("checkout", 1)
+		} else {
+			v.compileExpression(level)
+			v.emitOperator("checkout", 2)
+		}
+	})
+}
+
+// This private method compiles a notarize clause as follows:
+//
+//	<name>
+//	STORE VARIABLE $name-N
+//	<document>
+//	STORE DOCUMENT $name-N
+func (v *compiler) compileNotarize(clause abs.NotarizeClauseLike) {
+	var name = v.compileTemporary("name", clause.GetName())
+	v.compileExpression(clause.GetDocument())
+	v.emit(abs.STORE, abs.DOCUMENT, name)
+}
+
+// This private method compiles a post clause as follows:
+//
+//	<bag>
+//	STORE VARIABLE $bag-N
+//	<message>
+//	STORE MESSAGE $bag-N
+func (v *compiler) compilePost(clause abs.PostClauseLike) {
+	var bag = v.compileTemporary("bag", clause.GetBag())
+	v.compileExpression(clause.GetMessage())
+	v.emit(abs.STORE, abs.MESSAGE, bag)
+}
+
+// This private method compiles a retrieve clause as follows:
+//
+//	<bag>
+//	STORE VARIABLE $bag-N
+//	<store recipient>
+//	    LOAD MESSAGE $bag-N
+func (v *compiler) compileRetrieve(clause abs.RetrieveClauseLike) {
+	var bag = v.compileTemporary("bag", clause.GetBag())
+	v.compileStore(clause.GetRecipient(), func() {
+		v.emit(abs.LOAD, abs.MESSAGE, bag)
+	})
+}
+
+// This private method compiles a save clause as follows:
+//
+//	<document>
+//	STORE DRAFT $citation-N
+//	<store recipient>
+//	    LOAD VARIABLE $citation-N
+func (v *compiler) compileSave(clause abs.SaveClauseLike) {
+	var citation = v.addSymbol(v.newTemporary("citation"))
+	v.compileExpression(clause.GetDocument())
+	v.emit(abs.STORE, abs.DRAFT, citation)
+	v.compileStore(clause.GetRecipient(), func() {
+		v.emit(abs.LOAD, abs.VARIABLE, citation)
+	})
+}
+
+// This private method compiles the instructions that push the value of the
+// specified expression onto the component stack.
+func (v *compiler) compileExpression(expression abs.Expression) {
+	switch exp.GetType(expression) {
+	case "ValueExpression":
+		var literal = v.addLiteral(expression.(abs.ValueLike).GetComponent())
+		v.emit(abs.PUSH, abs.LITERAL, literal)
+	case "IntrinsicExpression":
+		var intrinsic = expression.(abs.IntrinsicLike)
+		var arguments = intrinsic.GetArguments().AsArray()
+		for _, argument := range arguments {
+			v.compileExpression(argument)
+		}
+		v.emitInvoke(intrinsic.GetFunction(), len(arguments))
+	case "VariableExpression":
+		var identifier = expression.(abs.VariableLike).GetIdentifier()
+		v.emit(abs.LOAD, abs.VARIABLE, v.addSymbol(identifier))
+	case "PrecedenceExpression":
+		v.compileExpression(expression.(abs.UnaryOperationLike).GetExpression())
+	case "DereferenceExpression":
+		v.compileExpression(expression.(abs.UnaryOperationLike).GetExpression())
+		v.emitOperator("dereference", 1)
+	case "InvocationExpression":
+		v.compileInvocation(expression.(abs.InvocationLike))
+	case "SubcomponentExpression":
+		var subcomponent = expression.(abs.SubcomponentLike)
+		v.compileExpression(subcomponent.GetComposite())
+		for _, index := range subcomponent.GetIndices().AsArray() {
+			v.compileExpression(index)
+			v.emitOperator("attribute", 2)
+		}
+	case "InversionExpression":
+		var inversion = expression.(abs.UnaryOperationLike)
+		v.compileExpression(inversion.GetExpression())
+		v.emitOperator(inversions[inversion.GetOperator()], 1)
+	case "MagnitudeExpression":
+		v.compileExpression(expression.(abs.UnaryOperationLike).GetExpression())
+		v.emitOperator("magnitude", 1)
+	case "ComplementExpression":
+		v.compileExpression(expression.(abs.UnaryOperationLike).GetExpression())
+		v.emitOperator("not", 1)
+	default:
+		// All remaining expressions are binary operations.
+		var operation = expression.(abs.BinaryOperationLike)
+		v.compileExpression(operation.GetFirst())
+		v.compileExpression(operation.GetSecond())
+		v.emitOperator(operations[operation.GetOperator()], 2)
+	}
+}
+
+// This private method compiles a message invocation on a target component. A
+// synchronous (.) invocation is compiled into a CALL instruction and an
+// asynchronous (<-) invocation is compiled into a SEND instruction.
+func (v *compiler) compileInvocation(invocation abs.InvocationLike) {
+	var method = invocation.GetMethod()
+	var arguments = invocation.GetArguments().AsArray()
+	var count = len(arguments)
+	if count > abs.MaximumModifier {
+		var message = fmt.Sprintf("The method $%v takes more than %v arguments: %v", method, abs.MaximumModifier, count)
+		panic(message)
+	}
+	v.compileExpression(invocation.GetTarget())
+	for _, argument := range arguments {
+		v.compileExpression(argument)
+	}
+	var operation = abs.CALL
+	if !invocation.IsSynchronous() {
+		operation = abs.SEND
+	}
+	v.emit(operation, abs.Modifier(count), v.addSymbol(method))
+}
@@ -0,0 +1,152 @@
+/*******************************************************************************
+ *   Copyright (c) 2009-2023 Crater Dog Technologies™.  All Rights Reserved.   *
+ *******************************************************************************
+ * DO NOT ALTER OR REMOVE COPYRIGHT NOTICES OR THIS FILE HEADER.               *
+ *                                                                             *
+ * This code is free software; you can redistribute it and/or modify it under  *
+ * the terms of The MIT License (MIT), as published by the Open Source         *
+ * Initiative. (See http://opensource.org/licenses/MIT)                        *
+ *******************************************************************************/
+
+package agents_test
+
+import (
+	abs "github.com/bali-nebula/go-component-framework/v2/abstractions"
+	age "github.com/bali-nebula/go-component-framework/v2/agents"
+	exp "github.com/bali-nebula/go-component-framework/v2/expressions"
+	pro "github.com/bali-nebula/go-component-framework/v2/procedures"
+	str "github.com/bali-nebula/go-component-framework/v2/strings"
+	cox "github.com/craterdog/go-collection-framework/v2"
+	ass "github.com/stretchr/testify/assert"
+	tes "testing"
+)
+
+func instruction(operation abs.Operation, modifier abs.Modifier, operand int) abs.Instruction {
+	return abs.InstructionFromOperation(operation, modifier, operand)
+}
+
+func TestInstructionFields(t *tes.T) {
+	var v = instruction(abs.INVOKE, 2, 0x7FF)
+	ass.Equal(t, abs.INVOKE, v.GetOperation())
+	ass.Equal(t, abs.Modifier(2), v.GetModifier())
+	ass.Equal(t, 0x7FF, v.GetOperand())
+	ass.Equal(t, byte(0xB7), v.GetLeftByte())
+	ass.Equal(t, byte(0xFF), v.GetRightByte())
+	ass.Equal(t, abs.Instruction(0), instruction(abs.JUMP, abs.ALWAYS, 0))
+}
+
+func TestCompileExpressions(t *tes.T) {
+	var compiler = age.Compiler()
+
+	// let $x := 5
+	// return $x + 1
+	var code = procedure(
+		let("x", abs.ASSIGN, number(5)),
+		statement(pro.ReturnClause(exp.Arithmetic(exp.Variable("x"), abs.PLUS, number(1)))),
+	)
+	var program = compiler.CompileProcedure(code)
+	ass.Equal(t, []abs.Instruction{
+		instruction(abs.PUSH, abs.LITERAL, 1),
+		instruction(abs.STORE, abs.VARIABLE, 1),
+		instruction(abs.LOAD, abs.VARIABLE, 1),
+		instruction(abs.PUSH, abs.LITERAL, 2),
+		instruction(abs.INVOKE, 2, 2),
+		instruction(abs.PULL, abs.RESULT, 0),
+	}, program.GetBytecode().AsArray())
+	ass.Equal(t, 2, program.GetLiterals().GetSize())
+	ass.Equal(t, 5.0, program.GetLiteral(1).ExtractNumber().GetReal())
+	ass.Equal(t, "x", program.GetSymbol(1).AsString())
+	ass.Equal(t, "sum-2", program.GetSymbol(2).AsString())
+}
+
+func TestCompileLoops(t *tes.T) {
+	var compiler = age.Compiler()
+
+	// while true do {
+	//     break loop
+	// }
+	var code = procedure(
+		statement(pro.WhileClause(pro.Block(
+			boolean(true),
+			procedure(statement(pro.BreakClause())),
+		))),
+	)
+	var program = compiler.CompileProcedure(code)
+	ass.Equal(t, []abs.Instruction{
+		instruction(abs.PUSH, abs.LITERAL, 1),
+		instruction(abs.JUMP, abs.ONFALSE, 5),
+		instruction(abs.JUMP, abs.ALWAYS, 5),
+		instruction(abs.JUMP, abs.ALWAYS, 1),
+	}, program.GetBytecode().AsArray())
+
+	// break loop
+	defer func() {
+		if e := recover(); e != nil {
+			ass.Equal(t, "A break loop clause must be inside of a loop.", e)
+		} else {
+			ass.Fail(t, "Test should result in recovered panic.")
+		}
+	}()
+	compiler.CompileProcedure(procedure(statement(pro.BreakClause()))) // This should panic.
+}
+
+func TestCompileHandlers(t *tes.T) {
+	var compiler = age.Compiler()
+
+	// throw "boom"
+	// on $failure matching "boom" do {
+	//     return $failure
+	// }
+	var blocks = cox.List[abs.BlockLike]()
+	blocks.AddValue(pro.Block(quote("boom"), procedure(
+		statement(pro.ReturnClause(exp.Variable("failure"))),
+	)))
+	var handler = pro.OnClause(str.SymbolFromString("failure"), blocks)
+	var code = procedure(pro.StatementWithHandler(pro.ThrowClause(quote("boom")), handler))
+	var program = compiler.CompileProcedure(code)
+	ass.Equal(t, []abs.Instruction{
+		instruction(abs.PUSH, abs.HANDLER, 6),
+		instruction(abs.PUSH, abs.LITERAL, 1),
+		instruction(abs.PULL, abs.EXCEPTION, 0),
+		instruction(abs.PULL, abs.HANDLER, 0),
+		instruction(abs.JUMP, abs.ALWAYS, 16),
+		instruction(abs.STORE, abs.VARIABLE, 1),
+		instruction(abs.LOAD, abs.VARIABLE, 1),
+		instruction(abs.PUSH, abs.LITERAL, 2),
+		instruction(abs.INVOKE, 2, 2),
+		instruction(abs.JUMP, abs.ONFALSE, 14),
+		instruction(abs.LOAD, abs.VARIABLE, 1),
+		instruction(abs.PULL, abs.RESULT, 0),
+		instruction(abs.JUMP, abs.ALWAYS, 16),
+		instruction(abs.LOAD, abs.VARIABLE, 1),
+		instruction(abs.PULL, abs.EXCEPTION, 0),
+	}, program.GetBytecode().AsArray())
+	ass.Equal(t, "matches-2", program.GetSymbol(2).AsString())
+}
+
+func TestCompileCompoundAttribute(t *tes.T) {
+	var compiler = age.Compiler()
+
+	// let $a[$i] += 1
+	var indices = cox.List[abs.Expression]()
+	indices.AddValue(exp.Variable("i"))
+	var attribute = pro.Attribute("a", indices)
+	var code = procedure(statement(pro.LetClauseWithRecipient(attribute, abs.SUM, number(1))))
+	var program = compiler.CompileProcedure(code)
+	ass.Equal(t, []abs.Instruction{
+		instruction(abs.LOAD, abs.VARIABLE, 1),
+		instruction(abs.STORE, abs.VARIABLE, 2),
+		instruction(abs.LOAD, abs.VARIABLE, 3),
+		instruction(abs.LOAD, abs.VARIABLE, 2),
+		instruction(abs.LOAD, abs.VARIABLE, 3),
+		instruction(abs.LOAD, abs.VARIABLE, 2),
+		instruction(abs.INVOKE, 2, 4),
+		instruction(abs.PUSH, abs.LITERAL, 1),
+		instruction(abs.INVOKE, 2, 5),
+		instruction(abs.INVOKE, 3, 6),
+		instruction(abs.PULL, abs.COMPONENT, 0),
+	}, program.GetBytecode().AsArray())
+	ass.Equal(t, "i", program.GetSymbol(1).AsString())
+	ass.Equal(t, "index-1", program.GetSymbol(2).AsString())
+	ass.Equal(t, "setAttribute-3", program.GetSymbol(6).AsString())
+}
@@ -0,0 +1,380 @@
+/*******************************************************************************
+ *   Copyright (c) 2009-2023 Crater Dog Technologies™.  All Rights Reserved.   *
+ *******************************************************************************
+ * DO NOT ALTER OR REMOVE COPYRIGHT NOTICES OR THIS FILE HEADER.               *
+ *                                                                             *
+ * This code is free software; you can redistribute it and/or modify it under  *
+ * the terms of The MIT License (MIT), as published by the Open Source         *
+ * Initiative. (See http://opensource.org/licenses/MIT)                        *
+ *******************************************************************************/
+
+package agents
+
+import (
+	fmt "fmt"
+	abs "github.com/bali-nebula/go-component-framework/v2/abstractions"
+	col "github.com/bali-nebula/go-component-framework/v2/collections"
+	com "github.com/bali-nebula/go-component-framework/v2/components"
+	ele "github.com/bali-nebula/go-component-framework/v2/elements"
+	str "github.com/bali-nebula/go-component-framework/v2/strings"
+	mat "math"
+	ref "reflect"
+	syn "sync"
+	uni "unicode"
+	utf "unicode/utf8"
+)
+
+// DISPATCHER IMPLEMENTATION
+
+// This constructor creates a new dispatcher that binds the methods named in
+// invocation expressions to the methods of the Go types that implement the
+// entities of the target components. A Bali method identifier is bound to the
+// Go method with the same name but with its first letter capitalized, so
+// "$list.addValue(value)" calls the AddValue() method of the list.
+//
+// The new dispatcher binds the methods of the following abstract types, and
+// only those methods, to any entity that implements them:
+//
+//	collections:  CatalogLike  ListLike  SetLike  QueueLike  StackLike
+//	strings:      BinaryLike  NameLike  NarrativeLike  QuoteLike  SymbolLike
+//	              TagLike  VersionLike
+//	agents:       FutureLike
+//
+// Additional Go types may be registered using the RegisterType() method, in
+// which case their exported methods are bound using reflection, or using the
+// RegisterMethod() method which binds a single method explicitly. The methods
+// that are bound explicitly are searched before those bound using reflection.
+// In each case the binding for the Go type of the entity itself is searched
+// first, followed by the bindings for the interface types that it implements
+// in the order they were registered.
+func Dispatcher() abs.DispatcherLike {
+	var v = &dispatcher{}
+	for _, type_ := range builtinTypes {
+		v.RegisterType(type_)
+	}
+	return v
+}
+
+// This type defines the structure and methods associated with a dispatcher
+// agent. A dispatcher may be shared by processors that run on different
+// goroutines so its bindings are guarded by a mutex.
+type dispatcher struct {
+	mutex    syn.RWMutex
+	bindings []*binding
+}
+
+// This type defines the methods that are bound to a registered Go type.
+type binding struct {
+	type_     ref.Type
+	methods   map[string]abs.Method
+	reflected bool
+}
+
+// These variables define the Go types that are used to convert arguments and
+// results.
+var (
+	componentType = ref.TypeOf((*abs.ComponentLike)(nil)).Elem()
+	errorType     = ref.TypeOf((*error)(nil)).Elem()
+	builtinTypes  = []ref.Type{
+		ref.TypeOf((*abs.CatalogLike)(nil)).Elem(),
+		ref.TypeOf((*abs.ListLike)(nil)).Elem(),
+		ref.TypeOf((*abs.SetLike)(nil)).Elem(),
+		ref.TypeOf((*abs.QueueLike)(nil)).Elem(),
+		ref.TypeOf((*abs.StackLike)(nil)).Elem(),
+		ref.TypeOf((*abs.BinaryLike)(nil)).Elem(),
+		ref.TypeOf((*abs.NameLike)(nil)).Elem(),
+		ref.TypeOf((*abs.NarrativeLike)(nil)).Elem(),
+		ref.TypeOf((*abs.QuoteLike)(nil)).Elem(),
+		ref.TypeOf((*abs.SymbolLike)(nil)).Elem(),
+		ref.TypeOf((*abs.TagLike)(nil)).Elem(),
+		ref.TypeOf((*abs.VersionLike)(nil)).Elem(),
+		ref.TypeOf((*abs.FutureLike)(nil)).Elem(),
+	}
+)
+
+// PUBLIC INTERFACE
+
+// This method binds all exported methods of the specified Go type to the
+// entities that have that type. If the type is an interface type, only the
+// methods it declares are bound, to any entity that implements it.
+func (v *dispatcher) RegisterType(type_ ref.Type) {
+	if type_ == nil {
+		panic("The dispatcher requires a type to register.")
+	}
+	v.mutex.Lock()
+	defer v.mutex.Unlock()
+	v.getBinding(type_).reflected = true
+}
+
+// This method explicitly binds the specified method to the entities that have
+// the specified Go type. An explicitly bound method takes precedence over any
+// method of the same name that is bound using reflection.
+func (v *dispatcher) RegisterMethod(type_ ref.Type, name string, method abs.Method) {
+	if type_ == nil || method == nil {
+		var message = fmt.Sprintf("The method $%v requires a type and an implementation.", name)
+		panic(message)
+	}
+	v.mutex.Lock()
+	defer v.mutex.Unlock()
+	v.getBinding(type_).methods[name] = method
+}
+
+// This method determines whether or not the specified method is bound to the
+// entity of the specified target component.
+func (v *dispatcher) SupportsMethod(target abs.ComponentLike, name string) bool {
+	var method, reflected = v.lookupMethod(target.GetEntity(), name)
+	return method != nil || reflected.IsValid()
+}
+
+// This method calls the specified method on the entity of the specified
+// target component with the specified arguments and returns the result. A
+// method without a result returns nil.
+func (v *dispatcher) DispatchMethod(
+	target abs.ComponentLike,
+	name string,
+	arguments []abs.ComponentLike,
+) abs.ComponentLike {
+	var method, reflected = v.lookupMethod(target.GetEntity(), name)
+	switch {
+	case method != nil:
+		return method(target, arguments)
+	case reflected.IsValid():
+		return v.callReflected(name, reflected, arguments)
+	default:
+		var message = fmt.Sprintf("The target component does not support the method: $%v", name)
+		panic(message)
+	}
+}
+
+// PRIVATE METHODS
+
+// This private method returns the binding for the specified Go type, creating
+// it if necessary.
+func (v *dispatcher) getBinding(type_ ref.Type) *binding {
+	for _, candidate := range v.bindings {
+		if candidate.type_ == type_ {
+			return candidate
+		}
+	}
+	var result = &binding{type_: type_, methods: make(map[string]abs.Method)}
+	v.bindings = append(v.bindings, result)
+	return result
+}
+
+// This private method returns the explicitly bound method, or else the
+// reflected Go method, that the specified method name is bound to for the
+// specified entity.
+func (v *dispatcher) lookupMethod(entity abs.Entity, name string) (abs.Method, ref.Value) {
+	var none ref.Value
+	if entity == nil {
+		return nil, none
+	}
+	v.mutex.RLock()
+	defer v.mutex.RUnlock()
+	var candidates = v.matchBindings(ref.TypeOf(entity))
+	for _, candidate := range candidates {
+		if method, ok := candidate.methods[name]; ok {
+			return method, none
+		}
+	}
+	var goName = v.exportName(name)
+	if len(goName) == 0 {
+		return nil, none
+	}
+	for _, candidate := range candidates {
+		if !candidate.reflected {
+			continue
+		}
+		if _, ok := candidate.type_.MethodByName(goName); ok {
+			return nil, ref.ValueOf(entity).MethodByName(goName)
+		}
+	}
+	return nil, none
+}
+
+// This private method returns the bindings that apply to the entities of the
+// specified Go type. The binding for the type itself comes first, followed by
+// the bindings for the interface types it implements in the order they were
+// registered.
+func (v *dispatcher) matchBindings(type_ ref.Type) []*binding {
+	var exact, implemented []*binding
+	for _, candidate := range v.bindings {
+		switch {
+		case candidate.type_ == type_:
+			exact = append(exact, candidate)
+		case candidate.type_.Kind() == ref.Interface && type_.Implements(candidate.type_):
+			implemented = append(implemented, candidate)
+		}
+	}
+	return append(exact, implemented...)
+}
+
+// This private method returns the name of the exported Go method that the
+// specified Bali method identifier is bound to, or an empty string if the
+// identifier does not begin with a lowercase letter.
+func (v *dispatcher) exportName(name string) string {
+	var first, size = utf.DecodeRuneInString(name)
+	if !uni.IsLower(first) {
+		return ""
+	}
+	return string(uni.ToUpper(first)) + name[size:]
+}
+
+// This private method calls the specified reflected Go method with the
+// specified arguments and returns its results as a component. If the last
+// result of the Go method is an error, a non-nil error is thrown as a Bali
+// exception and the error is not included in the results.
+func (v *dispatcher) callReflected(
+	name string,
+	method ref.Value,
+	arguments []abs.ComponentLike,
+) abs.ComponentLike {
+	var type_ = method.Type()
+	if type_.NumIn() != len(arguments) || type_.IsVariadic() {
+		var message = fmt.Sprintf("The method $%v requires %v arguments but was passed %v.", name, type_.NumIn(), len(arguments))
+		panic(message)
+	}
+	var values = make([]ref.Value, len(arguments))
+	for index, argument := range arguments {
+		var value, ok = v.convertArgument(argument, type_.In(index))
+		if !ok {
+			var message = fmt.Sprintf("The method $%v cannot accept argument %v: %v", name, index+1, argument.GetEntity())
+			panic(message)
+		}
+		values[index] = value
+	}
+	var results = method.Call(values)
+	var last = type_.NumOut() - 1
+	if last >= 0 && type_.Out(last).Implements(errorType) {
+		var failure = results[last]
+		var nilable = failure.Kind() == ref.Interface || failure.Kind() == ref.Pointer
+		if !nilable || !failure.IsNil() {
+			panic(&exception{describeFailure(failure.Interface())})
+		}
+		results = results[:last]
+	}
+	switch len(results) {
+	case 0:
+		return nil
+	case 1:
+		return v.convertResult(results[0])
+	default:
+		var list = col.List()
+		for _, result := range results {
+			list.AddValue(v.convertResult(result))
+		}
+		return com.Component(list)
+	}
+}
+
+// This private method converts the specified argument into a value of the
+// specified Go type. It returns the value and whether or not the conversion was
+// possible.
+func (v *dispatcher) convertArgument(argument abs.ComponentLike, type_ ref.Type) (ref.Value, bool) {
+	var entity = argument.GetEntity()
+	switch {
+	case type_ == componentType:
+		return ref.ValueOf(&argument).Elem(), true
+	case entity != nil && ref.TypeOf(entity).AssignableTo(type_):
+		var value = ref.New(type_).Elem()
+		value.Set(ref.ValueOf(entity))
+		return value, true
+	case ref.TypeOf(argument).AssignableTo(type_):
+		var value = ref.New(type_).Elem()
+		value.Set(ref.ValueOf(argument))
+		return value, true
+	}
+	var value = ref.New(type_).Elem()
+	switch type_.Kind() {
+	case ref.Bool:
+		var boolean, ok = entity.(abs.BooleanLike)
+		if !ok {
+			return value, false
+		}
+		value.SetBool(boolean.AsBoolean())
+	case ref.Int, ref.Int8, ref.Int16, ref.Int32, ref.Int64:
+		var number, ok = entity.(abs.NumberLike)
+		if !ok || !isInteger(number) {
+			return value, false
+		}
+		var integer = int64(number.GetReal())
+		if value.OverflowInt(integer) {
+			return value, false
+		}
+		value.SetInt(integer)
+	case ref.Uint, ref.Uint8, ref.Uint16, ref.Uint32, ref.Uint64:
+		var number, ok = entity.(abs.NumberLike)
+		if !ok || !isInteger(number) || number.GetReal() < 0 {
+			return value, false
+		}
+		var integer = uint64(number.GetReal())
+		if value.OverflowUint(integer) {
+			return value, false
+		}
+		value.SetUint(integer)
+	case ref.Float32, ref.Float64:
+		var number, ok = entity.(abs.NumberLike)
+		if !ok || number.GetImaginary() != 0 {
+			return value, false
+		}
+		value.SetFloat(number.GetReal())
+	case ref.String:
+		var quote, ok = entity.(abs.QuoteLike)
+		if !ok {
+			return value, false
+		}
+		value.SetString(quote.AsString())
+	default:
+		return value, false
+	}
+	return value, true
+}
+
+// This private method converts the specified Go value into a component. The
+// result is nil if the value is a nil interface or pointer.
+func (v *dispatcher) convertResult(value ref.Value) abs.ComponentLike {
+	switch value.Kind() {
+	case ref.Interface, ref.Pointer, ref.Map, ref.Func:
+		if value.IsNil() {
+			return nil
+		}
+	}
+	if component, ok := value.Interface().(abs.ComponentLike); ok {
+		return component
+	}
+	switch value.Kind() {
+	case ref.Bool:
+		return com.Component(ele.Boolean().FromBoolean(value.Bool()))
+	case ref.Int, ref.Int8, ref.Int16, ref.Int32, ref.Int64:
+		return com.Component(ele.Number().FromComplex(complex(float64(value.Int()), 0)))
+	case ref.Uint, ref.Uint8, ref.Uint16, ref.Uint32, ref.Uint64:
+		return com.Component(ele.Number().FromComplex(complex(float64(value.Uint()), 0)))
+	case ref.Float32, ref.Float64:
+		return com.Component(ele.Number().FromComplex(complex(value.Float(), 0)))
+	case ref.String:
+		return com.Component(str.QuoteFromArray([]rune(value.String())))
+	case ref.Slice, ref.Array:
+		var list = col.List()
+		for index := 0; index < value.Len(); index++ {
+			var item = v.convertResult(value.Index(index))
+			if item == nil {
+				item = com.Component(ele.Pattern().None())
+			}
+			list.AddValue(item)
+		}
+		return com.Component(list)
+	default:
+		return com.Component(value.Interface())
+	}
+}
+
+// PRIVATE FUNCTIONS
+
+// This function determines whether or not the specified number is a real
+// integer that can be converted into a Go integer without losing any part of
+// its value.
+func isInteger(number abs.NumberLike) bool {
+	var real_ = number.GetReal()
+	return number.GetImaginary() == 0 && real_ == mat.Trunc(real_) &&
+		real_ >= mat.MinInt64 && real_ < mat.MaxInt64
+}
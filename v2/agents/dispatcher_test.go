@@ -0,0 +1,215 @@
+/*******************************************************************************
+ *   Copyright (c) 2009-2023 Crater Dog Technologies™.  All Rights Reserved.   *
+ *******************************************************************************
+ * DO NOT ALTER OR REMOVE COPYRIGHT NOTICES OR THIS FILE HEADER.               *
+ *                                                                             *
+ * This code is free software; you can redistribute it and/or modify it under  *
+ * the terms of The MIT License (MIT), as published by the Open Source         *
+ * Initiative. (See http://opensource.org/licenses/MIT)                        *
+ *******************************************************************************/
+
+package agents_test
+
+import (
+	fmt "fmt"
+	abs "github.com/bali-nebula/go-component-framework/v2/abstractions"
+	age "github.com/bali-nebula/go-component-framework/v2/agents"
+	col "github.com/bali-nebula/go-component-framework/v2/collections"
+	com "github.com/bali-nebula/go-component-framework/v2/components"
+	ele "github.com/bali-nebula/go-component-framework/v2/elements"
+	exp "github.com/bali-nebula/go-component-framework/v2/expressions"
+	pro "github.com/bali-nebula/go-component-framework/v2/procedures"
+	str "github.com/bali-nebula/go-component-framework/v2/strings"
+	cox "github.com/craterdog/go-collection-framework/v2"
+	ass "github.com/stretchr/testify/assert"
+	ref "reflect"
+	tes "testing"
+)
+
+func numeric(value float64) abs.ComponentLike {
+	return com.Component(ele.Number().FromComplex(complex(value, 0)))
+}
+
+type counter struct {
+	count int
+}
+
+func (v *counter) Increment(step int) int {
+	v.count += step
+	return v.count
+}
+
+func TestDispatchBuiltinMethods(t *tes.T) {
+	var dispatcher = age.Dispatcher()
+	var list = com.Component(col.List())
+	ass.True(t, dispatcher.SupportsMethod(list, "addValue"))
+	ass.False(t, dispatcher.SupportsMethod(list, "AddValue"))
+	ass.False(t, dispatcher.SupportsMethod(list, "pushValue"))
+	ass.Nil(t, dispatcher.DispatchMethod(list, "addValue", []abs.ComponentLike{numeric(5)}))
+	var result = dispatcher.DispatchMethod(list, "isEmpty", nil)
+	ass.False(t, result.ExtractBoolean().AsBoolean())
+	result = dispatcher.DispatchMethod(list, "getValue", []abs.ComponentLike{numeric(1)})
+	ass.Equal(t, 5.0, result.ExtractNumber().GetReal())
+
+	var catalog = col.Catalog()
+	catalog.SetValue(str.SymbolFromString("alpha"), numeric(1))
+	var key = com.Component(str.SymbolFromString("alpha"))
+	result = dispatcher.DispatchMethod(com.Component(catalog), "getValue", []abs.ComponentLike{key})
+	ass.Equal(t, 1.0, result.ExtractNumber().GetReal())
+
+	var quote = com.Component(str.QuoteFromString("bali"))
+	result = dispatcher.DispatchMethod(quote, "getSize", nil)
+	ass.Equal(t, 4.0, result.ExtractNumber().GetReal())
+	result = dispatcher.DispatchMethod(quote, "asString", nil)
+	ass.Equal(t, "bali", result.ExtractQuote().AsString())
+
+	defer func() {
+		if e := recover(); e != nil {
+			ass.Equal(t, "The method $addValue requires 1 arguments but was passed 0.", e)
+		} else {
+			ass.Fail(t, "Test should result in recovered panic.")
+		}
+	}()
+	dispatcher.DispatchMethod(list, "addValue", nil) // This should panic.
+}
+
+func (v *counter) Decrement(step int) (int, error) {
+	if step > v.count {
+		return v.count, fmt.Errorf("The counter cannot go below zero.")
+	}
+	v.count -= step
+	return v.count, nil
+}
+
+func TestDispatchRegisteredTypes(t *tes.T) {
+	var dispatcher = age.Dispatcher()
+	var target = com.Component(&counter{})
+	ass.False(t, dispatcher.SupportsMethod(target, "increment"))
+	dispatcher.RegisterType(ref.TypeOf(&counter{}))
+	var result = dispatcher.DispatchMethod(target, "increment", []abs.ComponentLike{numeric(2)})
+	ass.Equal(t, 2.0, result.ExtractNumber().GetReal())
+
+	dispatcher.RegisterMethod(ref.TypeOf(&counter{}), "reset", func(target abs.ComponentLike, arguments []abs.ComponentLike) abs.ComponentLike {
+		target.GetEntity().(*counter).count = 0
+		return target
+	})
+	dispatcher.DispatchMethod(target, "reset", nil)
+	ass.Equal(t, 0, target.GetEntity().(*counter).count)
+
+	defer func() {
+		if e := recover(); e != nil {
+			ass.Equal(t, "The method $increment cannot accept argument 1: two", e)
+		} else {
+			ass.Fail(t, "Test should result in recovered panic.")
+		}
+	}()
+	dispatcher.DispatchMethod(target, "increment", []abs.ComponentLike{com.Component(str.QuoteFromString("two"))}) // This should panic.
+}
+
+type history struct {
+	abs.ListLike
+	added int
+}
+
+func TestDispatchOverriddenMethods(t *tes.T) {
+	var dispatcher = age.Dispatcher()
+	var entity = &history{ListLike: col.List()}
+	var target = com.Component(entity)
+	dispatcher.RegisterMethod(ref.TypeOf(entity), "addValue", func(target abs.ComponentLike, arguments []abs.ComponentLike) abs.ComponentLike {
+		var entity = target.GetEntity().(*history)
+		entity.added++
+		entity.ListLike.AddValue(arguments[0])
+		return nil
+	})
+	dispatcher.DispatchMethod(target, "addValue", []abs.ComponentLike{numeric(5)})
+	ass.Equal(t, 1, entity.added)
+	var result = dispatcher.DispatchMethod(target, "getSize", nil)
+	ass.Equal(t, 1.0, result.ExtractNumber().GetReal())
+	result = dispatcher.DispatchMethod(target, "getValue", []abs.ComponentLike{numeric(1)})
+	ass.Equal(t, 5.0, result.ExtractNumber().GetReal())
+}
+
+func TestDispatchErrorResults(t *tes.T) {
+	var environment = com.Context()
+	environment.SetValue(str.SymbolFromString("counter"), com.Component(&counter{count: 3}))
+	var interpreter = age.Interpreter(environment)
+	interpreter.GetDispatcher().RegisterType(ref.TypeOf(&counter{}))
+
+	// return counter.decrement(2)
+	var code = procedure(statement(pro.ReturnClause(
+		exp.Invocation(exp.Variable("counter"), abs.DOT, "decrement", arguments(number(2))),
+	)))
+	var result = interpreter.ExecuteProcedure(code)
+	ass.Equal(t, 1.0, result.ExtractNumber().GetReal())
+
+	// return counter.decrement(2)
+	// on $failure matching any do {
+	//     return $failure
+	// }
+	var blocks = cox.List[abs.BlockLike]()
+	blocks.AddValue(pro.Block(exp.Value(com.Component(ele.Pattern().Any())), procedure(
+		statement(pro.ReturnClause(exp.Variable("failure"))),
+	)))
+	var handler = pro.OnClause(str.SymbolFromString("failure"), blocks)
+	code = procedure(pro.StatementWithHandler(
+		pro.ReturnClause(exp.Invocation(exp.Variable("counter"), abs.DOT, "decrement", arguments(number(2)))),
+		handler,
+	))
+	result = interpreter.ExecuteProcedure(code)
+	ass.Equal(t, "The counter cannot go below zero.", result.ExtractQuote().AsString())
+}
+
+func TestDispatchInexactArguments(t *tes.T) {
+	var dispatcher = age.Dispatcher()
+	dispatcher.RegisterType(ref.TypeOf(&counter{}))
+	var target = com.Component(&counter{})
+	var arguments = []abs.ComponentLike{
+		numeric(2.5),
+		com.Component(ele.Number().FromComplex(complex(2, 1))),
+		numeric(1e300),
+	}
+	for _, argument := range arguments {
+		var message = fmt.Sprintf("The method $increment cannot accept argument 1: %v", argument.GetEntity())
+		ass.PanicsWithValue(t, message, func() {
+			dispatcher.DispatchMethod(target, "increment", []abs.ComponentLike{argument})
+		})
+	}
+	ass.Equal(t, 0, target.GetEntity().(*counter).count)
+}
+
+func TestDispatchUnknownMethod(t *tes.T) {
+	defer func() {
+		if e := recover(); e != nil {
+			ass.Equal(t, "The target component does not support the method: $increment", e)
+		} else {
+			ass.Fail(t, "Test should result in recovered panic.")
+		}
+	}()
+	age.Dispatcher().DispatchMethod(numeric(5), "increment", nil) // This should panic.
+}
+
+func TestInvokeMethods(t *tes.T) {
+	var environment = com.Context()
+	environment.SetValue(str.SymbolFromString("list"), com.Component(col.List()))
+
+	// list.addValue(3)
+	// list.addValue(4)
+	// return list.getSize()
+	var code = procedure(
+		statement(pro.LetClause(exp.Invocation(exp.Variable("list"), abs.DOT, "addValue", arguments(number(3))))),
+		statement(pro.LetClause(exp.Invocation(exp.Variable("list"), abs.DOT, "addValue", arguments(number(4))))),
+		statement(pro.ReturnClause(exp.Invocation(exp.Variable("list"), abs.DOT, "getSize", arguments()))),
+	)
+	var interpreter = age.Interpreter(environment)
+	var result = interpreter.ExecuteProcedure(code)
+	ass.Equal(t, 2.0, result.ExtractNumber().GetReal())
+
+	var processor = age.Processor(environment)
+	result = processor.ExecuteProgram(age.Compiler().CompileProcedure(code))
+	ass.Equal(t, 4.0, result.ExtractNumber().GetReal())
+
+	// list.isEmpty()
+	var evaluator = age.Evaluator(environment)
+	var expression = exp.Invocation(exp.Variable("list"), abs.DOT, "isEmpty", arguments())
+	ass.Equal(t, ele.Boolean().False(), evaluator.EvaluateExpression(expression).ExtractBoolean())
+}
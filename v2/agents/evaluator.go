@@ -0,0 +1,694 @@
+/*******************************************************************************
+ *   Copyright (c) 2009-2023 Crater Dog Technologies™.  All Rights Reserved.   *
+ *******************************************************************************
+ * DO NOT ALTER OR REMOVE COPYRIGHT NOTICES OR THIS FILE HEADER.               *
+ *                                                                             *
+ * This code is free software; you can redistribute it and/or modify it under  *
+ * the terms of The MIT License (MIT), as published by the Open Source         *
+ * Initiative. (See http://opensource.org/licenses/MIT)                        *
+ *******************************************************************************/
+
+package agents
+
+import (
+	fmt "fmt"
+	abs "github.com/bali-nebula/go-component-framework/v2/abstractions"
+	col "github.com/bali-nebula/go-component-framework/v2/collections"
+	com "github.com/bali-nebula/go-component-framework/v2/components"
+	ele "github.com/bali-nebula/go-component-framework/v2/elements"
+	exp "github.com/bali-nebula/go-component-framework/v2/expressions"
+	ins "github.com/bali-nebula/go-component-framework/v2/intrinsics"
+	str "github.com/bali-nebula/go-component-framework/v2/strings"
+	cox "github.com/craterdog/go-collection-framework/v2"
+	mat "math"
+)
+
+// EVALUATOR IMPLEMENTATION
+
+// This constructor creates a new evaluator that evaluates expressions within
+// the specified environment. The environment binds each variable symbol to its
+// current value. Each expression is evaluated by walking its abstract syntax
+// tree and the result of the evaluation is returned as a new component. The
+// intrinsic functions in the standard library are available to the expressions.
+func Evaluator(environment abs.ContextLike) abs.EvaluatorLike {
+	return EvaluatorWithRegistry(environment, ins.StandardLibrary())
+}
+
+// This constructor creates a new evaluator like the Evaluator() constructor
+// does but uses the intrinsic functions defined in the specified registry.
+func EvaluatorWithRegistry(environment abs.ContextLike, registry abs.RegistryLike) abs.EvaluatorLike {
+	if environment == nil {
+		panic("An evaluator requires an environment.")
+	}
+	if registry == nil {
+		panic("An evaluator requires a registry of intrinsic functions.")
+	}
+	return newEvaluator(environment, registry)
+}
+
+// This private constructor creates a new evaluator whose asynchronous
+// invocations are delivered through its dispatcher by a scheduler.
+func newEvaluator(environment abs.ContextLike, registry abs.RegistryLike) *evaluator {
+	var v = &evaluator{environment: environment, registry: registry, dispatcher: Dispatcher()}
+	v.scheduler = Scheduler(v.dispatcher.DispatchMethod)
+	return v
+}
+
+// This type defines the structure and methods associated with an evaluator
+// agent.
+type evaluator struct {
+	environment abs.ContextLike
+	registry    abs.RegistryLike
+	dispatcher  abs.DispatcherLike
+	scheduler   abs.SchedulerLike
+}
+
+// PUBLIC INTERFACE
+
+// This method returns the environment used by this evaluator.
+func (v *evaluator) GetEnvironment() abs.ContextLike {
+	return v.environment
+}
+
+// This method returns the dispatcher used by this evaluator to bind the
+// methods named in invocation expressions.
+func (v *evaluator) GetDispatcher() abs.DispatcherLike {
+	return v.dispatcher
+}
+
+// This method evaluates the specified expression within the environment of
+// this evaluator and returns the resulting component.
+func (v *evaluator) EvaluateExpression(expression abs.Expression) abs.ComponentLike {
+	var result abs.ComponentLike
+	switch exp.GetType(expression) {
+	case "ValueExpression":
+		result = expression.(abs.ValueLike).GetComponent()
+	case "IntrinsicExpression":
+		result = v.evaluateIntrinsic(expression.(abs.IntrinsicLike))
+	case "VariableExpression":
+		result = v.evaluateVariable(expression.(abs.VariableLike))
+	case "PrecedenceExpression":
+		result = v.EvaluateExpression(expression.(abs.UnaryOperationLike).GetExpression())
+	case "DereferenceExpression":
+		result = v.evaluateDereference(expression.(abs.UnaryOperationLike))
+	case "InvocationExpression":
+		result = v.evaluateInvocation(expression.(abs.InvocationLike))
+	case "SubcomponentExpression":
+		result = v.evaluateSubcomponent(expression.(abs.SubcomponentLike))
+	case "ChainingExpression":
+		result = v.evaluateChaining(expression.(abs.BinaryOperationLike))
+	case "ExponentialExpression":
+		result = v.evaluateExponential(expression.(abs.BinaryOperationLike))
+	case "InversionExpression":
+		result = v.evaluateInversion(expression.(abs.UnaryOperationLike))
+	case "ArithmeticExpression":
+		result = v.evaluateArithmetic(expression.(abs.BinaryOperationLike))
+	case "MagnitudeExpression":
+		result = v.evaluateMagnitude(expression.(abs.UnaryOperationLike))
+	case "ComparisonExpression":
+		result = v.evaluateComparison(expression.(abs.BinaryOperationLike))
+	case "ComplementExpression":
+		result = v.evaluateComplement(expression.(abs.UnaryOperationLike))
+	case "LogicalExpression":
+		result = v.evaluateLogical(expression.(abs.BinaryOperationLike))
+	}
+	return result
+}
+
+// PRIVATE METHODS
+
+// This private method evaluates the specified intrinsic function expression by
+// invoking the function from the registry on the values of its arguments.
+func (v *evaluator) evaluateIntrinsic(intrinsic abs.IntrinsicLike) abs.ComponentLike {
+	var arguments []abs.ComponentLike
+	for _, argument := range intrinsic.GetArguments().AsArray() {
+		arguments = append(arguments, v.EvaluateExpression(argument))
+	}
+	return v.registry.InvokeIntrinsic(intrinsic.GetFunction(), arguments)
+}
+
+// This private method evaluates the specified variable expression by looking up
+// its current value in the environment.
+func (v *evaluator) evaluateVariable(variable abs.VariableLike) abs.ComponentLike {
+	var identifier = variable.GetIdentifier()
+	var value = v.environment.GetValue(str.SymbolFromString(identifier))
+	if value == nil {
+		var message = fmt.Sprintf("Attempted to evaluate an undefined variable: $%v", identifier)
+		panic(message)
+	}
+	return value
+}
+
+// This private method evaluates the specified dereference expression.
+func (v *evaluator) evaluateDereference(dereference abs.UnaryOperationLike) abs.ComponentLike {
+	panic("The evaluator does not yet support the dereference of citations.")
+}
+
+// This private method evaluates the specified message invocation expression by
+// dispatching the method to the value of its target. A method without a result
+// evaluates to none. An asynchronous invocation is sent as a message to the
+// scheduler of this evaluator instead and evaluates to a future for its result.
+func (v *evaluator) evaluateInvocation(invocation abs.InvocationLike) abs.ComponentLike {
+	var target = v.EvaluateExpression(invocation.GetTarget())
+	var arguments []abs.ComponentLike
+	for _, argument := range invocation.GetArguments().AsArray() {
+		arguments = append(arguments, v.EvaluateExpression(argument))
+	}
+	if !invocation.IsSynchronous() {
+		return com.Component(v.scheduler.SendMessage(target, invocation.GetMethod(), arguments))
+	}
+	var result = v.dispatcher.DispatchMethod(target, invocation.GetMethod(), arguments)
+	if result == nil {
+		result = com.Component(ele.Pattern().None())
+	}
+	return result
+}
+
+// This private method evaluates the specified subcomponent expression by
+// indexing into its composite component one index at a time.
+func (v *evaluator) evaluateSubcomponent(subcomponent abs.SubcomponentLike) abs.ComponentLike {
+	var component = v.EvaluateExpression(subcomponent.GetComposite())
+	for _, index := range subcomponent.GetIndices().AsArray() {
+		var key = v.EvaluateExpression(index).GetEntity()
+		component = v.getSubcomponent(component, key)
+	}
+	return component
+}
+
+// This private method returns the subcomponent of the specified composite
+// component that is associated with the specified key or index.
+func (v *evaluator) getSubcomponent(composite abs.ComponentLike, key abs.Entity) abs.ComponentLike {
+	var subcomponent abs.ComponentLike
+	switch collection := composite.GetEntity().(type) {
+	case abs.CatalogLike:
+		subcomponent = collection.GetValue(key)
+	case abs.Accessible[abs.ComponentLike]:
+		subcomponent = collection.GetValue(v.extractIndex(key))
+	default:
+		var message = fmt.Sprintf("Attempted to retrieve a subcomponent from a non-composite component: %v", collection)
+		panic(message)
+	}
+	if subcomponent == nil {
+		var message = fmt.Sprintf("The composite component has no subcomponent at: %v", key)
+		panic(message)
+	}
+	return subcomponent
+}
+
+// This private method evaluates the specified chaining expression which
+// concatenates two strings or collections of the same type.
+func (v *evaluator) evaluateChaining(chaining abs.BinaryOperationLike) abs.ComponentLike {
+	var first = v.EvaluateExpression(chaining.GetFirst()).GetEntity()
+	var second = v.EvaluateExpression(chaining.GetSecond()).GetEntity()
+	var result abs.Entity
+	switch left := first.(type) {
+	case abs.BinaryLike:
+		result = str.Binary.Concatenate(left, v.extractBinary(second))
+	case abs.NameLike:
+		result = str.Name.Concatenate(left, v.extractName(second))
+	case abs.NarrativeLike:
+		result = str.Narrative.Concatenate(left, v.extractNarrative(second))
+	case abs.QuoteLike:
+		result = str.Quote.Concatenate(left, v.extractQuote(second))
+	case abs.VersionLike:
+		result = str.Version.Concatenate(left, v.extractVersion(second))
+	case abs.CatalogLike:
+		var catalog = col.CatalogFromSequence(left)
+		for _, association := range v.extractCatalog(second).AsArray() {
+			catalog.SetValue(association.GetKey(), association.GetValue())
+		}
+		result = catalog
+	case abs.ListLike:
+		var list = col.ListFromSequence(left)
+		list.AddValues(v.extractList(second))
+		result = list
+	default:
+		var message = fmt.Sprintf("Attempted to chain a non-chainable value: %v", first)
+		panic(message)
+	}
+	return com.Component(result)
+}
+
+// This private method evaluates the specified exponential expression.
+func (v *evaluator) evaluateExponential(exponential abs.BinaryOperationLike) abs.ComponentLike {
+	var base = v.extractNumber(v.EvaluateExpression(exponential.GetFirst()).GetEntity())
+	var exponent = v.extractNumber(v.EvaluateExpression(exponential.GetSecond()).GetEntity())
+	return com.Component(ele.Number().Power(base, exponent))
+}
+
+// This private method evaluates the specified inversion expression which
+// returns the additive (-), multiplicative (/) or conjugate (*) inverse of the
+// value of its expression.
+func (v *evaluator) evaluateInversion(inversion abs.UnaryOperationLike) abs.ComponentLike {
+	var value = v.EvaluateExpression(inversion.GetExpression()).GetEntity()
+	var operator = inversion.GetOperator()
+	var result abs.Entity
+	switch operand := value.(type) {
+	case abs.DurationLike:
+		if operator != abs.MINUS {
+			v.invalidOperator(operator, value)
+		}
+		result = ele.Duration().FromMilliseconds(-operand.AsInteger())
+	case abs.NumberLike:
+		switch operator {
+		case abs.MINUS:
+			result = ele.Number().Inverse(operand)
+		case abs.SLASH:
+			result = ele.Number().Reciprocal(operand)
+		case abs.STAR:
+			result = ele.Number().Conjugate(operand)
+		}
+	case abs.PercentageLike:
+		if operator != abs.MINUS {
+			v.invalidOperator(operator, value)
+		}
+		result = ele.Percentage().FromFloat(-operand.AsFloat() * 100.0)
+	case abs.AngleLike:
+		switch operator {
+		case abs.MINUS:
+			result = ele.Angle().Inverse(operand)
+		case abs.STAR:
+			result = ele.Angle().Conjugate(operand)
+		default:
+			v.invalidOperator(operator, value)
+		}
+	default:
+		v.invalidOperator(operator, value)
+	}
+	return com.Component(result)
+}
+
+// This private method evaluates the specified arithmetic expression.
+func (v *evaluator) evaluateArithmetic(arithmetic abs.BinaryOperationLike) abs.ComponentLike {
+	var first = v.EvaluateExpression(arithmetic.GetFirst()).GetEntity()
+	var operator = arithmetic.GetOperator()
+	var second = v.EvaluateExpression(arithmetic.GetSecond()).GetEntity()
+	var result abs.Entity
+	switch left := first.(type) {
+	case abs.DurationLike:
+		var milliseconds = float64(left.AsInteger())
+		switch operator {
+		case abs.PLUS:
+			milliseconds += float64(v.extractDuration(second).AsInteger())
+		case abs.MINUS:
+			milliseconds -= float64(v.extractDuration(second).AsInteger())
+		case abs.STAR:
+			milliseconds *= v.extractFactor(second)
+		case abs.SLASH:
+			milliseconds /= v.extractFactor(second)
+		default:
+			v.invalidOperator(operator, first)
+		}
+		result = ele.Duration().FromMilliseconds(int(mat.Round(milliseconds)))
+	case abs.MomentLike:
+		switch {
+		case operator == abs.PLUS:
+			result = ele.Moment().Later(left, v.extractDuration(second))
+		case operator == abs.MINUS && v.isDuration(second):
+			result = ele.Moment().Earlier(left, v.extractDuration(second))
+		case operator == abs.MINUS:
+			result = ele.Moment().Duration(v.extractMoment(second), left)
+		default:
+			v.invalidOperator(operator, first)
+		}
+	case abs.NumberLike:
+		var right = v.extractNumber(second)
+		switch operator {
+		case abs.PLUS:
+			result = ele.Number().Sum(left, right)
+		case abs.MINUS:
+			result = ele.Number().Difference(left, right)
+		case abs.STAR:
+			result = ele.Number().Product(left, right)
+		case abs.SLASH:
+			result = ele.Number().Quotient(left, right)
+		case abs.MODULO:
+			result = ele.Number().Remainder(left, right)
+		}
+	case abs.PercentageLike:
+		var percent = left.AsFloat() * 100.0
+		switch operator {
+		case abs.PLUS:
+			percent += v.extractPercentage(second).AsFloat() * 100.0
+		case abs.MINUS:
+			percent -= v.extractPercentage(second).AsFloat() * 100.0
+		case abs.STAR:
+			percent *= v.extractFactor(second)
+		case abs.SLASH:
+			percent /= v.extractFactor(second)
+		default:
+			v.invalidOperator(operator, first)
+		}
+		result = ele.Percentage().FromFloat(percent)
+	case abs.AngleLike:
+		switch operator {
+		case abs.PLUS:
+			result = ele.Angle().Sum(left, v.extractAngle(second))
+		case abs.MINUS:
+			result = ele.Angle().Difference(left, v.extractAngle(second))
+		case abs.STAR:
+			result = ele.Angle().Scaled(left, v.extractFactor(second))
+		case abs.SLASH:
+			result = ele.Angle().Scaled(left, 1.0/v.extractFactor(second))
+		default:
+			v.invalidOperator(operator, first)
+		}
+	default:
+		v.invalidOperator(operator, first)
+	}
+	return com.Component(result)
+}
+
+// This private method evaluates the specified magnitude expression.
+func (v *evaluator) evaluateMagnitude(magnitude abs.UnaryOperationLike) abs.ComponentLike {
+	var value = v.EvaluateExpression(magnitude.GetExpression()).GetEntity()
+	var result abs.Entity
+	switch operand := value.(type) {
+	case abs.DurationLike:
+		var milliseconds = operand.AsInteger()
+		if milliseconds < 0 {
+			milliseconds = -milliseconds
+		}
+		result = ele.Duration().FromMilliseconds(milliseconds)
+	case abs.NumberLike:
+		result = ele.Number().FromComplex(complex(operand.GetMagnitude(), 0))
+	case abs.PercentageLike:
+		result = ele.Percentage().FromFloat(mat.Abs(operand.AsFloat() * 100.0))
+	default:
+		v.invalidOperator(abs.MAGNITUDE, value)
+	}
+	return com.Component(result)
+}
+
+// This private method evaluates the specified comparison expression.
+func (v *evaluator) evaluateComparison(comparison abs.BinaryOperationLike) abs.ComponentLike {
+	var first = v.EvaluateExpression(comparison.GetFirst())
+	var operator = comparison.GetOperator()
+	var second = v.EvaluateExpression(comparison.GetSecond())
+	var result bool
+	switch operator {
+	case abs.LESS:
+		result = cox.RankValues(first.GetEntity(), second.GetEntity()) < 0
+	case abs.EQUAL:
+		result = cox.CompareValues(first.GetEntity(), second.GetEntity())
+	case abs.UNEQUAL:
+		result = !cox.CompareValues(first.GetEntity(), second.GetEntity())
+	case abs.MORE:
+		result = cox.RankValues(first.GetEntity(), second.GetEntity()) > 0
+	case abs.IS:
+		result = first == second || first.GetEntity() == second.GetEntity()
+	case abs.MATCHES:
+		result = v.matchesPattern(first.GetEntity(), second.GetEntity())
+	}
+	return com.Component(ele.Boolean().FromBoolean(result))
+}
+
+// This private method determines whether or not the specified value matches
+// the specified pattern.
+func (v *evaluator) matchesPattern(value abs.Entity, pattern abs.Entity) bool {
+	var matcher, ok = pattern.(abs.PatternLike)
+	if !ok {
+		// Any value other than a pattern matches only itself.
+		return cox.CompareValues(value, pattern)
+	}
+	var lexical, isLexical = value.(abs.Lexical)
+	if !isLexical {
+		var message = fmt.Sprintf("Attempted to match a non-lexical value against a pattern: %v", value)
+		panic(message)
+	}
+	return matcher.MatchesText(lexical.AsString())
+}
+
+// This private method evaluates the specified complement expression.
+func (v *evaluator) evaluateComplement(complement abs.UnaryOperationLike) abs.ComponentLike {
+	var value = v.EvaluateExpression(complement.GetExpression()).GetEntity()
+	var result abs.Entity
+	switch operand := value.(type) {
+	case abs.BinaryLike:
+		result = str.Binary.Not(operand)
+	case abs.PercentageLike:
+		v.invalidOperator(abs.NOT, value)
+	case abs.ProbabilityLike:
+		result = ele.Probability().FromFloat(1.0 - operand.AsFloat())
+	case abs.BooleanLike:
+		result = ele.Boolean().Not(operand)
+	default:
+		v.invalidOperator(abs.NOT, value)
+	}
+	return com.Component(result)
+}
+
+// This private method evaluates the specified logical expression. Probabilities
+// are combined as if they were independent events.
+func (v *evaluator) evaluateLogical(logical abs.BinaryOperationLike) abs.ComponentLike {
+	var first = v.EvaluateExpression(logical.GetFirst()).GetEntity()
+	var operator = logical.GetOperator()
+	var second = v.EvaluateExpression(logical.GetSecond()).GetEntity()
+	var result abs.Entity
+	switch left := first.(type) {
+	case abs.BinaryLike:
+		var right = v.extractBinary(second)
+		switch operator {
+		case abs.AND:
+			result = str.Binary.And(left, right)
+		case abs.SANS:
+			result = str.Binary.Sans(left, right)
+		case abs.OR:
+			result = str.Binary.Or(left, right)
+		case abs.XOR:
+			result = str.Binary.Xor(left, right)
+		}
+	case abs.PercentageLike:
+		v.invalidOperator(operator, first)
+	case abs.ProbabilityLike:
+		var p = left.AsFloat()
+		var q = v.extractProbability(second).AsFloat()
+		switch operator {
+		case abs.AND:
+			result = ele.Probability().FromFloat(p * q)
+		case abs.SANS:
+			result = ele.Probability().FromFloat(p * (1.0 - q))
+		case abs.OR:
+			result = ele.Probability().FromFloat(p + q - p*q)
+		case abs.XOR:
+			result = ele.Probability().FromFloat(p + q - 2.0*p*q)
+		}
+	case abs.BooleanLike:
+		var right = v.extractBoolean(second)
+		switch operator {
+		case abs.AND:
+			result = ele.Boolean().And(left, right)
+		case abs.SANS:
+			result = ele.Boolean().Sans(left, right)
+		case abs.OR:
+			result = ele.Boolean().Or(left, right)
+		case abs.XOR:
+			result = ele.Boolean().Xor(left, right)
+		}
+	default:
+		v.invalidOperator(operator, first)
+	}
+	return com.Component(result)
+}
+
+// This private method panics with a message describing an operator that cannot
+// be applied to the specified value.
+func (v *evaluator) invalidOperator(operator abs.Operator, value abs.Entity) {
+	var message = fmt.Sprintf("The operator (%v) cannot be applied to the value: %v", operator, value)
+	panic(message)
+}
+
+// This private method determines whether or not the specified value is a
+// duration.
+func (v *evaluator) isDuration(value abs.Entity) bool {
+	var _, ok = value.(abs.DurationLike)
+	return ok
+}
+
+// This private method extracts an ordinal based index from the specified value.
+func (v *evaluator) extractIndex(value abs.Entity) int {
+	switch index := value.(type) {
+	case abs.NumberLike:
+		return int(index.GetReal())
+	case abs.Discrete:
+		return index.AsInteger()
+	default:
+		var message = fmt.Sprintf("An index must be an integer value: %v", value)
+		panic(message)
+	}
+}
+
+// This private method returns an array containing the items in the specified
+// sequence component.
+func (v *evaluator) extractItems(sequence abs.ComponentLike) []abs.ComponentLike {
+	var items []abs.ComponentLike
+	switch collection := sequence.GetEntity().(type) {
+	case abs.Sequential[abs.ComponentLike]:
+		items = collection.AsArray()
+	case abs.CatalogLike:
+		for _, association := range collection.AsArray() {
+			items = append(items, com.Component(association))
+		}
+	case abs.IntervalLike:
+		for _, value := range collection.AsArray() {
+			items = append(items, com.Component(value))
+		}
+	default:
+		var message = fmt.Sprintf("Attempted to iterate over a non-sequential component: %v", collection)
+		panic(message)
+	}
+	return items
+}
+
+// This private method extracts a scaling factor from the specified value.
+func (v *evaluator) extractFactor(value abs.Entity) float64 {
+	switch factor := value.(type) {
+	case abs.NumberLike:
+		return factor.GetReal()
+	case abs.Continuous:
+		return factor.AsFloat()
+	default:
+		var message = fmt.Sprintf("A scaling factor must be a numeric value: %v", value)
+		panic(message)
+	}
+}
+
+// This private method extracts an angle from the specified value.
+func (v *evaluator) extractAngle(value abs.Entity) abs.AngleLike {
+	var result, ok = value.(abs.AngleLike)
+	if !ok {
+		var message = fmt.Sprintf("Expected an angle but found: %v", value)
+		panic(message)
+	}
+	return result
+}
+
+// This private method extracts a binary string from the specified value.
+func (v *evaluator) extractBinary(value abs.Entity) abs.BinaryLike {
+	var result, ok = value.(abs.BinaryLike)
+	if !ok {
+		var message = fmt.Sprintf("Expected a binary string but found: %v", value)
+		panic(message)
+	}
+	return result
+}
+
+// This private method extracts a boolean from the specified value.
+func (v *evaluator) extractBoolean(value abs.Entity) abs.BooleanLike {
+	var result, ok = value.(abs.BooleanLike)
+	if !ok {
+		var message = fmt.Sprintf("Expected a boolean but found: %v", value)
+		panic(message)
+	}
+	return result
+}
+
+// This private method extracts a catalog from the specified value.
+func (v *evaluator) extractCatalog(value abs.Entity) abs.CatalogLike {
+	var result, ok = value.(abs.CatalogLike)
+	if !ok {
+		var message = fmt.Sprintf("Expected a catalog but found: %v", value)
+		panic(message)
+	}
+	return result
+}
+
+// This private method extracts a duration from the specified value.
+func (v *evaluator) extractDuration(value abs.Entity) abs.DurationLike {
+	var result, ok = value.(abs.DurationLike)
+	if !ok {
+		var message = fmt.Sprintf("Expected a duration but found: %v", value)
+		panic(message)
+	}
+	return result
+}
+
+// This private method extracts a list from the specified value.
+func (v *evaluator) extractList(value abs.Entity) abs.ListLike {
+	var result, ok = value.(abs.ListLike)
+	if !ok {
+		var message = fmt.Sprintf("Expected a list but found: %v", value)
+		panic(message)
+	}
+	return result
+}
+
+// This private method extracts a moment from the specified value.
+func (v *evaluator) extractMoment(value abs.Entity) abs.MomentLike {
+	var result, ok = value.(abs.MomentLike)
+	if !ok {
+		var message = fmt.Sprintf("Expected a moment but found: %v", value)
+		panic(message)
+	}
+	return result
+}
+
+// This private method extracts a name from the specified value.
+func (v *evaluator) extractName(value abs.Entity) abs.NameLike {
+	var result, ok = value.(abs.NameLike)
+	if !ok {
+		var message = fmt.Sprintf("Expected a name but found: %v", value)
+		panic(message)
+	}
+	return result
+}
+
+// This private method extracts a narrative from the specified value.
+func (v *evaluator) extractNarrative(value abs.Entity) abs.NarrativeLike {
+	var result, ok = value.(abs.NarrativeLike)
+	if !ok {
+		var message = fmt.Sprintf("Expected a narrative but found: %v", value)
+		panic(message)
+	}
+	return result
+}
+
+// This private method extracts a number from the specified value.
+func (v *evaluator) extractNumber(value abs.Entity) abs.NumberLike {
+	var result, ok = value.(abs.NumberLike)
+	if !ok {
+		var message = fmt.Sprintf("Expected a number but found: %v", value)
+		panic(message)
+	}
+	return result
+}
+
+// This private method extracts a percentage from the specified value.
+func (v *evaluator) extractPercentage(value abs.Entity) abs.PercentageLike {
+	var result, ok = value.(abs.PercentageLike)
+	if !ok {
+		var message = fmt.Sprintf("Expected a percentage but found: %v", value)
+		panic(message)
+	}
+	return result
+}
+
+// This private method extracts a probability from the specified value.
+func (v *evaluator) extractProbability(value abs.Entity) abs.ProbabilityLike {
+	var result, ok = value.(abs.ProbabilityLike)
+	if !ok {
+		var message = fmt.Sprintf("Expected a probability but found: %v", value)
+		panic(message)
+	}
+	return result
+}
+
+// This private method extracts a quote from the specified value.
+func (v *evaluator) extractQuote(value abs.Entity) abs.QuoteLike {
+	var result, ok = value.(abs.QuoteLike)
+	if !ok {
+		var message = fmt.Sprintf("Expected a quote but found: %v", value)
+		panic(message)
+	}
+	return result
+}
+
+// This private method extracts a version string from the specified value.
+func (v *evaluator) extractVersion(value abs.Entity) abs.VersionLike {
+	var result, ok = value.(abs.VersionLike)
+	if !ok {
+		var message = fmt.Sprintf("Expected a version string but found: %v", value)
+		panic(message)
+	}
+	return result
+}
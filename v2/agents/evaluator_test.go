@@ -0,0 +1,146 @@
+/*******************************************************************************
+ *   Copyright (c) 2009-2023 Crater Dog Technologies™.  All Rights Reserved.   *
+ *******************************************************************************
+ * DO NOT ALTER OR REMOVE COPYRIGHT NOTICES OR THIS FILE HEADER.               *
+ *                                                                             *
+ * This code is free software; you can redistribute it and/or modify it under  *
+ * the terms of The MIT License (MIT), as published by the Open Source         *
+ * Initiative. (See http://opensource.org/licenses/MIT)                        *
+ *******************************************************************************/
+
+package agents_test
+
+import (
+	abs "github.com/bali-nebula/go-component-framework/v2/abstractions"
+	age "github.com/bali-nebula/go-component-framework/v2/agents"
+	col "github.com/bali-nebula/go-component-framework/v2/collections"
+	com "github.com/bali-nebula/go-component-framework/v2/components"
+	ele "github.com/bali-nebula/go-component-framework/v2/elements"
+	exp "github.com/bali-nebula/go-component-framework/v2/expressions"
+	str "github.com/bali-nebula/go-component-framework/v2/strings"
+	cox "github.com/craterdog/go-collection-framework/v2"
+	ass "github.com/stretchr/testify/assert"
+	tes "testing"
+)
+
+func number(value float64) abs.Expression {
+	return exp.Value(com.Component(ele.Number().FromComplex(complex(value, 0))))
+}
+
+func boolean(value bool) abs.Expression {
+	return exp.Value(com.Component(ele.Boolean().FromBoolean(value)))
+}
+
+func TestEvaluatorWithoutEnvironment(t *tes.T) {
+	defer func() {
+		if e := recover(); e != nil {
+			ass.Equal(t, "An evaluator requires an environment.", e)
+		} else {
+			ass.Fail(t, "Test should result in recovered panic.")
+		}
+	}()
+	age.Evaluator(nil) // This should panic.
+}
+
+func TestEvaluatorArithmetic(t *tes.T) {
+	var evaluator = age.Evaluator(com.Context())
+
+	// (3 + 4) * 2 - 10 // 4
+	var expression abs.Expression = exp.Arithmetic(
+		exp.Arithmetic(
+			exp.Precedence(exp.Arithmetic(number(3), abs.PLUS, number(4))),
+			abs.STAR,
+			number(2),
+		),
+		abs.MINUS,
+		exp.Arithmetic(number(10), abs.MODULO, number(4)),
+	)
+	var result = evaluator.EvaluateExpression(expression)
+	ass.Equal(t, 12.0, result.ExtractNumber().GetReal())
+
+	// -2 ^ 3
+	expression = exp.Exponential(exp.Inversion(abs.MINUS, number(2)), abs.CARET, number(3))
+	result = evaluator.EvaluateExpression(expression)
+	ass.Equal(t, -8.0, result.ExtractNumber().GetReal())
+
+	// |-5|
+	expression = exp.Magnitude(exp.Inversion(abs.MINUS, number(5)))
+	result = evaluator.EvaluateExpression(expression)
+	ass.Equal(t, 5.0, result.ExtractNumber().GetReal())
+
+	// 1h - 15m
+	var hour = exp.Value(com.Component(ele.Duration().FromMilliseconds(3600000)))
+	var quarter = exp.Value(com.Component(ele.Duration().FromMilliseconds(900000)))
+	result = evaluator.EvaluateExpression(exp.Arithmetic(hour, abs.MINUS, quarter))
+	ass.Equal(t, 2700000, result.ExtractDuration().AsInteger())
+}
+
+func TestEvaluatorVariables(t *tes.T) {
+	var environment = com.Context()
+	environment.SetValue(str.SymbolFromString("x"), com.Component(ele.Number().FromComplex(5)))
+	var evaluator = age.Evaluator(environment)
+	ass.Equal(t, environment, evaluator.GetEnvironment())
+
+	// $x * $x
+	var expression = exp.Arithmetic(exp.Variable("x"), abs.STAR, exp.Variable("x"))
+	var result = evaluator.EvaluateExpression(expression)
+	ass.Equal(t, 25.0, result.ExtractNumber().GetReal())
+
+	defer func() {
+		if e := recover(); e != nil {
+			ass.Equal(t, "Attempted to evaluate an undefined variable: $y", e)
+		} else {
+			ass.Fail(t, "Test should result in recovered panic.")
+		}
+	}()
+	evaluator.EvaluateExpression(exp.Variable("y")) // This should panic.
+}
+
+func TestEvaluatorLogic(t *tes.T) {
+	var evaluator = age.Evaluator(com.Context())
+
+	// (2 < 3) AND NOT false
+	var expression abs.Expression = exp.Logical(
+		exp.Precedence(exp.Comparison(number(2), abs.LESS, number(3))),
+		abs.AND,
+		exp.Complement(abs.NOT, boolean(false)),
+	)
+	var result = evaluator.EvaluateExpression(expression)
+	ass.True(t, result.ExtractBoolean().AsBoolean())
+
+	// 5 = 5 XOR true
+	expression = exp.Logical(exp.Comparison(number(5), abs.EQUAL, number(5)), abs.XOR, boolean(true))
+	result = evaluator.EvaluateExpression(expression)
+	ass.False(t, result.ExtractBoolean().AsBoolean())
+
+	// "abc" MATCHES "^a.*"?
+	var quote = exp.Value(com.Component(str.QuoteFromString("abc")))
+	var pattern = exp.Value(com.Component(ele.Pattern().FromString(`"^a.*"?`)))
+	result = evaluator.EvaluateExpression(exp.Comparison(quote, abs.MATCHES, pattern))
+	ass.True(t, result.ExtractBoolean().AsBoolean())
+}
+
+func TestEvaluatorCollections(t *tes.T) {
+	var list = col.List()
+	list.AddValue(com.Component(ele.Number().FromComplex(1)))
+	list.AddValue(com.Component(ele.Number().FromComplex(2)))
+	var catalog = col.Catalog()
+	catalog.SetValue(str.SymbolFromString("values"), com.Component(list))
+	var environment = com.Context()
+	environment.SetValue(str.SymbolFromString("catalog"), com.Component(catalog))
+	var evaluator = age.Evaluator(environment)
+
+	// $catalog[$values, 2]
+	var indices = cox.List[abs.Expression]()
+	indices.AddValue(exp.Value(com.Component(str.SymbolFromString("values"))))
+	indices.AddValue(number(2))
+	var expression = exp.Subcomponent(exp.Variable("catalog"), indices)
+	var result = evaluator.EvaluateExpression(expression)
+	ass.Equal(t, 2.0, result.ExtractNumber().GetReal())
+
+	// "Hello " & "World!"
+	var hello = exp.Value(com.Component(str.QuoteFromString("Hello ")))
+	var world = exp.Value(com.Component(str.QuoteFromString("World!")))
+	result = evaluator.EvaluateExpression(exp.Chaining(hello, abs.AMPERSAND, world))
+	ass.Equal(t, "Hello World!", result.ExtractQuote().AsString())
+}
@@ -0,0 +1,391 @@
+/*******************************************************************************
+ *   Copyright (c) 2009-2023 Crater Dog Technologies™.  All Rights Reserved.   *
+ *******************************************************************************
+ * DO NOT ALTER OR REMOVE COPYRIGHT NOTICES OR THIS FILE HEADER.               *
+ *                                                                             *
+ * This code is free software; you can redistribute it and/or modify it under  *
+ * the terms of The MIT License (MIT), as published by the Open Source         *
+ * Initiative. (See http://opensource.org/licenses/MIT)                        *
+ *******************************************************************************/
+
+package agents
+
+import (
+	fmt "fmt"
+	abs "github.com/bali-nebula/go-component-framework/v2/abstractions"
+	com "github.com/bali-nebula/go-component-framework/v2/components"
+	exp "github.com/bali-nebula/go-component-framework/v2/expressions"
+	ins "github.com/bali-nebula/go-component-framework/v2/intrinsics"
+	pro "github.com/bali-nebula/go-component-framework/v2/procedures"
+	str "github.com/bali-nebula/go-component-framework/v2/strings"
+	sts "strings"
+)
+
+// INTERPRETER IMPLEMENTATION
+
+// This constructor creates a new interpreter that executes procedures within
+// the specified environment. The statements in a procedure are executed one at
+// a time and any expressions they contain are evaluated using an evaluator that
+// shares the same environment. An exception that is thrown and not handled by
+// an on clause causes the interpreter to panic with the exception component.
+func Interpreter(environment abs.ContextLike) abs.InterpreterLike {
+	return InterpreterWithRegistry(environment, ins.StandardLibrary())
+}
+
+// This constructor creates a new interpreter like the Interpreter() constructor
+// does but uses the intrinsic functions defined in the specified registry.
+func InterpreterWithRegistry(environment abs.ContextLike, registry abs.RegistryLike) abs.InterpreterLike {
+	if environment == nil {
+		panic("An interpreter requires an environment.")
+	}
+	if registry == nil {
+		panic("An interpreter requires a registry of intrinsic functions.")
+	}
+	return &interpreter{evaluator: newEvaluator(environment, registry)}
+}
+
+// This type defines the structure and methods associated with an interpreter
+// agent.
+type interpreter struct {
+	evaluator *evaluator
+	result    abs.ComponentLike
+}
+
+// This type enumerates the ways in which the execution of a statement can
+// affect the flow of control in its enclosing procedure.
+type signal int
+
+const (
+	proceeding signal = iota
+	breaking
+	continuing
+	returning
+)
+
+// This type wraps a component that has been thrown as an exception so that it
+// can be distinguished from other panics while it unwinds the procedure.
+type exception struct {
+	component abs.ComponentLike
+}
+
+// This function returns the component that describes the specified failure.
+// Any failure that was not thrown as an exception is described by a quote
+// containing its message.
+func describeFailure(failure any) abs.ComponentLike {
+	if thrown, ok := failure.(*exception); ok {
+		return thrown.component
+	}
+	if component, ok := failure.(abs.ComponentLike); ok {
+		return component
+	}
+	var message = sts.TrimSpace(fmt.Sprintf("%v", failure))
+	return com.Component(str.QuoteFromArray([]rune(message)))
+}
+
+// This function determines whether or not the specified failure is a Bali
+// exception, either thrown by a throw clause or rethrown as its component.
+func isException(failure any) bool {
+	switch failure.(type) {
+	case *exception, abs.ComponentLike:
+		return true
+	default:
+		return false
+	}
+}
+
+// PUBLIC INTERFACE
+
+// This method returns the environment used by this interpreter.
+func (v *interpreter) GetEnvironment() abs.ContextLike {
+	return v.evaluator.GetEnvironment()
+}
+
+// This method returns the dispatcher used by this interpreter to bind the
+// methods named in invocation expressions.
+func (v *interpreter) GetDispatcher() abs.DispatcherLike {
+	return v.evaluator.GetDispatcher()
+}
+
+// This method evaluates the specified expression within the environment of
+// this interpreter and returns the resulting component.
+func (v *interpreter) EvaluateExpression(expression abs.Expression) abs.ComponentLike {
+	return v.evaluator.EvaluateExpression(expression)
+}
+
+// This method executes the specified procedure within the environment of this
+// interpreter and returns the result of its return clause, or nil if the
+// procedure completes without returning a result.
+func (v *interpreter) ExecuteProcedure(procedure abs.ProcedureLike) abs.ComponentLike {
+	defer func() {
+		if e := recover(); e != nil {
+			if thrown, ok := e.(*exception); ok {
+				// Rethrow the unhandled exception as the component itself.
+				panic(thrown.component)
+			}
+			panic(e)
+		}
+	}()
+	v.result = nil
+	switch v.executeProcedure(procedure) {
+	case breaking:
+		panic("A break loop clause was executed outside of a loop.")
+	case continuing:
+		panic("A continue loop clause was executed outside of a loop.")
+	}
+	var result = v.result
+	v.result = nil
+	return result
+}
+
+// PRIVATE METHODS
+
+// This private method executes each statement in the specified procedure until
+// one of them interrupts the normal flow of control.
+func (v *interpreter) executeProcedure(procedure abs.ProcedureLike) signal {
+	for _, statement := range procedure.AsArray() {
+		if statement == nil {
+			// Skip any blank lines in the procedure.
+			continue
+		}
+		var status = v.executeStatement(statement)
+		if status != proceeding {
+			return status
+		}
+	}
+	return proceeding
+}
+
+// This private method executes the specified statement. Any exception that is
+// thrown while executing its main clause is handed to its on clause if it has
+// one. Any other failure, like a Go runtime error, is not an exception and
+// continues to unwind the procedure.
+func (v *interpreter) executeStatement(statement abs.StatementLike) (status signal) {
+	var onClause = statement.GetOnClause()
+	if onClause != nil {
+		defer func() {
+			if e := recover(); e != nil {
+				if !isException(e) {
+					panic(e)
+				}
+				status = v.handleFailure(onClause, e)
+			}
+		}()
+	}
+	return v.executeClause(statement.GetMainClause())
+}
+
+// This private method handles the specified failure using the first block in
+// the specified on clause whose pattern matches the failure. The failure is
+// rethrown if no block matches it.
+func (v *interpreter) handleFailure(onClause abs.OnClauseLike, failure any) signal {
+	var component = describeFailure(failure)
+	v.GetEnvironment().SetValue(onClause.GetFailure(), component)
+	for _, block := range onClause.GetBlocks().AsArray() {
+		if v.isMatch(component, block.GetExpression()) {
+			return v.executeProcedure(block.GetProcedure())
+		}
+	}
+	panic(failure)
+}
+
+// This private method executes the specified clause.
+func (v *interpreter) executeClause(clause abs.Clause) signal {
+	var status = proceeding
+	switch pro.GetType(clause) {
+	case "BreakClause":
+		status = breaking
+	case "ContinueClause":
+		status = continuing
+	case "IfClause":
+		status = v.executeIf(clause.(abs.IfClauseLike))
+	case "LetClause":
+		v.executeLet(clause.(abs.LetClauseLike))
+	case "ReturnClause":
+		v.result = v.EvaluateExpression(clause.(abs.ReturnClauseLike).GetResult())
+		status = returning
+	case "SelectClause":
+		status = v.executeSelect(clause.(abs.SelectClauseLike))
+	case "ThrowClause":
+		var component = v.EvaluateExpression(clause.(abs.ThrowClauseLike).GetException())
+		panic(&exception{component})
+	case "WhileClause":
+		status = v.executeWhile(clause.(abs.WhileClauseLike))
+	case "WithClause":
+		status = v.executeWith(clause.(abs.WithClauseLike))
+	default:
+		var message = fmt.Sprintf("The interpreter does not yet support the %v.", pro.GetType(clause))
+		panic(message)
+	}
+	return status
+}
+
+// This private method executes the specified if clause.
+func (v *interpreter) executeIf(clause abs.IfClauseLike) signal {
+	var block = clause.GetBlock()
+	if v.isTrue(block.GetExpression()) {
+		return v.executeProcedure(block.GetProcedure())
+	}
+	return proceeding
+}
+
+// This private method executes the specified let clause. A let clause without
+// a recipient simply evaluates its expression.
+func (v *interpreter) executeLet(clause abs.LetClauseLike) {
+	if !clause.HasRecipient() {
+		v.EvaluateExpression(clause.GetExpression())
+		return
+	}
+	var recipient, operator = clause.GetRecipient()
+	var current abs.ComponentLike
+	if operator != abs.ASSIGN {
+		current = v.getRecipient(recipient)
+	}
+	if operator == abs.DEFAULT && current != nil {
+		// The recipient already has a value so the expression is not evaluated.
+		return
+	}
+	var value = v.EvaluateExpression(clause.GetExpression())
+	switch operator {
+	case abs.SUM:
+		value = v.combineValues(current, abs.PLUS, value)
+	case abs.DIFFERENCE:
+		value = v.combineValues(current, abs.MINUS, value)
+	case abs.PRODUCT:
+		value = v.combineValues(current, abs.STAR, value)
+	case abs.QUOTIENT:
+		value = v.combineValues(current, abs.SLASH, value)
+	}
+	v.setRecipient(recipient, value)
+}
+
+// This private method applies the specified arithmetic operator to the current
+// value of a recipient and the specified value.
+func (v *interpreter) combineValues(current abs.ComponentLike, operator abs.Operator, value abs.ComponentLike) abs.ComponentLike {
+	if current == nil {
+		panic("Attempted to update the value of an undefined recipient.")
+	}
+	var expression = exp.Arithmetic(exp.Value(current), operator, exp.Value(value))
+	return v.EvaluateExpression(expression)
+}
+
+// This private method executes the specified select clause by executing the
+// procedure of the first block whose pattern matches the target.
+func (v *interpreter) executeSelect(clause abs.SelectClauseLike) signal {
+	var target = v.EvaluateExpression(clause.GetTarget())
+	for _, block := range clause.GetBlocks().AsArray() {
+		if v.isMatch(target, block.GetExpression()) {
+			return v.executeProcedure(block.GetProcedure())
+		}
+	}
+	return proceeding
+}
+
+// This private method executes the specified while clause.
+func (v *interpreter) executeWhile(clause abs.WhileClauseLike) signal {
+	var block = clause.GetBlock()
+	for v.isTrue(block.GetExpression()) {
+		switch v.executeProcedure(block.GetProcedure()) {
+		case breaking:
+			return proceeding
+		case returning:
+			return returning
+		}
+	}
+	return proceeding
+}
+
+// This private method executes the specified with clause by binding its item
+// to each value in a sequence in turn.
+func (v *interpreter) executeWith(clause abs.WithClauseLike) signal {
+	var item = clause.GetItem()
+	var block = clause.GetBlock()
+	var sequence = v.EvaluateExpression(block.GetExpression())
+	for _, value := range v.evaluator.extractItems(sequence) {
+		v.GetEnvironment().SetValue(item, value)
+		switch v.executeProcedure(block.GetProcedure()) {
+		case breaking:
+			return proceeding
+		case returning:
+			return returning
+		}
+	}
+	return proceeding
+}
+
+// This private method determines whether or not the specified condition
+// evaluates to true.
+func (v *interpreter) isTrue(condition abs.Expression) bool {
+	var value = v.EvaluateExpression(condition).GetEntity()
+	var boolean, ok = value.(abs.BooleanLike)
+	if !ok {
+		var message = fmt.Sprintf("A condition must evaluate to a boolean value: %v", value)
+		panic(message)
+	}
+	return boolean.AsBoolean()
+}
+
+// This private method determines whether or not the specified component matches
+// the pattern that the specified expression evaluates to.
+func (v *interpreter) isMatch(component abs.ComponentLike, pattern abs.Expression) bool {
+	var expression = exp.Comparison(exp.Value(component), abs.MATCHES, pattern)
+	return v.EvaluateExpression(expression).ExtractBoolean().AsBoolean()
+}
+
+// This private method returns the current value of the specified recipient, or
+// nil if the recipient does not yet have a value.
+func (v *interpreter) getRecipient(recipient abs.Recipient) abs.ComponentLike {
+	switch value := recipient.(type) {
+	case abs.SymbolLike:
+		return v.GetEnvironment().GetValue(value)
+	case abs.AttributeLike:
+		var composite, index = v.resolveAttribute(value)
+		switch collection := composite.GetEntity().(type) {
+		case abs.CatalogLike:
+			return collection.GetValue(index)
+		case abs.ListLike:
+			return collection.GetValue(v.evaluator.extractIndex(index))
+		}
+		var message = fmt.Sprintf("Attempted to retrieve an attribute from a non-composite component: %v", composite)
+		panic(message)
+	default:
+		var message = fmt.Sprintf("An invalid recipient type was found: %T", value)
+		panic(message)
+	}
+}
+
+// This private method sets the value of the specified recipient.
+func (v *interpreter) setRecipient(recipient abs.Recipient, component abs.ComponentLike) {
+	switch value := recipient.(type) {
+	case abs.SymbolLike:
+		v.GetEnvironment().SetValue(value, component)
+	case abs.AttributeLike:
+		var composite, index = v.resolveAttribute(value)
+		switch collection := composite.GetEntity().(type) {
+		case abs.CatalogLike:
+			collection.SetValue(index, component)
+		case abs.ListLike:
+			collection.SetValue(v.evaluator.extractIndex(index), component)
+		default:
+			var message = fmt.Sprintf("Attempted to set an attribute of a non-composite component: %v", composite)
+			panic(message)
+		}
+	default:
+		var message = fmt.Sprintf("An invalid recipient type was found: %T", value)
+		panic(message)
+	}
+}
+
+// This private method resolves all but the last index of the specified
+// attribute and returns the resulting composite component along with the
+// value of the last index.
+func (v *interpreter) resolveAttribute(attribute abs.AttributeLike) (abs.ComponentLike, abs.Entity) {
+	var indices = attribute.GetIndices().AsArray()
+	var size = len(indices)
+	var component = v.EvaluateExpression(exp.Variable(attribute.GetVariable()))
+	for _, index := range indices[:size-1] {
+		var key = v.EvaluateExpression(index).GetEntity()
+		component = v.evaluator.getSubcomponent(component, key)
+	}
+	var index = v.EvaluateExpression(indices[size-1]).GetEntity()
+	return component, index
+}
@@ -0,0 +1,226 @@
+/*******************************************************************************
+ *   Copyright (c) 2009-2023 Crater Dog Technologies™.  All Rights Reserved.   *
+ *******************************************************************************
+ * DO NOT ALTER OR REMOVE COPYRIGHT NOTICES OR THIS FILE HEADER.               *
+ *                                                                             *
+ * This code is free software; you can redistribute it and/or modify it under  *
+ * the terms of The MIT License (MIT), as published by the Open Source         *
+ * Initiative. (See http://opensource.org/licenses/MIT)                        *
+ *******************************************************************************/
+
+package agents_test
+
+import (
+	abs "github.com/bali-nebula/go-component-framework/v2/abstractions"
+	age "github.com/bali-nebula/go-component-framework/v2/agents"
+	col "github.com/bali-nebula/go-component-framework/v2/collections"
+	com "github.com/bali-nebula/go-component-framework/v2/components"
+	ele "github.com/bali-nebula/go-component-framework/v2/elements"
+	exp "github.com/bali-nebula/go-component-framework/v2/expressions"
+	ins "github.com/bali-nebula/go-component-framework/v2/intrinsics"
+	pro "github.com/bali-nebula/go-component-framework/v2/procedures"
+	str "github.com/bali-nebula/go-component-framework/v2/strings"
+	cox "github.com/craterdog/go-collection-framework/v2"
+	ass "github.com/stretchr/testify/assert"
+	run "runtime"
+	tes "testing"
+)
+
+func procedure(statements ...abs.StatementLike) abs.ProcedureLike {
+	var list = cox.List[abs.StatementLike]()
+	for _, statement := range statements {
+		list.AddValue(statement)
+	}
+	return pro.ProcedureFromSequence(list)
+}
+
+func statement(clause abs.Clause) abs.StatementLike {
+	return pro.Statement(clause)
+}
+
+func let(identifier string, operator abs.Operator, expression abs.Expression) abs.StatementLike {
+	var symbol = str.SymbolFromString(identifier)
+	return statement(pro.LetClauseWithRecipient(symbol, operator, expression))
+}
+
+func quote(value string) abs.Expression {
+	return exp.Value(com.Component(str.QuoteFromString(value)))
+}
+
+func TestInterpreterWithoutEnvironment(t *tes.T) {
+	defer func() {
+		if e := recover(); e != nil {
+			ass.Equal(t, "An interpreter requires an environment.", e)
+		} else {
+			ass.Fail(t, "Test should result in recovered panic.")
+		}
+	}()
+	age.Interpreter(nil) // This should panic.
+}
+
+func TestInterpreterLoops(t *tes.T) {
+	var list = col.List()
+	for i := 1; i < 5; i++ {
+		list.AddValue(com.Component(ele.Number().FromComplex(complex(float64(i), 0))))
+	}
+	var interpreter = age.Interpreter(com.Context())
+
+	// let $sum := 0
+	// with each $n in [1, 2, 3, 4] do {
+	//     if $n = 3 do {
+	//         continue loop
+	//     }
+	//     let $sum += $n
+	// }
+	// let $count ?= 10
+	// let $count ?= 20
+	// while true do {
+	//     if $sum > 100 do {
+	//         break loop
+	//     }
+	//     let $sum *= 2
+	// }
+	// return $sum - $count
+	var code = procedure(
+		let("sum", abs.ASSIGN, number(0)),
+		statement(pro.WithClause(str.SymbolFromString("n"), pro.Block(
+			exp.Value(com.Component(list)),
+			procedure(
+				statement(pro.IfClause(pro.Block(
+					exp.Comparison(exp.Variable("n"), abs.EQUAL, number(3)),
+					procedure(statement(pro.ContinueClause())),
+				))),
+				let("sum", abs.SUM, exp.Variable("n")),
+			),
+		))),
+		nil,
+		let("count", abs.DEFAULT, number(10)),
+		let("count", abs.DEFAULT, number(20)),
+		statement(pro.WhileClause(pro.Block(
+			boolean(true),
+			procedure(
+				statement(pro.IfClause(pro.Block(
+					exp.Comparison(exp.Variable("sum"), abs.MORE, number(100)),
+					procedure(statement(pro.BreakClause())),
+				))),
+				let("sum", abs.PRODUCT, number(2)),
+			),
+		))),
+		statement(pro.ReturnClause(
+			exp.Arithmetic(exp.Variable("sum"), abs.MINUS, exp.Variable("count")),
+		)),
+	)
+	var result = interpreter.ExecuteProcedure(code)
+	ass.Equal(t, 102.0, result.ExtractNumber().GetReal())
+	var count = interpreter.GetEnvironment().GetValue(str.SymbolFromString("count"))
+	ass.Equal(t, 10.0, count.ExtractNumber().GetReal())
+}
+
+func TestInterpreterDefaultAssignment(t *tes.T) {
+	var calls int
+	var registry = ins.Registry()
+	registry.RegisterIntrinsic("next", ins.Signature([]string{}, "Number"),
+		func(arguments []abs.ComponentLike) abs.ComponentLike {
+			calls++
+			return com.Component(ele.Number().FromComplex(complex(float64(calls), 0)))
+		})
+	var interpreter = age.InterpreterWithRegistry(com.Context(), registry)
+
+	// let $count ?= $next()
+	// let $count ?= $next()
+	// return $count
+	var code = procedure(
+		let("count", abs.DEFAULT, exp.Intrinsic("next", arguments())),
+		let("count", abs.DEFAULT, exp.Intrinsic("next", arguments())),
+		statement(pro.ReturnClause(exp.Variable("count"))),
+	)
+	var result = interpreter.ExecuteProcedure(code)
+	ass.Equal(t, 1.0, result.ExtractNumber().GetReal())
+	ass.Equal(t, 1, calls, "The second expression should not be evaluated.")
+}
+
+func TestInterpreterSelection(t *tes.T) {
+	var interpreter = age.Interpreter(com.Context())
+
+	// select "beta" matching
+	//     "alpha" do {
+	//         return 1
+	//     }
+	//     "^b.*"? do {
+	//         return 2
+	//     }
+	var blocks = cox.List[abs.BlockLike]()
+	blocks.AddValue(pro.Block(quote("alpha"), procedure(
+		statement(pro.ReturnClause(number(1))),
+	)))
+	blocks.AddValue(pro.Block(exp.Value(com.Component(ele.Pattern().FromString(`"^b.*"?`))), procedure(
+		statement(pro.ReturnClause(number(2))),
+	)))
+	var code = procedure(statement(pro.SelectClause(quote("beta"), blocks)))
+	var result = interpreter.ExecuteProcedure(code)
+	ass.Equal(t, 2.0, result.ExtractNumber().GetReal())
+}
+
+func TestInterpreterExceptions(t *tes.T) {
+	var interpreter = age.Interpreter(com.Context())
+
+	// throw "boom"
+	// on $failure matching "boom" do {
+	//     return $failure
+	// }
+	var blocks = cox.List[abs.BlockLike]()
+	blocks.AddValue(pro.Block(quote("boom"), procedure(
+		statement(pro.ReturnClause(exp.Variable("failure"))),
+	)))
+	var handler = pro.OnClause(str.SymbolFromString("failure"), blocks)
+	var code = procedure(pro.StatementWithHandler(pro.ThrowClause(quote("boom")), handler))
+	var result = interpreter.ExecuteProcedure(code)
+	ass.Equal(t, "boom", result.ExtractQuote().AsString())
+
+	// throw "bust"
+	// on $failure matching "boom" do {
+	//     return $failure
+	// }
+	code = procedure(pro.StatementWithHandler(pro.ThrowClause(quote("bust")), handler))
+	defer func() {
+		if e := recover(); e != nil {
+			var component = e.(abs.ComponentLike)
+			ass.Equal(t, "bust", component.ExtractQuote().AsString())
+		} else {
+			ass.Fail(t, "Test should result in recovered panic.")
+		}
+	}()
+	interpreter.ExecuteProcedure(code) // This should panic.
+}
+
+func TestInterpreterForeignFailures(t *tes.T) {
+	var registry = ins.Registry()
+	registry.RegisterIntrinsic("broken", ins.Signature([]string{}, "Any"),
+		func(arguments []abs.ComponentLike) abs.ComponentLike {
+			return arguments[0] // This causes a runtime error.
+		})
+	var interpreter = age.InterpreterWithRegistry(com.Context(), registry)
+
+	// return $broken()
+	// on $failure matching any do {
+	//     return $failure
+	// }
+	var blocks = cox.List[abs.BlockLike]()
+	blocks.AddValue(pro.Block(exp.Value(com.Component(ele.Pattern().Any())), procedure(
+		statement(pro.ReturnClause(exp.Variable("failure"))),
+	)))
+	var handler = pro.OnClause(str.SymbolFromString("failure"), blocks)
+	var code = procedure(pro.StatementWithHandler(
+		pro.ReturnClause(exp.Intrinsic("broken", arguments())),
+		handler,
+	))
+	defer func() {
+		if e := recover(); e != nil {
+			var _, ok = e.(run.Error)
+			ass.True(t, ok, "The runtime error should not be handled as an exception.")
+		} else {
+			ass.Fail(t, "Test should result in recovered panic.")
+		}
+	}()
+	interpreter.ExecuteProcedure(code) // This should panic.
+}
@@ -0,0 +1,167 @@
+/*******************************************************************************
+ *   Copyright (c) 2009-2023 Crater Dog Technologies™.  All Rights Reserved.   *
+ *******************************************************************************
+ * DO NOT ALTER OR REMOVE COPYRIGHT NOTICES OR THIS FILE HEADER.               *
+ *                                                                             *
+ * This code is free software; you can redistribute it and/or modify it under  *
+ * the terms of The MIT License (MIT), as published by the Open Source         *
+ * Initiative. (See http://opensource.org/licenses/MIT)                        *
+ *******************************************************************************/
+
+package agents
+
+import (
+	ed2 "crypto/ed25519"
+	ran "crypto/rand"
+	fmt "fmt"
+	abs "github.com/bali-nebula/go-component-framework/v2/abstractions"
+	bal "github.com/bali-nebula/go-component-framework/v2/bali"
+	col "github.com/bali-nebula/go-component-framework/v2/collections"
+	com "github.com/bali-nebula/go-component-framework/v2/components"
+	ele "github.com/bali-nebula/go-component-framework/v2/elements"
+	str "github.com/bali-nebula/go-component-framework/v2/strings"
+)
+
+// NOTARY IMPLEMENTATION
+
+// This constructor creates a new digital notary that signs documents on behalf
+// of the owner of the specified certificate using a newly generated Ed25519
+// private key.
+func Notary(certificate abs.CitationLike) abs.NotaryLike {
+	var _, key, err = ed2.GenerateKey(ran.Reader)
+	if err != nil {
+		var message = fmt.Sprintf("Could not generate a private key for the notary: %v.", err)
+		panic(message)
+	}
+	return newNotary(certificate, key)
+}
+
+// This constructor creates a new digital notary like the Notary() constructor
+// does but derives its Ed25519 private key from the specified 32 byte seed.
+func NotaryWithSeed(certificate abs.CitationLike, seed abs.BinaryLike) abs.NotaryLike {
+	if seed == nil || len(seed.AsArray()) != ed2.SeedSize {
+		var message = fmt.Sprintf("A notary requires a private key seed of %v bytes.", ed2.SeedSize)
+		panic(message)
+	}
+	return newNotary(certificate, ed2.NewKeyFromSeed(seed.AsArray()))
+}
+
+// This private constructor creates a new digital notary with the specified
+// certificate and private key.
+func newNotary(certificate abs.CitationLike, key ed2.PrivateKey) *notary {
+	if certificate == nil {
+		panic("A notary requires a certificate citation.")
+	}
+	return &notary{certificate: certificate, key: key}
+}
+
+// This type defines the structure and methods associated with a digital
+// notary agent.
+type notary struct {
+	certificate abs.CitationLike
+	key         ed2.PrivateKey
+}
+
+// NOTARIAL INTERFACE
+
+// This method returns the citation to the certificate of this notary.
+func (v *notary) GetCertificate() abs.CitationLike {
+	return v.certificate
+}
+
+// This method returns the Ed25519 public key of this notary.
+func (v *notary) GetPublicKey() abs.BinaryLike {
+	return str.BinaryFromArray(v.key.Public().(ed2.PublicKey))
+}
+
+// This method returns a notarized version of the specified document. The
+// notarized document is a catalog containing the document, the citation to
+// the certificate of this notary, the moment it was notarized and the Ed25519
+// signature of the canonical Bali source form of the other three attributes:
+//
+//	[
+//	    $content: <document>
+//	    $certificate: <citation>
+//	    $timestamp: <moment>
+//	    $signature: <binary>
+//	]
+func (v *notary) NotarizeDocument(document abs.ComponentLike) abs.ComponentLike {
+	if document == nil {
+		panic("A notary cannot notarize a nil document.")
+	}
+	var timestamp = ele.Moment().Now()
+	var contract = createContract(document, v.certificate, timestamp)
+	var signature = ed2.Sign(v.key, bal.FormatDocument(com.Component(contract)))
+	contract.SetValue(str.SymbolFromString("signature"), com.Component(str.BinaryFromArray(signature)))
+	return com.Component(contract)
+}
+
+// This method determines whether or not the specified notarized document was
+// notarized by this notary and has not been altered since. A component that
+// is not a notarized document is never valid.
+func (v *notary) VerifyDocument(document abs.ComponentLike) bool {
+	var certificate = GetNotaryCertificate(document)
+	if certificate == nil || certificate.AsString() != v.certificate.AsString() {
+		return false
+	}
+	return VerifyDocument(document, v.GetPublicKey())
+}
+
+// NOTARY FUNCTIONS
+
+// This function returns the citation to the certificate of the notary that
+// notarized the specified document, or nil if the component is not a notarized
+// document. The certificate holds the public key needed to verify the document.
+func GetNotaryCertificate(document abs.ComponentLike) (certificate abs.CitationLike) {
+	defer func() {
+		if e := recover(); e != nil {
+			certificate = nil
+		}
+	}()
+	var catalog = document.ExtractCatalog()
+	return catalog.GetValue(str.SymbolFromString("certificate")).ExtractCitation()
+}
+
+// This function determines whether or not the specified notarized document
+// was signed using the private key that corresponds to the specified Ed25519
+// public key and has not been altered since. It allows anyone holding the
+// public key of a notary, typically taken from the certificate that the
+// document cites, to verify the document without access to the notary itself.
+// A component that is not a notarized document is never valid.
+func VerifyDocument(document abs.ComponentLike, publicKey abs.BinaryLike) (valid bool) {
+	defer func() {
+		if e := recover(); e != nil {
+			valid = false
+		}
+	}()
+	if publicKey == nil || len(publicKey.AsArray()) != ed2.PublicKeySize {
+		return false
+	}
+	var catalog = document.ExtractCatalog()
+	var content = catalog.GetValue(str.SymbolFromString("content"))
+	var certificate = catalog.GetValue(str.SymbolFromString("certificate")).ExtractCitation()
+	var timestamp = catalog.GetValue(str.SymbolFromString("timestamp")).ExtractMoment()
+	var signature = catalog.GetValue(str.SymbolFromString("signature")).ExtractBinary()
+	if content == nil {
+		return false
+	}
+	var contract = createContract(content, certificate, timestamp)
+	var public = ed2.PublicKey(publicKey.AsArray())
+	return ed2.Verify(public, bal.FormatDocument(com.Component(contract)), signature.AsArray())
+}
+
+// PRIVATE FUNCTIONS
+
+// This private function returns a new catalog containing the signed attributes
+// of a notarized document.
+func createContract(
+	content abs.ComponentLike,
+	certificate abs.CitationLike,
+	timestamp abs.MomentLike,
+) abs.CatalogLike {
+	var contract = col.Catalog()
+	contract.SetValue(str.SymbolFromString("content"), content)
+	contract.SetValue(str.SymbolFromString("certificate"), com.Component(certificate))
+	contract.SetValue(str.SymbolFromString("timestamp"), com.Component(timestamp))
+	return contract
+}
@@ -0,0 +1,113 @@
+/*******************************************************************************
+ *   Copyright (c) 2009-2023 Crater Dog Technologies™.  All Rights Reserved.   *
+ *******************************************************************************
+ * DO NOT ALTER OR REMOVE COPYRIGHT NOTICES OR THIS FILE HEADER.               *
+ *                                                                             *
+ * This code is free software; you can redistribute it and/or modify it under  *
+ * the terms of The MIT License (MIT), as published by the Open Source         *
+ * Initiative. (See http://opensource.org/licenses/MIT)                        *
+ *******************************************************************************/
+
+package agents_test
+
+import (
+	age "github.com/bali-nebula/go-component-framework/v2/agents"
+	bal "github.com/bali-nebula/go-component-framework/v2/bali"
+	com "github.com/bali-nebula/go-component-framework/v2/components"
+	ele "github.com/bali-nebula/go-component-framework/v2/elements"
+	exp "github.com/bali-nebula/go-component-framework/v2/expressions"
+	pro "github.com/bali-nebula/go-component-framework/v2/procedures"
+	rep "github.com/bali-nebula/go-component-framework/v2/repositories"
+	str "github.com/bali-nebula/go-component-framework/v2/strings"
+	ass "github.com/stretchr/testify/assert"
+	tes "testing"
+)
+
+func TestNotarizeDocuments(t *tes.T) {
+	var certificate = ele.Citation().FromString("/acme/certificates/Notary/v1")
+	var notary = age.Notary(certificate)
+	ass.Equal(t, certificate, notary.GetCertificate())
+	ass.Equal(t, 32, len(notary.GetPublicKey().AsArray()))
+
+	var document = bal.ParseComponent("[$total: 5, $items: [$apple, $banana]]")
+	var contract = notary.NotarizeDocument(document)
+	var catalog = contract.ExtractCatalog()
+	ass.Equal(t, bal.FormatDocument(document), bal.FormatDocument(catalog.GetValue(str.SymbolFromString("content"))))
+	ass.Equal(t, certificate.AsString(), catalog.GetValue(str.SymbolFromString("certificate")).ExtractCitation().AsString())
+	ass.NotNil(t, catalog.GetValue(str.SymbolFromString("timestamp")).ExtractMoment())
+	ass.Equal(t, 64, len(catalog.GetValue(str.SymbolFromString("signature")).ExtractBinary().AsArray()))
+	ass.True(t, notary.VerifyDocument(contract))
+
+	// A notarized document survives a round trip through its Bali source form.
+	contract = bal.ParseDocument(bal.FormatDocument(contract))
+	ass.True(t, notary.VerifyDocument(contract))
+
+	// A notarized document that has been altered is rejected.
+	contract.ExtractCatalog().GetValue(str.SymbolFromString("content")).ExtractCatalog().SetValue(str.SymbolFromString("total"), numeric(6))
+	ass.False(t, notary.VerifyDocument(contract))
+
+	// A document notarized by a different notary is rejected.
+	var other = age.Notary(certificate)
+	ass.False(t, other.VerifyDocument(notary.NotarizeDocument(document)))
+
+	// A document that was never notarized is rejected.
+	ass.False(t, notary.VerifyDocument(document))
+}
+
+func TestVerifyWithPublicKey(t *tes.T) {
+	var certificate = ele.Citation().FromString("/acme/certificates/Notary/v1")
+	var notary = age.Notary(certificate)
+	var document = bal.ParseComponent("[$total: 5]")
+	var contract = bal.ParseDocument(bal.FormatDocument(notary.NotarizeDocument(document)))
+
+	// Anyone holding the public key of the notary can verify the document.
+	ass.Equal(t, certificate.AsString(), age.GetNotaryCertificate(contract).AsString())
+	ass.True(t, age.VerifyDocument(contract, notary.GetPublicKey()))
+	ass.False(t, age.VerifyDocument(contract, age.Notary(certificate).GetPublicKey()))
+	ass.False(t, age.VerifyDocument(contract, str.BinaryFromArray([]byte("short"))))
+	ass.False(t, age.VerifyDocument(contract, nil))
+
+	// A document that was never notarized has no certificate and is rejected.
+	ass.Nil(t, age.GetNotaryCertificate(document))
+	ass.False(t, age.VerifyDocument(document, notary.GetPublicKey()))
+}
+
+func TestNotaryWithSeed(t *tes.T) {
+	var certificate = ele.Citation().FromString("/acme/certificates/Notary/v1")
+	var seed = str.BinaryFromArray([]byte("0123456789abcdef0123456789abcdef"))
+	var first = age.NotaryWithSeed(certificate, seed)
+	var second = age.NotaryWithSeed(certificate, seed)
+	ass.Equal(t, first.GetPublicKey().AsString(), second.GetPublicKey().AsString())
+	var document = bal.ParseComponent("[$total: 5]")
+	ass.True(t, second.VerifyDocument(first.NotarizeDocument(document)))
+
+	defer func() {
+		if e := recover(); e != nil {
+			ass.Equal(t, "A notary requires a private key seed of 32 bytes.", e)
+		} else {
+			ass.Fail(t, "Test should result in recovered panic.")
+		}
+	}()
+	age.NotaryWithSeed(certificate, str.BinaryFromArray([]byte("short"))) // This should panic.
+}
+
+func TestProcessorNotarization(t *tes.T) {
+	var processor = age.Processor(com.Context())
+	var repository = rep.LocalRepository(t.TempDir())
+	var notary = age.Notary(ele.Citation().FromString("/acme/certificates/Notary/v1"))
+	processor.SetRepository(repository)
+	processor.SetNotary(notary)
+
+	// notarize [$total: 5] as /acme/orders/Order/v1
+	var document = exp.Value(bal.ParseComponent("[$total: 5]"))
+	var citation = exp.Value(com.Component(ele.Citation().FromString("/acme/orders/Order/v1")))
+	var code = procedure(
+		statement(pro.NotarizeClause(document, citation)),
+	)
+	processor.ExecuteProgram(age.Compiler().CompileProcedure(code))
+	var name = str.NameFromString("/acme/orders/Order")
+	var contract = repository.RetrieveDocument(name, str.VersionFromString("1"))
+	ass.True(t, notary.VerifyDocument(contract))
+	var content = contract.ExtractCatalog().GetValue(str.SymbolFromString("content"))
+	ass.Equal(t, 5.0, content.ExtractCatalog().GetValue(str.SymbolFromString("total")).ExtractNumber().GetReal())
+}
@@ -0,0 +1,901 @@
+/*******************************************************************************
+ *   Copyright (c) 2009-2023 Crater Dog Technologies™.  All Rights Reserved.   *
+ *******************************************************************************
+ * DO NOT ALTER OR REMOVE COPYRIGHT NOTICES OR THIS FILE HEADER.               *
+ *                                                                             *
+ * This code is free software; you can redistribute it and/or modify it under  *
+ * the terms of The MIT License (MIT), as published by the Open Source         *
+ * Initiative. (See http://opensource.org/licenses/MIT)                        *
+ *******************************************************************************/
+
+package agents
+
+import (
+	fmt "fmt"
+	abs "github.com/bali-nebula/go-component-framework/v2/abstractions"
+	bal "github.com/bali-nebula/go-component-framework/v2/bali"
+	col "github.com/bali-nebula/go-component-framework/v2/collections"
+	com "github.com/bali-nebula/go-component-framework/v2/components"
+	ele "github.com/bali-nebula/go-component-framework/v2/elements"
+	exp "github.com/bali-nebula/go-component-framework/v2/expressions"
+	ins "github.com/bali-nebula/go-component-framework/v2/intrinsics"
+	rep "github.com/bali-nebula/go-component-framework/v2/repositories"
+	str "github.com/bali-nebula/go-component-framework/v2/strings"
+	cox "github.com/craterdog/go-collection-framework/v2"
+	sts "strings"
+	syn "sync"
+)
+
+// PROCESSOR IMPLEMENTATION
+
+// These constants define the limits of the stacks used by a processor.
+const (
+	componentCapacity = 1024
+	frameCapacity     = 256
+)
+
+// This constructor creates a new processor (a bytecode virtual machine) that
+// executes programs within the specified environment. The processor maintains
+// a stack of components that the instructions operate on and a stack of call
+// frames, one for each program that is currently executing. Each call frame
+// has its own variables and its own stack of exception handler addresses.
+//
+// The processor comes with a built-in function for each operator supported by
+// the compiler along with the intrinsic functions in the standard library. The
+// built-in functions are named by reserved symbols so they never shadow an
+// intrinsic function with the same name. Additional intrinsic functions and
+// methods may be added using the SetIntrinsic() and SetMethod() methods. A
+// method that is not found in the method table is looked up in the catalog of
+// the target component. If the catalog contains a procedure with that name, the
+// procedure is executed in a new call frame with the target component bound to
+// the $target variable and each argument bound to the corresponding parameter
+// of the procedure. Any other method is bound to the entity of the target
+// component by the dispatcher of the processor. An asynchronous (<-) invocation
+// is sent as a message to the scheduler of the processor and results in a
+// future component that is resolved once the method has been delivered. The
+// post and retrieve clauses access the message bag that is the value of their
+// bag expression, and a retrieved message is leased from that bag until it is
+// accepted or rejected by the procedure. The publish clauses publish their
+// events to the event bus of the processor, if one has been set, in the order
+// they are executed. The checkout, save, discard and notarize clauses access
+// the documents and drafts in the repository of the processor, if one has been
+// set, and a document is notarized by the notary of the processor, if one has
+// been set, before it is committed. The save clause results in a
+// content-addressed citation to the saved draft.
+func Processor(environment abs.ContextLike) abs.ProcessorLike {
+	return ProcessorWithRegistry(environment, ins.StandardLibrary())
+}
+
+// This constructor creates a new processor like the Processor() constructor
+// does but uses the intrinsic functions defined in the specified registry
+// instead of the standard library.
+func ProcessorWithRegistry(environment abs.ContextLike, registry abs.RegistryLike) abs.ProcessorLike {
+	if environment == nil {
+		panic("A processor requires an environment.")
+	}
+	if registry == nil {
+		panic("A processor requires a registry of intrinsic functions.")
+	}
+	var v = &processor{
+		evaluator: newEvaluator(environment, registry),
+		compiler:  Compiler(),
+		operators: make(map[string]abs.Intrinsic),
+		tables: &tables{
+			intrinsics: make(map[string]abs.Intrinsic),
+			methods:    make(map[string]abs.Method),
+		},
+		programs: make(map[abs.ProcedureLike]abs.ProgramLike),
+		leases: &ledger{
+			bags:      make(map[abs.ComponentLike]abs.MessageBagLike),
+			locations: make(map[abs.ComponentLike]*location),
+		},
+		components: col.StackWithCapacity(componentCapacity),
+	}
+	v.scheduler = Scheduler(v.deliverMessage)
+	v.addOperators()
+	return v
+}
+
+// This type defines the structure and methods associated with a processor
+// agent.
+type processor struct {
+	evaluator  *evaluator
+	compiler   abs.CompilerLike
+	operators  map[string]abs.Intrinsic
+	tables     *tables
+	programs   map[abs.ProcedureLike]abs.ProgramLike
+	scheduler  abs.SchedulerLike
+	leases     *ledger
+	bus        abs.EventBusLike
+	repository abs.RepositoryLike
+	notary     abs.NotaryLike
+	components abs.StackLike
+	frames     []*frame
+	result     abs.ComponentLike
+}
+
+// This type defines the structure of a call frame for a program that is being
+// executed by a processor.
+type frame struct {
+	program      abs.ProgramLike
+	instructions []abs.Instruction
+	environment  abs.ContextLike
+	address      int
+	handlers     []int
+	depth        int
+	isBase       bool
+}
+
+// This type defines the structure of the intrinsic and method tables of a
+// processor. The tables are shared by a processor and the processors that
+// deliver its asynchronous messages.
+type tables struct {
+	mutex      syn.RWMutex
+	intrinsics map[string]abs.Intrinsic
+	methods    map[string]abs.Method
+}
+
+// This private method adds the specified intrinsic function to the intrinsic
+// table.
+func (v *tables) setIntrinsic(name string, intrinsic abs.Intrinsic) {
+	v.mutex.Lock()
+	defer v.mutex.Unlock()
+	v.intrinsics[name] = intrinsic
+}
+
+// This private method returns the intrinsic function with the specified name
+// from the intrinsic table, or nil if it is not there.
+func (v *tables) getIntrinsic(name string) abs.Intrinsic {
+	v.mutex.RLock()
+	defer v.mutex.RUnlock()
+	return v.intrinsics[name]
+}
+
+// This private method adds the specified method to the method table.
+func (v *tables) setMethod(name string, method abs.Method) {
+	v.mutex.Lock()
+	defer v.mutex.Unlock()
+	v.methods[name] = method
+}
+
+// This private method returns the method with the specified name from the
+// method table, or nil if it is not there.
+func (v *tables) getMethod(name string) abs.Method {
+	v.mutex.RLock()
+	defer v.mutex.RUnlock()
+	return v.methods[name]
+}
+
+// This type defines the structure of the ledger that records the message bag
+// that each leased message was retrieved from, so that the message can later be
+// accepted or rejected, and the location in the repository of each document
+// and draft that was loaded or checked out, so that the draft can later be
+// saved or discarded. Each message retrieved from a message bag is a distinct
+// component, so the ledger holds a separate lease for each retrieval even when
+// the same message was posted more than once. A location is removed once its
+// document has been checked out, or its draft has been committed or discarded.
+// The ledger is shared by a processor and the processors that deliver its
+// asynchronous messages.
+type ledger struct {
+	mutex     syn.Mutex
+	bags      map[abs.ComponentLike]abs.MessageBagLike
+	locations map[abs.ComponentLike]*location
+}
+
+// This type defines the structure of the location of a version of a document
+// in a repository.
+type location struct {
+	name    abs.NameLike
+	version abs.VersionLike
+}
+
+// This private method records that the specified message was leased from the
+// specified message bag.
+func (v *ledger) addLease(message abs.ComponentLike, bag abs.MessageBagLike) {
+	v.mutex.Lock()
+	defer v.mutex.Unlock()
+	v.bags[message] = bag
+}
+
+// This private method removes the lease on the specified message from the
+// ledger and returns the message bag it was leased from.
+func (v *ledger) removeLease(message abs.ComponentLike) abs.MessageBagLike {
+	v.mutex.Lock()
+	defer v.mutex.Unlock()
+	var bag, ok = v.bags[message]
+	if !ok {
+		var message = fmt.Sprintf("The processor did not retrieve the message from a message bag: %v", message.GetEntity())
+		panic(message)
+	}
+	delete(v.bags, message)
+	return bag
+}
+
+// This private method records the location in the repository of the specified
+// document or draft.
+func (v *ledger) addLocation(document abs.ComponentLike, location *location) {
+	v.mutex.Lock()
+	defer v.mutex.Unlock()
+	v.locations[document] = location
+}
+
+// This private method returns the location in the repository of the specified
+// document or draft.
+func (v *ledger) getLocation(document abs.ComponentLike) *location {
+	v.mutex.Lock()
+	defer v.mutex.Unlock()
+	var location, ok = v.locations[document]
+	if !ok {
+		var message = fmt.Sprintf("The processor did not load or check out the document: %v", document.GetEntity())
+		panic(message)
+	}
+	return location
+}
+
+// This private method removes the location of the specified document or draft
+// from the ledger, if it is there.
+func (v *ledger) removeLocation(document abs.ComponentLike) {
+	v.mutex.Lock()
+	defer v.mutex.Unlock()
+	delete(v.locations, document)
+}
+
+// PUBLIC INTERFACE
+
+// This method returns the environment used by this processor.
+func (v *processor) GetEnvironment() abs.ContextLike {
+	return v.evaluator.environment
+}
+
+// This method returns the dispatcher used by this processor to bind the
+// methods that are not in its method table.
+func (v *processor) GetDispatcher() abs.DispatcherLike {
+	return v.evaluator.dispatcher
+}
+
+// This method adds the specified intrinsic function to the intrinsic table of
+// this processor, replacing any existing function with the same name.
+func (v *processor) SetIntrinsic(name string, intrinsic abs.Intrinsic) {
+	if intrinsic == nil {
+		var message = fmt.Sprintf("The intrinsic function $%v requires an implementation.", name)
+		panic(message)
+	}
+	v.tables.setIntrinsic(name, intrinsic)
+}
+
+// This method adds the specified method to the method table of this processor,
+// replacing any existing method with the same name.
+func (v *processor) SetMethod(name string, method abs.Method) {
+	if method == nil {
+		var message = fmt.Sprintf("The method $%v requires an implementation.", name)
+		panic(message)
+	}
+	v.tables.setMethod(name, method)
+}
+
+// This method sets the event bus that the publish clauses executed by this
+// processor publish their events to.
+func (v *processor) SetEventBus(bus abs.EventBusLike) {
+	v.bus = bus
+}
+
+// This method sets the repository that the checkout, save, discard and
+// notarize clauses executed by this processor access their documents in.
+func (v *processor) SetRepository(repository abs.RepositoryLike) {
+	v.repository = repository
+}
+
+// This method sets the notary that notarizes each document committed by the
+// notarize clauses executed by this processor.
+func (v *processor) SetNotary(notary abs.NotaryLike) {
+	v.notary = notary
+}
+
+// This method executes the specified program within the environment of this
+// processor and returns the result of its return clause, or nil if the program
+// completes without returning a result. An exception that is not handled by
+// the program causes the processor to panic with the exception component.
+func (v *processor) ExecuteProgram(program abs.ProgramLike) abs.ComponentLike {
+	if program == nil {
+		panic("The processor requires a program to execute.")
+	}
+	var depth = len(v.frames)
+	v.pushFrame(program, v.GetEnvironment())
+	v.frames[depth].isBase = true
+	for len(v.frames) > depth {
+		v.step(depth)
+	}
+	var result = v.result
+	v.result = nil
+	return result
+}
+
+// PRIVATE METHODS
+
+// This private method executes the next instruction in the current call frame.
+// Any exception that is thrown during its execution is handed to the nearest
+// exception handler. Any other failure, like a Go runtime error, is not an
+// exception and propagates out of the processor.
+func (v *processor) step(depth int) {
+	defer func() {
+		if e := recover(); e != nil {
+			v.handleException(depth, e)
+		}
+	}()
+	v.executeInstruction(v.frames[len(v.frames)-1])
+}
+
+// This private method unwinds the call frames until it finds a frame with an
+// exception handler for the specified failure. The failure propagates out of
+// the processor if no such frame exists.
+func (v *processor) handleException(depth int, failure any) {
+	if !isException(failure) {
+		for len(v.frames) > depth {
+			v.popFrame()
+		}
+		panic(failure)
+	}
+	var component = describeFailure(failure)
+	for len(v.frames) > depth {
+		var current = v.frames[len(v.frames)-1]
+		var count = len(current.handlers)
+		if count > 0 {
+			v.truncateComponents(current.depth)
+			v.components.AddValue(component)
+			current.address = current.handlers[count-1]
+			current.handlers = current.handlers[:count-1]
+			return
+		}
+		v.popFrame()
+	}
+	if depth > 0 {
+		// Let the enclosing execution handle the exception.
+		panic(&exception{component})
+	}
+	panic(component)
+}
+
+// This private method pushes a new call frame for the specified program onto
+// the call stack.
+func (v *processor) pushFrame(program abs.ProgramLike, environment abs.ContextLike) {
+	if len(v.frames) == frameCapacity {
+		var message = fmt.Sprintf("The processor has exceeded its maximum call depth: %v", frameCapacity)
+		panic(message)
+	}
+	var current = &frame{
+		program:      program,
+		instructions: program.GetBytecode().AsArray(),
+		environment:  environment,
+		address:      1,
+		depth:        v.components.GetSize(),
+	}
+	v.frames = append(v.frames, current)
+}
+
+// This private method pops the current call frame off of the call stack and
+// discards any components it left on the component stack.
+func (v *processor) popFrame() *frame {
+	var count = len(v.frames)
+	var current = v.frames[count-1]
+	v.frames = v.frames[:count-1]
+	v.truncateComponents(current.depth)
+	return current
+}
+
+// This private method returns from the current call frame with the specified
+// result.
+func (v *processor) returnResult(result abs.ComponentLike) {
+	var current = v.popFrame()
+	if current.isBase {
+		v.result = result
+		return
+	}
+	if result == nil {
+		result = v.none()
+	}
+	v.components.AddValue(result)
+}
+
+// This private method removes components from the component stack until it
+// contains only the specified number of components.
+func (v *processor) truncateComponents(depth int) {
+	for v.components.GetSize() > depth {
+		v.components.RemoveTop()
+	}
+}
+
+// This private method removes the specified number of components from the top
+// of the component stack and returns them in the order they were pushed.
+func (v *processor) pullComponents(count int) []abs.ComponentLike {
+	var components = make([]abs.ComponentLike, count)
+	for index := count - 1; index >= 0; index-- {
+		components[index] = v.components.RemoveTop()
+	}
+	return components
+}
+
+// This private method returns a new component containing the none pattern.
+func (v *processor) none() abs.ComponentLike {
+	return com.Component(ele.Pattern().None())
+}
+
+// This private method determines whether or not the specified component is
+// none.
+func (v *processor) isNone(component abs.ComponentLike) bool {
+	return cox.CompareValues(component.GetEntity(), ele.Pattern().None())
+}
+
+// This private method executes the next instruction in the specified call
+// frame. Reaching the end of the bytecode returns from the frame without a
+// result.
+func (v *processor) executeInstruction(current *frame) {
+	if current.address > len(current.instructions) {
+		v.returnResult(nil)
+		return
+	}
+	var instruction = current.instructions[current.address-1]
+	current.address++
+	var modifier = instruction.GetModifier()
+	var operand = instruction.GetOperand()
+	switch instruction.GetOperation() {
+	case abs.JUMP:
+		v.executeJump(current, modifier, operand)
+	case abs.PUSH:
+		v.executePush(current, modifier, operand)
+	case abs.PULL:
+		v.executePull(current, modifier)
+	case abs.LOAD:
+		v.executeLoad(current, modifier, operand)
+	case abs.STORE:
+		v.executeStore(current, modifier, operand)
+	case abs.INVOKE:
+		var name = current.program.GetSymbol(operand).AsString()
+		var arguments = v.pullComponents(int(modifier))
+		v.components.AddValue(v.invokeIntrinsic(name, arguments))
+	case abs.CALL:
+		var name = current.program.GetSymbol(operand).AsString()
+		var arguments = v.pullComponents(int(modifier))
+		var target = v.components.RemoveTop()
+		v.callMethod(target, name, arguments)
+	case abs.SEND:
+		var name = current.program.GetSymbol(operand).AsString()
+		var arguments = v.pullComponents(int(modifier))
+		var target = v.components.RemoveTop()
+		v.components.AddValue(com.Component(v.scheduler.SendMessage(target, name, arguments)))
+	}
+}
+
+// This private method executes a JUMP instruction.
+func (v *processor) executeJump(current *frame, modifier abs.Modifier, address int) {
+	var isJumping bool
+	switch modifier {
+	case abs.ALWAYS:
+		// A jump to address zero is a SKIP instruction.
+		isJumping = address > 0
+	case abs.ONNONE:
+		isJumping = v.isNone(v.components.RemoveTop())
+	case abs.ONFALSE:
+		isJumping = !v.components.RemoveTop().ExtractBoolean().AsBoolean()
+	case abs.ONTRUE:
+		isJumping = v.components.RemoveTop().ExtractBoolean().AsBoolean()
+	}
+	if isJumping {
+		current.address = address
+	}
+}
+
+// This private method executes a PUSH instruction.
+func (v *processor) executePush(current *frame, modifier abs.Modifier, operand int) {
+	switch modifier {
+	case abs.HANDLER:
+		current.handlers = append(current.handlers, operand)
+	case abs.LITERAL:
+		v.components.AddValue(current.program.GetLiteral(operand))
+	default:
+		var message = fmt.Sprintf("An invalid PUSH modifier was found: %v", modifier)
+		panic(message)
+	}
+}
+
+// This private method executes a PULL instruction.
+func (v *processor) executePull(current *frame, modifier abs.Modifier) {
+	switch modifier {
+	case abs.HANDLER:
+		current.handlers = current.handlers[:len(current.handlers)-1]
+	case abs.COMPONENT:
+		v.components.RemoveTop()
+	case abs.RESULT:
+		v.returnResult(v.components.RemoveTop())
+	case abs.EXCEPTION:
+		panic(&exception{v.components.RemoveTop()})
+	}
+}
+
+// This private method executes a LOAD instruction. A variable that has not
+// been assigned a value is none.
+func (v *processor) executeLoad(current *frame, modifier abs.Modifier, operand int) {
+	var symbol = current.program.GetSymbol(operand)
+	switch modifier {
+	case abs.VARIABLE:
+		var value = current.environment.GetValue(symbol)
+		if value == nil {
+			value = v.none()
+		}
+		v.components.AddValue(value)
+	case abs.MESSAGE:
+		var bag = v.extractBag(current.environment.GetValue(symbol))
+		var message = bag.RetrieveMessage()
+		if message == nil {
+			message = v.none()
+		} else {
+			v.leases.addLease(message, bag)
+		}
+		v.components.AddValue(message)
+	case abs.DOCUMENT:
+		v.components.AddValue(v.loadDocument(current.environment.GetValue(symbol)))
+	default:
+		var message = fmt.Sprintf("The processor does not support loading a draft: $%v", symbol.AsString())
+		panic(message)
+	}
+}
+
+// This private method executes a STORE instruction.
+func (v *processor) executeStore(current *frame, modifier abs.Modifier, operand int) {
+	var symbol = current.program.GetSymbol(operand)
+	switch modifier {
+	case abs.VARIABLE:
+		current.environment.SetValue(symbol, v.components.RemoveTop())
+	case abs.MESSAGE:
+		var bag = v.extractBag(current.environment.GetValue(symbol))
+		bag.PostMessage(v.components.RemoveTop())
+	case abs.DRAFT:
+		var draft = v.components.RemoveTop()
+		var location = v.leases.getLocation(draft)
+		var citation = v.getRepository().SaveDraft(location.name, location.version, draft)
+		current.environment.SetValue(symbol, citation)
+	case abs.DOCUMENT:
+		var document = v.components.RemoveTop()
+		var location = v.locateDocument(current.environment.GetValue(symbol), false)
+		v.leases.removeLocation(document)
+		if v.notary != nil {
+			document = v.notary.NotarizeDocument(document)
+		}
+		v.getRepository().CommitDocument(location.name, location.version, document)
+	}
+}
+
+// This private method returns the repository of this processor.
+func (v *processor) getRepository() abs.RepositoryLike {
+	if v.repository == nil {
+		panic("The processor does not have a repository to access documents in.")
+	}
+	return v.repository
+}
+
+// This private method returns the location in the repository of the document
+// that the specified citation or name refers to. A name that does not end with
+// a version refers to the latest committed version of the document if the
+// latest version is allowed, and is invalid otherwise.
+func (v *processor) locateDocument(reference abs.ComponentLike, latest bool) *location {
+	var result = &location{}
+	switch actual := reference.GetEntity().(type) {
+	case abs.CitationLike:
+		result.name = str.NameFromString(actual.GetName())
+		result.version = str.VersionFromString(sts.TrimPrefix(actual.GetVersion(), "v"))
+	case abs.NameLike:
+		var identifiers = actual.AsArray()
+		var last = string(identifiers[len(identifiers)-1])
+		if len(identifiers) > 1 && v.isVersion(last) {
+			result.name = str.NameFromArray(identifiers[:len(identifiers)-1])
+			result.version = str.VersionFromString(last[1:])
+			break
+		}
+		result.name = actual
+		if latest {
+			result.version = v.getRepository().GetLatestVersion(actual)
+		}
+		if result.version == nil {
+			var message = fmt.Sprintf("The processor requires a specific version of the document: %v", actual.AsString())
+			panic(message)
+		}
+	default:
+		var message = fmt.Sprintf("Attempted to locate a document using an invalid reference: %v", actual)
+		panic(message)
+	}
+	return result
+}
+
+// This private method determines whether or not the specified identifier is a
+// version string (e.g. "v1.2").
+func (v *processor) isVersion(identifier string) (result bool) {
+	defer func() {
+		if e := recover(); e != nil {
+			result = false
+		}
+	}()
+	str.VersionFromString(sts.TrimPrefix(identifier, "v"))
+	return sts.HasPrefix(identifier, "v")
+}
+
+// This private method loads the committed document, or else the draft, that
+// the specified citation or name refers to from the repository. A document
+// that does not match the digest in its citation is rejected.
+func (v *processor) loadDocument(reference abs.ComponentLike) abs.ComponentLike {
+	var repository = v.getRepository()
+	var location = v.locateDocument(reference, true)
+	var document = repository.RetrieveDocument(location.name, location.version)
+	if document == nil {
+		document = repository.RetrieveDraft(location.name, location.version)
+	}
+	if document == nil {
+		var message = fmt.Sprintf("The repository does not contain the document: %v/v%v", location.name.AsString(), location.version.AsString())
+		panic(message)
+	}
+	if !rep.VerifyCitation(reference, document) {
+		var message = fmt.Sprintf("The document does not match the digest in its citation: %v/v%v", location.name.AsString(), location.version.AsString())
+		panic(message)
+	}
+	v.leases.addLocation(document, location)
+	return document
+}
+
+// This private method returns the message bag that is the entity of the
+// specified component.
+func (v *processor) extractBag(component abs.ComponentLike) abs.MessageBagLike {
+	var bag, ok = component.GetEntity().(abs.MessageBagLike)
+	if !ok {
+		var message = fmt.Sprintf("Attempted to access a message bag that is not a message bag: %v", component.GetEntity())
+		panic(message)
+	}
+	return bag
+}
+
+// This private method invokes the named intrinsic function on the specified
+// arguments and returns its result. The built-in functions are named by
+// reserved symbols so they never shadow an intrinsic function, and the
+// intrinsic functions of this processor take precedence over those in its
+// registry.
+func (v *processor) invokeIntrinsic(name string, arguments []abs.ComponentLike) abs.ComponentLike {
+	var result abs.ComponentLike
+	if operator, ok := v.operators[name]; ok {
+		result = operator(arguments)
+	} else if intrinsic := v.tables.getIntrinsic(name); intrinsic != nil {
+		result = intrinsic(arguments)
+	} else {
+		result = v.evaluator.registry.InvokeIntrinsic(name, arguments)
+	}
+	if result == nil {
+		result = v.none()
+	}
+	return result
+}
+
+// This private method calls the named method on the specified target component
+// with the specified arguments. A procedure found in the catalog of the target
+// is executed in a new call frame while the result of any other method is
+// pushed onto the component stack immediately. Methods that are not in the
+// method table or the catalog are bound by the dispatcher.
+func (v *processor) callMethod(target abs.ComponentLike, name string, arguments []abs.ComponentLike) {
+	if method := v.tables.getMethod(name); method != nil {
+		var result = method(target, arguments)
+		if result == nil {
+			result = v.none()
+		}
+		v.components.AddValue(result)
+		return
+	}
+	if catalog, ok := target.GetEntity().(abs.CatalogLike); ok {
+		var value = catalog.GetValue(str.SymbolFromString(name))
+		if value != nil {
+			if procedure, ok := value.GetEntity().(abs.ProcedureLike); ok {
+				v.callProcedure(target, name, value, procedure, arguments)
+				return
+			}
+		}
+	}
+	var result = v.evaluator.dispatcher.DispatchMethod(target, name, arguments)
+	if result == nil {
+		result = v.none()
+	}
+	v.components.AddValue(result)
+}
+
+// This private method delivers an asynchronous message to the specified target
+// component. The message is delivered by a new processor that shares the
+// registry, the intrinsic and method tables and the dispatcher of this
+// processor but has its own evaluator, environment, built-in functions and
+// call and component stacks, so that any procedure that implements the method
+// runs to completion independently of the program that sent the message.
+func (v *processor) deliverMessage(
+	target abs.ComponentLike,
+	name string,
+	arguments []abs.ComponentLike,
+) abs.ComponentLike {
+	var worker = &processor{
+		evaluator: &evaluator{
+			environment: com.Context(),
+			registry:    v.evaluator.registry,
+			dispatcher:  v.evaluator.dispatcher,
+			scheduler:   v.evaluator.scheduler,
+		},
+		compiler:   Compiler(),
+		operators:  make(map[string]abs.Intrinsic),
+		tables:     v.tables,
+		programs:   make(map[abs.ProcedureLike]abs.ProgramLike),
+		scheduler:  v.scheduler,
+		leases:     v.leases,
+		bus:        v.bus,
+		repository: v.repository,
+		notary:     v.notary,
+		components: col.StackWithCapacity(componentCapacity),
+	}
+	worker.addOperators()
+	worker.callMethod(target, name, arguments)
+	for len(worker.frames) > 0 {
+		worker.step(0)
+	}
+	return worker.components.RemoveTop()
+}
+
+// This private method executes the specified procedure in a new call frame.
+// The parameters of the procedure are defined by the context of the component
+// that contains it.
+func (v *processor) callProcedure(
+	target abs.ComponentLike,
+	name string,
+	component abs.ComponentLike,
+	procedure abs.ProcedureLike,
+	arguments []abs.ComponentLike,
+) {
+	var parameters []abs.ParameterLike
+	if component.IsParameterized() {
+		parameters = component.GetContext().AsArray()
+	}
+	if len(parameters) != len(arguments) {
+		var message = fmt.Sprintf("The method $%v requires %v arguments but was passed %v.", name, len(parameters), len(arguments))
+		panic(message)
+	}
+	var environment = com.Context()
+	environment.SetValue(str.SymbolFromString("target"), target)
+	for index, parameter := range parameters {
+		environment.SetValue(parameter.GetKey(), arguments[index])
+	}
+	var program, ok = v.programs[procedure]
+	if !ok {
+		program = v.compiler.CompileProcedure(procedure)
+		v.programs[procedure] = program
+	}
+	v.pushFrame(program, environment)
+}
+
+// This private method adds the built-in functions that implement the operators
+// and control structures used by compiled procedures.
+func (v *processor) addOperators() {
+	for operator, name := range inversions {
+		v.operators[operatorSymbol(name, 1)] = v.unaryOperation(func(expression abs.Expression) abs.UnaryOperationLike {
+			return exp.Inversion(operator, expression)
+		})
+	}
+	v.operators[operatorSymbol("magnitude", 1)] = v.unaryOperation(exp.Magnitude)
+	v.operators[operatorSymbol("not", 1)] = v.unaryOperation(func(expression abs.Expression) abs.UnaryOperationLike {
+		return exp.Complement(abs.NOT, expression)
+	})
+	for operator, name := range operations {
+		v.operators[operatorSymbol(name, 2)] = v.binaryOperation(operator)
+	}
+	v.operators[operatorSymbol("attribute", 2)] = func(arguments []abs.ComponentLike) abs.ComponentLike {
+		var composite = arguments[0]
+		var key = arguments[1].GetEntity()
+		if catalog, ok := composite.GetEntity().(abs.CatalogLike); ok {
+			// A missing attribute of a catalog is none.
+			return catalog.GetValue(key)
+		}
+		return v.evaluator.getSubcomponent(composite, key)
+	}
+	v.operators[operatorSymbol("setAttribute", 3)] = func(arguments []abs.ComponentLike) abs.ComponentLike {
+		var composite = arguments[0]
+		var key = arguments[1].GetEntity()
+		switch collection := composite.GetEntity().(type) {
+		case abs.CatalogLike:
+			collection.SetValue(key, arguments[2])
+		case abs.ListLike:
+			collection.SetValue(v.evaluator.extractIndex(key), arguments[2])
+		default:
+			var message = fmt.Sprintf("Attempted to set an attribute of a non-composite component: %v", collection)
+			panic(message)
+		}
+		return composite
+	}
+	v.operators[operatorSymbol("accept", 1)] = func(arguments []abs.ComponentLike) abs.ComponentLike {
+		v.leases.removeLease(arguments[0]).AcceptMessage(arguments[0])
+		return nil
+	}
+	v.operators[operatorSymbol("reject", 1)] = func(arguments []abs.ComponentLike) abs.ComponentLike {
+		v.leases.removeLease(arguments[0]).RejectMessage(arguments[0])
+		return nil
+	}
+	v.operators[operatorSymbol("publish", 1)] = func(arguments []abs.ComponentLike) abs.ComponentLike {
+		if v.bus == nil {
+			panic("The processor does not have an event bus to publish the event to.")
+		}
+		v.bus.PublishEvent(fmt.Sprintf("processor-%p", v), arguments[0])
+		return nil
+	}
+	var checkout = func(arguments []abs.ComponentLike) abs.ComponentLike {
+		var current = v.leases.getLocation(arguments[0])
+		var level abs.Ordinal
+		if len(arguments) > 1 {
+			level = abs.Ordinal(v.evaluator.extractIndex(arguments[1].GetEntity()))
+		}
+		var next = &location{current.name, str.Version.GetNextVersion(current.version, level)}
+		if v.getRepository().DocumentExists(next.name, next.version) {
+			var message = fmt.Sprintf("The next version of the document has already been committed: %v/v%v", next.name.AsString(), next.version.AsString())
+			panic(message)
+		}
+		var draft = bal.ParseComponent(bal.FormatComponent(arguments[0]))
+		v.leases.removeLocation(arguments[0])
+		v.leases.addLocation(draft, next)
+		return draft
+	}
+	v.operators[operatorSymbol("checkout", 1)] = checkout
+	v.operators[operatorSymbol("checkout", 2)] = checkout
+	v.operators[operatorSymbol("discard", 1)] = func(arguments []abs.ComponentLike) abs.ComponentLike {
+		var location *location
+		switch arguments[0].GetEntity().(type) {
+		case abs.CitationLike, abs.NameLike:
+			location = v.locateDocument(arguments[0], false)
+		default:
+			location = v.leases.getLocation(arguments[0])
+			v.leases.removeLocation(arguments[0])
+		}
+		v.getRepository().DiscardDraft(location.name, location.version)
+		return nil
+	}
+	v.operators[operatorSymbol("iterator", 1)] = func(arguments []abs.ComponentLike) abs.ComponentLike {
+		var items = col.List()
+		for _, item := range v.evaluator.extractItems(arguments[0]) {
+			items.AddValue(item)
+		}
+		return com.Component(com.ComponentIterator(items))
+	}
+	v.operators[operatorSymbol("hasNext", 1)] = func(arguments []abs.ComponentLike) abs.ComponentLike {
+		var iterator = arguments[0].GetEntity().(abs.ComponentIteratorLike)
+		return com.Component(ele.Boolean().FromBoolean(iterator.HasNext()))
+	}
+	v.operators[operatorSymbol("getNext", 1)] = func(arguments []abs.ComponentLike) abs.ComponentLike {
+		var iterator = arguments[0].GetEntity().(abs.ComponentIteratorLike)
+		return iterator.GetNext()
+	}
+}
+
+// This private method returns an intrinsic function that evaluates the unary
+// operation created by the specified function.
+func (v *processor) unaryOperation(operation func(abs.Expression) abs.UnaryOperationLike) abs.Intrinsic {
+	return func(arguments []abs.ComponentLike) abs.ComponentLike {
+		var expression = operation(exp.Value(arguments[0]))
+		return v.evaluator.EvaluateExpression(expression)
+	}
+}
+
+// This private method returns an intrinsic function that evaluates the binary
+// operation for the specified operator.
+func (v *processor) binaryOperation(operator abs.Operator) abs.Intrinsic {
+	return func(arguments []abs.ComponentLike) abs.ComponentLike {
+		var first = exp.Value(arguments[0])
+		var second = exp.Value(arguments[1])
+		var expression abs.Expression
+		switch {
+		case operator == abs.AMPERSAND:
+			expression = exp.Chaining(first, operator, second)
+		case operator == abs.CARET:
+			expression = exp.Exponential(first, operator, second)
+		case operator >= abs.PLUS && operator <= abs.MODULO:
+			expression = exp.Arithmetic(first, operator, second)
+		case operator >= abs.LESS && operator <= abs.MATCHES:
+			expression = exp.Comparison(first, operator, second)
+		default:
+			expression = exp.Logical(first, operator, second)
+		}
+		return v.evaluator.EvaluateExpression(expression)
+	}
+}
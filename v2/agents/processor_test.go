@@ -0,0 +1,476 @@
+/*******************************************************************************
+ *   Copyright (c) 2009-2023 Crater Dog Technologies™.  All Rights Reserved.   *
+ *******************************************************************************
+ * DO NOT ALTER OR REMOVE COPYRIGHT NOTICES OR THIS FILE HEADER.               *
+ *                                                                             *
+ * This code is free software; you can redistribute it and/or modify it under  *
+ * the terms of The MIT License (MIT), as published by the Open Source         *
+ * Initiative. (See http://opensource.org/licenses/MIT)                        *
+ *******************************************************************************/
+
+package agents_test
+
+import (
+	abs "github.com/bali-nebula/go-component-framework/v2/abstractions"
+	age "github.com/bali-nebula/go-component-framework/v2/agents"
+	bag "github.com/bali-nebula/go-component-framework/v2/bags"
+	bal "github.com/bali-nebula/go-component-framework/v2/bali"
+	col "github.com/bali-nebula/go-component-framework/v2/collections"
+	com "github.com/bali-nebula/go-component-framework/v2/components"
+	ele "github.com/bali-nebula/go-component-framework/v2/elements"
+	evt "github.com/bali-nebula/go-component-framework/v2/events"
+	exp "github.com/bali-nebula/go-component-framework/v2/expressions"
+	ins "github.com/bali-nebula/go-component-framework/v2/intrinsics"
+	pro "github.com/bali-nebula/go-component-framework/v2/procedures"
+	rep "github.com/bali-nebula/go-component-framework/v2/repositories"
+	str "github.com/bali-nebula/go-component-framework/v2/strings"
+	cox "github.com/craterdog/go-collection-framework/v2"
+	ass "github.com/stretchr/testify/assert"
+	tes "testing"
+)
+
+func arguments(expressions ...abs.Expression) abs.Sequential[abs.Expression] {
+	var list = cox.List[abs.Expression]()
+	for _, expression := range expressions {
+		list.AddValue(expression)
+	}
+	return list
+}
+
+func TestProcessorWithoutEnvironment(t *tes.T) {
+	defer func() {
+		if e := recover(); e != nil {
+			ass.Equal(t, "A processor requires an environment.", e)
+		} else {
+			ass.Fail(t, "Test should result in recovered panic.")
+		}
+	}()
+	age.Processor(nil) // This should panic.
+}
+
+func TestProcessorLoops(t *tes.T) {
+	var list = col.List()
+	for i := 1; i < 5; i++ {
+		list.AddValue(com.Component(ele.Number().FromComplex(complex(float64(i), 0))))
+	}
+	var compiler = age.Compiler()
+	var processor = age.Processor(com.Context())
+
+	// let $sum := 0
+	// with each $n in [1, 2, 3, 4] do {
+	//     if $n = 3 do {
+	//         continue loop
+	//     }
+	//     let $sum += $n
+	// }
+	// let $count ?= 10
+	// let $count ?= 20
+	// while true do {
+	//     if $sum > 100 do {
+	//         break loop
+	//     }
+	//     let $sum *= 2
+	// }
+	// return $sum - $count
+	var code = procedure(
+		let("sum", abs.ASSIGN, number(0)),
+		statement(pro.WithClause(str.SymbolFromString("n"), pro.Block(
+			exp.Value(com.Component(list)),
+			procedure(
+				statement(pro.IfClause(pro.Block(
+					exp.Comparison(exp.Variable("n"), abs.EQUAL, number(3)),
+					procedure(statement(pro.ContinueClause())),
+				))),
+				let("sum", abs.SUM, exp.Variable("n")),
+			),
+		))),
+		let("count", abs.DEFAULT, number(10)),
+		let("count", abs.DEFAULT, number(20)),
+		statement(pro.WhileClause(pro.Block(
+			boolean(true),
+			procedure(
+				statement(pro.IfClause(pro.Block(
+					exp.Comparison(exp.Variable("sum"), abs.MORE, number(100)),
+					procedure(statement(pro.BreakClause())),
+				))),
+				let("sum", abs.PRODUCT, number(2)),
+			),
+		))),
+		statement(pro.ReturnClause(
+			exp.Arithmetic(exp.Variable("sum"), abs.MINUS, exp.Variable("count")),
+		)),
+	)
+	var result = processor.ExecuteProgram(compiler.CompileProcedure(code))
+	ass.Equal(t, 102.0, result.ExtractNumber().GetReal())
+	var count = processor.GetEnvironment().GetValue(str.SymbolFromString("count"))
+	ass.Equal(t, 10.0, count.ExtractNumber().GetReal())
+
+	// let $x := 1
+	var program = compiler.CompileProcedure(procedure(let("x", abs.ASSIGN, number(1))))
+	ass.Nil(t, processor.ExecuteProgram(program))
+}
+
+func TestProcessorExceptions(t *tes.T) {
+	var compiler = age.Compiler()
+	var processor = age.Processor(com.Context())
+
+	// throw "boom"
+	// on $failure matching "boom" do {
+	//     return $failure
+	// }
+	var blocks = cox.List[abs.BlockLike]()
+	blocks.AddValue(pro.Block(quote("boom"), procedure(
+		statement(pro.ReturnClause(exp.Variable("failure"))),
+	)))
+	var handler = pro.OnClause(str.SymbolFromString("failure"), blocks)
+	var code = procedure(pro.StatementWithHandler(pro.ThrowClause(quote("boom")), handler))
+	var result = processor.ExecuteProgram(compiler.CompileProcedure(code))
+	ass.Equal(t, "boom", result.ExtractQuote().AsString())
+
+	// throw "bust"
+	// on $failure matching "boom" do {
+	//     return $failure
+	// }
+	code = procedure(pro.StatementWithHandler(pro.ThrowClause(quote("bust")), handler))
+	defer func() {
+		if e := recover(); e != nil {
+			var component = e.(abs.ComponentLike)
+			ass.Equal(t, "bust", component.ExtractQuote().AsString())
+		} else {
+			ass.Fail(t, "Test should result in recovered panic.")
+		}
+	}()
+	processor.ExecuteProgram(compiler.CompileProcedure(code)) // This should panic.
+}
+
+func TestProcessorForeignFailures(t *tes.T) {
+	var compiler = age.Compiler()
+	var processor = age.Processor(com.Context())
+	processor.SetIntrinsic("missing", func(arguments []abs.ComponentLike) abs.ComponentLike {
+		panic("boom")
+	})
+
+	// return $missing()
+	// on $failure matching any do {
+	//     return $failure
+	// }
+	var blocks = cox.List[abs.BlockLike]()
+	blocks.AddValue(pro.Block(exp.Value(com.Component(ele.Pattern().Any())), procedure(
+		statement(pro.ReturnClause(exp.Variable("failure"))),
+	)))
+	var handler = pro.OnClause(str.SymbolFromString("failure"), blocks)
+	var code = procedure(pro.StatementWithHandler(
+		pro.ReturnClause(exp.Intrinsic("missing", arguments())),
+		handler,
+	))
+	defer func() {
+		if e := recover(); e != nil {
+			ass.Equal(t, "boom", e, "The Go panic should not be handled as an exception.")
+		} else {
+			ass.Fail(t, "Test should result in recovered panic.")
+		}
+	}()
+	processor.ExecuteProgram(compiler.CompileProcedure(code)) // This should panic.
+}
+
+func TestProcessorIntrinsicNames(t *tes.T) {
+	var registry = ins.Registry()
+	registry.RegisterIntrinsic("sum", ins.Signature([]string{"Number", "Number"}, "Number"),
+		func(arguments []abs.ComponentLike) abs.ComponentLike {
+			return com.Component(ele.Number().FromComplex(complex(42, 0)))
+		})
+	var compiler = age.Compiler()
+	var processor = age.ProcessorWithRegistry(com.Context(), registry)
+	processor.SetIntrinsic("iterator", func(arguments []abs.ComponentLike) abs.ComponentLike {
+		return com.Component(str.QuoteFromString("iterator"))
+	})
+
+	// return $sum(1, 2)
+	var code = procedure(statement(pro.ReturnClause(exp.Intrinsic("sum", arguments(number(1), number(2))))))
+	var result = processor.ExecuteProgram(compiler.CompileProcedure(code))
+	ass.Equal(t, 42.0, result.ExtractNumber().GetReal())
+
+	// return 1 + 2
+	code = procedure(statement(pro.ReturnClause(exp.Arithmetic(number(1), abs.PLUS, number(2)))))
+	result = processor.ExecuteProgram(compiler.CompileProcedure(code))
+	ass.Equal(t, 3.0, result.ExtractNumber().GetReal())
+
+	// return $iterator()
+	code = procedure(statement(pro.ReturnClause(exp.Intrinsic("iterator", arguments()))))
+	result = processor.ExecuteProgram(compiler.CompileProcedure(code))
+	ass.Equal(t, "iterator", result.ExtractQuote().AsString())
+
+	// let $count := 0
+	// with each $item in [1, 2, 3] do {
+	//     let $count += 1
+	// }
+	// return $count
+	var list = col.List()
+	for i := 1; i < 4; i++ {
+		list.AddValue(com.Component(ele.Number().FromComplex(complex(float64(i), 0))))
+	}
+	code = procedure(
+		let("count", abs.ASSIGN, number(0)),
+		statement(pro.WithClause(str.SymbolFromString("item"), pro.Block(
+			exp.Value(com.Component(list)),
+			procedure(let("count", abs.SUM, number(1))),
+		))),
+		statement(pro.ReturnClause(exp.Variable("count"))),
+	)
+	result = processor.ExecuteProgram(compiler.CompileProcedure(code))
+	ass.Equal(t, 3.0, result.ExtractNumber().GetReal())
+}
+
+func TestProcessorMethods(t *tes.T) {
+	var compiler = age.Compiler()
+	var processor = age.Processor(com.Context())
+	processor.SetMethod("double", func(target abs.ComponentLike, arguments []abs.ComponentLike) abs.ComponentLike {
+		var value = target.ExtractNumber().GetReal()
+		return com.Component(ele.Number().FromComplex(complex(2*value, 0)))
+	})
+
+	// return 21.double()
+	var code = procedure(statement(pro.ReturnClause(
+		exp.Invocation(number(21), abs.DOT, "double", arguments()),
+	)))
+	var result = processor.ExecuteProgram(compiler.CompileProcedure(code))
+	ass.Equal(t, 42.0, result.ExtractNumber().GetReal())
+
+	// let $account := [
+	//     $balance: 10
+	//     $deposit: {
+	//         let $target[$balance] := $target[$balance] + $amount
+	//         return $target[$balance]
+	//     }(
+	//         $amount: none
+	//     )
+	// ]
+	// let $account.deposit(5)
+	// return $account.deposit(7)
+	var balance = exp.Value(com.Component(str.SymbolFromString("balance")))
+	var deposit = procedure(
+		statement(pro.LetClauseWithRecipient(
+			pro.Attribute("target", arguments(balance)),
+			abs.ASSIGN,
+			exp.Arithmetic(
+				exp.Subcomponent(exp.Variable("target"), arguments(balance)),
+				abs.PLUS,
+				exp.Variable("amount"),
+			),
+		)),
+		statement(pro.ReturnClause(exp.Subcomponent(exp.Variable("target"), arguments(balance)))),
+	)
+	var parameters = com.Context()
+	parameters.SetValue(str.SymbolFromString("amount"), com.Component(ele.Pattern().None()))
+	var account = col.Catalog()
+	account.SetValue(str.SymbolFromString("balance"), com.Component(ele.Number().FromComplex(10)))
+	account.SetValue(str.SymbolFromString("deposit"), com.ComponentWithContext(deposit, parameters))
+	processor.GetEnvironment().SetValue(str.SymbolFromString("account"), com.Component(account))
+	code = procedure(
+		statement(pro.LetClause(exp.Invocation(exp.Variable("account"), abs.DOT, "deposit", arguments(number(5))))),
+		statement(pro.ReturnClause(exp.Invocation(exp.Variable("account"), abs.DOT, "deposit", arguments(number(7))))),
+	)
+	result = processor.ExecuteProgram(compiler.CompileProcedure(code))
+	ass.Equal(t, 22.0, result.ExtractNumber().GetReal())
+
+	// return $account.withdraw(3)
+	code = procedure(statement(pro.ReturnClause(
+		exp.Invocation(exp.Variable("account"), abs.DOT, "withdraw", arguments(number(3))),
+	)))
+	defer func() {
+		if e := recover(); e != nil {
+			ass.Equal(t, "The target component does not support the method: $withdraw", e)
+		} else {
+			ass.Fail(t, "Test should result in recovered panic.")
+		}
+	}()
+	processor.ExecuteProgram(compiler.CompileProcedure(code)) // This should panic.
+}
+
+func TestProcessorMessages(t *tes.T) {
+	var compiler = age.Compiler()
+	var processor = age.Processor(com.Context())
+	var messages = bag.MessageBag(2, ele.Duration().FromMilliseconds(60000))
+	processor.GetEnvironment().SetValue(str.SymbolFromString("bag"), com.Component(messages))
+
+	// post "first" to $bag
+	// post "second" to $bag
+	// retrieve $message from $bag
+	// reject $message
+	// retrieve $message from $bag
+	// accept $message
+	// return $message
+	var code = procedure(
+		statement(pro.PostClause(quote("first"), exp.Variable("bag"))),
+		statement(pro.PostClause(quote("second"), exp.Variable("bag"))),
+		statement(pro.RetrieveClause(str.SymbolFromString("message"), exp.Variable("bag"))),
+		statement(pro.RejectClause(exp.Variable("message"))),
+		statement(pro.RetrieveClause(str.SymbolFromString("message"), exp.Variable("bag"))),
+		statement(pro.AcceptClause(exp.Variable("message"))),
+		statement(pro.ReturnClause(exp.Variable("message"))),
+	)
+	var result = processor.ExecuteProgram(compiler.CompileProcedure(code))
+	ass.Equal(t, "second", result.ExtractQuote().AsString())
+	ass.Equal(t, 1, messages.GetSize())
+
+	// post "third" to $bag
+	// post "fourth" to $bag
+	code = procedure(
+		statement(pro.PostClause(quote("third"), exp.Variable("bag"))),
+		statement(pro.PostClause(quote("fourth"), exp.Variable("bag"))),
+	)
+	defer func() {
+		if e := recover(); e != nil {
+			ass.Equal(t, "The message bag has reached its capacity of 2 messages.", e)
+		} else {
+			ass.Fail(t, "Test should result in recovered panic.")
+		}
+	}()
+	processor.ExecuteProgram(compiler.CompileProcedure(code)) // This should panic.
+}
+
+func TestProcessorEvents(t *tes.T) {
+	var compiler = age.Compiler()
+	var processor = age.Processor(com.Context())
+	var bus = evt.EventBus()
+	var events []string
+	bus.SubscribeToPattern(ele.Pattern().Any(), func(event abs.ComponentLike) {
+		events = append(events, event.ExtractQuote().AsString())
+	})
+
+	// publish "started"
+	// publish "finished"
+	var code = procedure(
+		statement(pro.PublishClause(quote("started"))),
+		statement(pro.PublishClause(quote("finished"))),
+	)
+	var program = compiler.CompileProcedure(code)
+	defer func() {
+		if e := recover(); e != nil {
+			ass.Equal(t, "The processor does not have an event bus to publish the event to.", e)
+		} else {
+			ass.Fail(t, "Test should result in recovered panic.")
+		}
+	}()
+	processor.SetEventBus(bus)
+	processor.ExecuteProgram(program)
+	bus.AwaitDelivery()
+	ass.Equal(t, []string{"started", "finished"}, events)
+
+	processor.SetEventBus(nil)
+	processor.ExecuteProgram(program) // This should panic.
+}
+
+func TestProcessorDocuments(t *tes.T) {
+	var compiler = age.Compiler()
+	var processor = age.Processor(com.Context())
+	var repository = rep.LocalRepository(t.TempDir())
+	processor.SetRepository(repository)
+	var name = str.NameFromString("/acme/orders/Order")
+	repository.CommitDocument(name, str.VersionFromString("1.2"), bal.ParseComponent("[$total: 5]"))
+	var total = exp.Value(com.Component(str.SymbolFromString("total")))
+	var named = exp.Value(com.Component(name))
+	var cited = func(citation string) abs.Expression {
+		return exp.Value(com.Component(ele.Citation().FromString(citation)))
+	}
+
+	// checkout $draft at level 2 from /acme/orders/Order
+	// let $draft[$total] := 7
+	// save $draft as $citation
+	// notarize $draft as /acme/orders/Order/v1.3
+	// return $citation
+	var code = procedure(
+		statement(pro.CheckoutClause(str.SymbolFromString("draft"), number(2), named)),
+		statement(pro.LetClauseWithRecipient(pro.Attribute("draft", arguments(total)), abs.ASSIGN, number(7))),
+		statement(pro.SaveClause(exp.Variable("draft"), str.SymbolFromString("citation"))),
+		statement(pro.NotarizeClause(exp.Variable("draft"), cited("/acme/orders/Order/v1.3"))),
+		statement(pro.ReturnClause(exp.Variable("citation"))),
+	)
+	var result = processor.ExecuteProgram(compiler.CompileProcedure(code))
+	ass.Equal(t, "/acme/orders/Order/v1.3", result.ExtractCitation().AsString())
+	var committed = repository.RetrieveDocument(name, str.VersionFromString("1.3"))
+	ass.Equal(t, 7.0, committed.ExtractCatalog().GetValue(str.SymbolFromString("total")).ExtractNumber().GetReal())
+	ass.True(t, rep.VerifyCitation(result, committed))
+	ass.False(t, repository.DraftExists(name, str.VersionFromString("1.3")))
+
+	// checkout $draft from /acme/orders/Order/v1.3
+	// save $draft as $citation
+	// discard $draft
+	code = procedure(
+		statement(pro.CheckoutClause(str.SymbolFromString("draft"), nil, cited("/acme/orders/Order/v1.3"))),
+		statement(pro.SaveClause(exp.Variable("draft"), str.SymbolFromString("citation"))),
+		statement(pro.DiscardClause(exp.Variable("draft"))),
+	)
+	processor.ExecuteProgram(compiler.CompileProcedure(code))
+	ass.False(t, repository.DraftExists(name, str.VersionFromString("1.4")))
+
+	// checkout $draft at level 2 from /acme/orders/Order/v1.2
+	code = procedure(
+		statement(pro.CheckoutClause(str.SymbolFromString("draft"), number(2), cited("/acme/orders/Order/v1.2"))),
+	)
+	defer func() {
+		if e := recover(); e != nil {
+			ass.Equal(t, "The next version of the document has already been committed: /acme/orders/Order/v1.3", e)
+		} else {
+			ass.Fail(t, "Test should result in recovered panic.")
+		}
+	}()
+	processor.ExecuteProgram(compiler.CompileProcedure(code)) // This should panic.
+}
+
+func TestProcessorDiscardedDrafts(t *tes.T) {
+	var processor = age.Processor(com.Context())
+	var repository = rep.LocalRepository(t.TempDir())
+	processor.SetRepository(repository)
+	var name = str.NameFromString("/acme/orders/Order")
+	repository.CommitDocument(name, str.VersionFromString("1"), bal.ParseComponent("[$total: 5]"))
+
+	// checkout $draft from /acme/orders/Order/v1
+	// discard $draft
+	// discard $draft
+	var code = procedure(
+		statement(pro.CheckoutClause(str.SymbolFromString("draft"), nil, exp.Value(com.Component(name)))),
+		statement(pro.DiscardClause(exp.Variable("draft"))),
+		statement(pro.DiscardClause(exp.Variable("draft"))),
+	)
+	defer func() {
+		if e := recover(); e != nil {
+			ass.Contains(t, e, "The processor did not load or check out the document: ")
+		} else {
+			ass.Fail(t, "Test should result in recovered panic.")
+		}
+	}()
+	processor.ExecuteProgram(age.Compiler().CompileProcedure(code)) // This should panic.
+}
+
+func TestProcessorCitationDigests(t *tes.T) {
+	var processor = age.Processor(com.Context())
+	var repository = rep.LocalRepository(t.TempDir())
+	processor.SetRepository(repository)
+	var name = str.NameFromString("/acme/orders/Order")
+	var document = bal.ParseComponent("[$total: 5]")
+	var citation = repository.CommitDocument(name, str.VersionFromString("1"), document)
+
+	// checkout $draft from /acme/orders/Order/v1($digest: ...)
+	// return $draft
+	var code = procedure(
+		statement(pro.CheckoutClause(str.SymbolFromString("draft"), nil, exp.Value(citation))),
+		statement(pro.ReturnClause(exp.Variable("draft"))),
+	)
+	var result = processor.ExecuteProgram(age.Compiler().CompileProcedure(code))
+	ass.Equal(t, 5.0, result.ExtractCatalog().GetValue(str.SymbolFromString("total")).ExtractNumber().GetReal())
+
+	// checkout $draft from /acme/orders/Order/v1($digest: <some other digest>)
+	var other = rep.CiteDocument(citation.ExtractCitation(), bal.ParseComponent("[$total: 6]"))
+	code = procedure(
+		statement(pro.CheckoutClause(str.SymbolFromString("draft"), nil, exp.Value(other))),
+	)
+	defer func() {
+		if e := recover(); e != nil {
+			ass.Equal(t, "The document does not match the digest in its citation: /acme/orders/Order/v1", e)
+		} else {
+			ass.Fail(t, "Test should result in recovered panic.")
+		}
+	}()
+	processor.ExecuteProgram(age.Compiler().CompileProcedure(code)) // This should panic.
+}
@@ -0,0 +1,212 @@
+/*******************************************************************************
+ *   Copyright (c) 2009-2023 Crater Dog Technologies™.  All Rights Reserved.   *
+ *******************************************************************************
+ * DO NOT ALTER OR REMOVE COPYRIGHT NOTICES OR THIS FILE HEADER.               *
+ *                                                                             *
+ * This code is free software; you can redistribute it and/or modify it under  *
+ * the terms of The MIT License (MIT), as published by the Open Source         *
+ * Initiative. (See http://opensource.org/licenses/MIT)                        *
+ *******************************************************************************/
+
+package agents
+
+import (
+	fmt "fmt"
+	abs "github.com/bali-nebula/go-component-framework/v2/abstractions"
+	com "github.com/bali-nebula/go-component-framework/v2/components"
+	ele "github.com/bali-nebula/go-component-framework/v2/elements"
+	run "runtime"
+	syn "sync"
+)
+
+// SCHEDULER IMPLEMENTATION
+
+// This constructor creates a new scheduler that executes asynchronous (<-)
+// invocations. Each message sent to the scheduler is placed on the local
+// message queue for its target component and the sender immediately receives a
+// future for its result. The messages are delivered to the specified handler
+// by a pool of worker goroutines, one per CPU. The messages for a target are
+// delivered one at a time in the order they were sent, so a target is never
+// accessed by two deliveries at once, while the messages for different targets
+// are delivered concurrently. The worker pool grows as messages are queued and
+// shrinks again once the queues are empty, so an idle scheduler has no running
+// goroutines.
+//
+// The result returned by the handler, or none if it returns nil, resolves the
+// future. A failure that occurs while the handler is delivering the message is
+// recovered and resolves the future with the exception component instead. A
+// sender that is not interested in the result may simply ignore the future.
+func Scheduler(handler abs.Handler) abs.SchedulerLike {
+	return SchedulerWithWorkers(handler, run.NumCPU())
+}
+
+// This constructor creates a new scheduler like the Scheduler() constructor
+// does but with a worker pool containing at most the specified number of
+// worker goroutines.
+func SchedulerWithWorkers(handler abs.Handler, workers int) abs.SchedulerLike {
+	if handler == nil {
+		panic("A scheduler requires a handler to deliver its messages.")
+	}
+	if workers < 1 {
+		var message = fmt.Sprintf("A scheduler requires at least one worker but was given %v.", workers)
+		panic(message)
+	}
+	var v = &scheduler{
+		handler: handler,
+		workers: workers,
+		queues:  make(map[abs.ComponentLike]*queue),
+	}
+	return v
+}
+
+// This type defines the structure and methods associated with a scheduler
+// agent.
+type scheduler struct {
+	mutex   syn.Mutex
+	handler abs.Handler
+	workers int
+	active  int
+	queues  map[abs.ComponentLike]*queue
+	ready   []*queue
+}
+
+// This type defines the structure of the message queue for a target component.
+// A queue is ready while it is waiting for a worker to deliver its next message
+// and is removed from its scheduler once it is empty.
+type queue struct {
+	target   abs.ComponentLike
+	messages []*message
+}
+
+// This type defines the structure of an asynchronous message that is waiting
+// on the message queue of a scheduler.
+type message struct {
+	target    abs.ComponentLike
+	method    string
+	arguments []abs.ComponentLike
+	future    *future
+}
+
+// PUBLIC INTERFACE
+
+// This method places the specified message on the message queue for its
+// target component and returns a future that is resolved once the message has
+// been delivered.
+func (v *scheduler) SendMessage(
+	target abs.ComponentLike,
+	method string,
+	arguments []abs.ComponentLike,
+) abs.FutureLike {
+	if target == nil {
+		var message = fmt.Sprintf("The scheduler requires a target for the method: $%v", method)
+		panic(message)
+	}
+	var result = &future{done: make(chan struct{})}
+	v.mutex.Lock()
+	defer v.mutex.Unlock()
+	var pending, ok = v.queues[target]
+	if !ok {
+		pending = &queue{target: target}
+		v.queues[target] = pending
+		v.ready = append(v.ready, pending)
+	}
+	pending.messages = append(pending.messages, &message{target, method, arguments, result})
+	if v.active < v.workers && v.active < len(v.queues) {
+		v.active++
+		go v.work()
+	}
+	return result
+}
+
+// PRIVATE METHODS
+
+// This private method delivers the next message from each ready message queue
+// until no queues are ready. A queue is not ready while one of its messages is
+// being delivered, and it becomes ready again afterwards if it has more
+// messages.
+func (v *scheduler) work() {
+	v.mutex.Lock()
+	for len(v.ready) > 0 {
+		var pending = v.ready[0]
+		v.ready[0] = nil
+		v.ready = v.ready[1:]
+		var next = pending.messages[0]
+		v.mutex.Unlock()
+		v.deliver(next)
+		v.mutex.Lock()
+		pending.messages[0] = nil
+		pending.messages = pending.messages[1:]
+		if len(pending.messages) > 0 {
+			v.ready = append(v.ready, pending)
+		} else {
+			delete(v.queues, pending.target)
+		}
+	}
+	v.active--
+	v.mutex.Unlock()
+}
+
+// This private method delivers the specified message to the handler of this
+// scheduler and resolves the future of the message with the outcome.
+func (v *scheduler) deliver(next *message) {
+	defer func() {
+		if e := recover(); e != nil {
+			next.future.resolve(nil, describeFailure(e))
+		}
+	}()
+	var result = v.handler(next.target, next.method, next.arguments)
+	if result == nil {
+		result = com.Component(ele.Pattern().None())
+	}
+	next.future.resolve(result, nil)
+}
+
+// FUTURE IMPLEMENTATION
+
+// This type defines the structure and methods associated with a future that is
+// resolved when an asynchronous message has been delivered.
+type future struct {
+	done    chan struct{}
+	result  abs.ComponentLike
+	failure abs.ComponentLike
+}
+
+// PUBLIC INTERFACE
+
+// This method determines whether or not the message has been delivered.
+func (v *future) IsDone() bool {
+	select {
+	case <-v.done:
+		return true
+	default:
+		return false
+	}
+}
+
+// This method waits until the message has been delivered and returns the
+// result. If the delivery failed this method panics with the exception
+// component instead.
+func (v *future) GetResult() abs.ComponentLike {
+	<-v.done
+	if v.failure != nil {
+		panic(v.failure)
+	}
+	return v.result
+}
+
+// This method waits until the message has been delivered and returns the
+// exception component if the delivery failed, or nil if it succeeded.
+func (v *future) GetFailure() abs.ComponentLike {
+	<-v.done
+	return v.failure
+}
+
+// PRIVATE METHODS
+
+// This private method resolves this future with the specified result or
+// failure.
+func (v *future) resolve(result, failure abs.ComponentLike) {
+	v.result = result
+	v.failure = failure
+	close(v.done)
+}
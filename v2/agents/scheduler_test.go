@@ -0,0 +1,238 @@
+/*******************************************************************************
+ *   Copyright (c) 2009-2023 Crater Dog Technologies™.  All Rights Reserved.   *
+ *******************************************************************************
+ * DO NOT ALTER OR REMOVE COPYRIGHT NOTICES OR THIS FILE HEADER.               *
+ *                                                                             *
+ * This code is free software; you can redistribute it and/or modify it under  *
+ * the terms of The MIT License (MIT), as published by the Open Source         *
+ * Initiative. (See http://opensource.org/licenses/MIT)                        *
+ *******************************************************************************/
+
+package agents_test
+
+import (
+	abs "github.com/bali-nebula/go-component-framework/v2/abstractions"
+	age "github.com/bali-nebula/go-component-framework/v2/agents"
+	col "github.com/bali-nebula/go-component-framework/v2/collections"
+	com "github.com/bali-nebula/go-component-framework/v2/components"
+	ele "github.com/bali-nebula/go-component-framework/v2/elements"
+	exp "github.com/bali-nebula/go-component-framework/v2/expressions"
+	pro "github.com/bali-nebula/go-component-framework/v2/procedures"
+	str "github.com/bali-nebula/go-component-framework/v2/strings"
+	ass "github.com/stretchr/testify/assert"
+	ref "reflect"
+	run "runtime"
+	tes "testing"
+)
+
+func TestSchedulerMessages(t *tes.T) {
+	var scheduler = age.SchedulerWithWorkers(func(
+		target abs.ComponentLike,
+		method string,
+		arguments []abs.ComponentLike,
+	) abs.ComponentLike {
+		if method == "fail" {
+			panic("boom")
+		}
+		return numeric(2 * target.ExtractNumber().GetReal())
+	}, 2)
+
+	var futures []abs.FutureLike
+	for i := 1; i <= 10; i++ {
+		futures = append(futures, scheduler.SendMessage(numeric(float64(i)), "double", nil))
+	}
+	for i, future := range futures {
+		ass.Equal(t, float64(2*(i+1)), future.GetResult().ExtractNumber().GetReal())
+		ass.True(t, future.IsDone())
+		ass.Nil(t, future.GetFailure())
+	}
+
+	var future = scheduler.SendMessage(numeric(1), "fail", nil)
+	ass.Equal(t, "boom", future.GetFailure().ExtractQuote().AsString())
+	defer func() {
+		if e := recover(); e != nil {
+			var component = e.(abs.ComponentLike)
+			ass.Equal(t, "boom", component.ExtractQuote().AsString())
+		} else {
+			ass.Fail(t, "Test should result in recovered panic.")
+		}
+	}()
+	future.GetResult() // This should panic.
+}
+
+func TestSchedulerOrdering(t *tes.T) {
+	var counts = make(map[abs.ComponentLike]*int)
+	var targets = []abs.ComponentLike{numeric(1), numeric(2), numeric(3)}
+	for _, target := range targets {
+		counts[target] = new(int)
+	}
+	var scheduler = age.SchedulerWithWorkers(func(
+		target abs.ComponentLike,
+		method string,
+		arguments []abs.ComponentLike,
+	) abs.ComponentLike {
+		// The count for each target is only safe if its messages are never
+		// delivered concurrently.
+		var count = *counts[target] + 1
+		run.Gosched()
+		*counts[target] = count
+		return numeric(float64(count))
+	}, 4)
+
+	var futures []abs.FutureLike
+	for i := 0; i < 300; i++ {
+		futures = append(futures, scheduler.SendMessage(targets[i%3], "increment", nil))
+	}
+	for i, future := range futures {
+		ass.Equal(t, float64(i/3+1), future.GetResult().ExtractNumber().GetReal())
+	}
+}
+
+func TestEvaluateAsynchronousInvocations(t *tes.T) {
+	var list = col.List()
+	var evaluator = age.Evaluator(com.Context())
+	evaluator.GetEnvironment().SetValue(str.SymbolFromString("list"), com.Component(list))
+
+	// $list <- addValue(5)
+	var component = evaluator.EvaluateExpression(
+		exp.Invocation(exp.Variable("list"), abs.ARROW, "addValue", arguments(number(5))),
+	)
+	var future = component.GetEntity().(abs.FutureLike)
+	ass.True(t, ele.Pattern().None() == future.GetResult().GetEntity())
+	ass.Equal(t, 1, list.GetSize())
+
+	// $list <- removeAll(1)
+	component = evaluator.EvaluateExpression(
+		exp.Invocation(exp.Variable("list"), abs.ARROW, "removeAll", arguments(number(1))),
+	)
+	future = component.GetEntity().(abs.FutureLike)
+	ass.Equal(t, "The method $removeAll requires 0 arguments but was passed 1.", future.GetFailure().ExtractQuote().AsString())
+}
+
+func TestProcessAsynchronousInvocations(t *tes.T) {
+	var compiler = age.Compiler()
+	var processor = age.Processor(com.Context())
+
+	// let $account := [
+	//     $balance: 10
+	//     $deposit: {
+	//         let $target[$balance] := $target[$balance] + $amount
+	//         return $target[$balance]
+	//     }(
+	//         $amount: none
+	//     )
+	// ]
+	// let $future := $account <- deposit(5)
+	// return $future.getResult()
+	var balance = exp.Value(com.Component(str.SymbolFromString("balance")))
+	var deposit = procedure(
+		statement(pro.LetClauseWithRecipient(
+			pro.Attribute("target", arguments(balance)),
+			abs.ASSIGN,
+			exp.Arithmetic(
+				exp.Subcomponent(exp.Variable("target"), arguments(balance)),
+				abs.PLUS,
+				exp.Variable("amount"),
+			),
+		)),
+		statement(pro.ReturnClause(exp.Subcomponent(exp.Variable("target"), arguments(balance)))),
+	)
+	var parameters = com.Context()
+	parameters.SetValue(str.SymbolFromString("amount"), com.Component(ele.Pattern().None()))
+	var account = col.Catalog()
+	account.SetValue(str.SymbolFromString("balance"), numeric(10))
+	account.SetValue(str.SymbolFromString("deposit"), com.ComponentWithContext(deposit, parameters))
+	processor.GetEnvironment().SetValue(str.SymbolFromString("account"), com.Component(account))
+	var code = procedure(
+		let("future", abs.ASSIGN, exp.Invocation(exp.Variable("account"), abs.ARROW, "deposit", arguments(number(5)))),
+		statement(pro.ReturnClause(exp.Invocation(exp.Variable("future"), abs.DOT, "getResult", arguments()))),
+	)
+	var result = processor.ExecuteProgram(compiler.CompileProcedure(code))
+	ass.Equal(t, 15.0, result.ExtractNumber().GetReal())
+
+	// let $future := $account <- withdraw(3)
+	// return $future.getFailure()
+	code = procedure(
+		let("future", abs.ASSIGN, exp.Invocation(exp.Variable("account"), abs.ARROW, "withdraw", arguments(number(3)))),
+		statement(pro.ReturnClause(exp.Invocation(exp.Variable("future"), abs.DOT, "getFailure", arguments()))),
+	)
+	result = processor.ExecuteProgram(compiler.CompileProcedure(code))
+	ass.Equal(t, "The target component does not support the method: $withdraw", result.ExtractQuote().AsString())
+}
+
+func TestProcessManyAsynchronousInvocations(t *tes.T) {
+	var compiler = age.Compiler()
+	var processor = age.Processor(com.Context())
+
+	// let $account := [
+	//     $balance: 0
+	//     $deposit: {
+	//         let $target[$balance] := $target[$balance] + $amount
+	//         return $target[$balance]
+	//     }(
+	//         $amount: none
+	//     )
+	// ]
+	var balance = exp.Value(com.Component(str.SymbolFromString("balance")))
+	var deposit = procedure(
+		statement(pro.LetClauseWithRecipient(
+			pro.Attribute("target", arguments(balance)),
+			abs.ASSIGN,
+			exp.Arithmetic(
+				exp.Subcomponent(exp.Variable("target"), arguments(balance)),
+				abs.PLUS,
+				exp.Variable("amount"),
+			),
+		)),
+		statement(pro.ReturnClause(exp.Subcomponent(exp.Variable("target"), arguments(balance)))),
+	)
+	var parameters = com.Context()
+	parameters.SetValue(str.SymbolFromString("amount"), com.Component(ele.Pattern().None()))
+	var account = col.Catalog()
+	account.SetValue(str.SymbolFromString("balance"), numeric(0))
+	account.SetValue(str.SymbolFromString("deposit"), com.ComponentWithContext(deposit, parameters))
+	processor.GetEnvironment().SetValue(str.SymbolFromString("account"), com.Component(account))
+
+	// return $account <- deposit(1)
+	var program = compiler.CompileProcedure(procedure(
+		statement(pro.ReturnClause(exp.Invocation(exp.Variable("account"), abs.ARROW, "deposit", arguments(number(1))))),
+	))
+	var futures []abs.FutureLike
+	for i := 0; i < 200; i++ {
+		var result = processor.ExecuteProgram(program)
+		futures = append(futures, result.GetEntity().(abs.FutureLike))
+	}
+	for i, future := range futures {
+		ass.Equal(t, float64(i+1), future.GetResult().ExtractNumber().GetReal())
+	}
+	var total = account.GetValue(str.SymbolFromString("balance"))
+	ass.Equal(t, 200.0, total.ExtractNumber().GetReal())
+}
+
+func TestProcessAsynchronousTableUpdates(t *tes.T) {
+	var compiler = age.Compiler()
+	var processor = age.Processor(com.Context())
+	var double = func(target abs.ComponentLike, arguments []abs.ComponentLike) abs.ComponentLike {
+		return numeric(2 * target.ExtractNumber().GetReal())
+	}
+	processor.SetMethod("double", double)
+
+	// return 21 <- double()
+	var program = compiler.CompileProcedure(procedure(
+		statement(pro.ReturnClause(exp.Invocation(number(21), abs.ARROW, "double", arguments()))),
+	))
+	var futures []abs.FutureLike
+	for i := 0; i < 50; i++ {
+		var result = processor.ExecuteProgram(program)
+		futures = append(futures, result.GetEntity().(abs.FutureLike))
+		// The tables are updated while the messages are being delivered.
+		processor.SetMethod("double", double)
+		processor.SetIntrinsic("answer", func(arguments []abs.ComponentLike) abs.ComponentLike {
+			return numeric(42)
+		})
+		processor.GetDispatcher().RegisterType(ref.TypeOf(&counter{}))
+	}
+	for _, future := range futures {
+		ass.Equal(t, 42.0, future.GetResult().ExtractNumber().GetReal())
+	}
+}
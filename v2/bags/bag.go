@@ -0,0 +1,334 @@
+/*******************************************************************************
+ *   Copyright (c) 2009-2023 Crater Dog Technologies™.  All Rights Reserved.   *
+ *******************************************************************************
+ * DO NOT ALTER OR REMOVE COPYRIGHT NOTICES OR THIS FILE HEADER.               *
+ *                                                                             *
+ * This code is free software; you can redistribute it and/or modify it under  *
+ * the terms of The MIT License (MIT), as published by the Open Source         *
+ * Initiative. (See http://opensource.org/licenses/MIT)                        *
+ *******************************************************************************/
+
+package bags
+
+import (
+	fmt "fmt"
+	abs "github.com/bali-nebula/go-component-framework/v2/abstractions"
+	bal "github.com/bali-nebula/go-component-framework/v2/bali"
+	uti "github.com/bali-nebula/go-component-framework/v2/utilities"
+	osx "os"
+	sor "sort"
+	stc "strconv"
+	sts "strings"
+	syn "sync"
+	tim "time"
+)
+
+// CONSTANT DEFINITIONS
+
+const (
+	messageSuffix = ".bali"  // The suffix of a file holding an available message.
+	leaseSuffix   = ".lease" // The suffix of a file holding a leased message.
+)
+
+// MESSAGE BAG IMPLEMENTATION
+
+// This constructor creates a new in-memory message bag that holds at most the
+// specified number of messages. A message that is retrieved from the bag is
+// leased to the retriever for the specified duration. The lease ends when the
+// message is accepted, which removes it from the bag, or rejected, which
+// returns it to the bag. A message whose lease expires before either happens
+// is returned to the bag automatically so that it may be retrieved again. Each
+// retrieval results in a distinct component for the leased message, so a
+// message that was posted more than once is leased independently each time.
+func MessageBag(capacity int, lease abs.DurationLike) abs.MessageBagLike {
+	return newMessageBag("", capacity, lease)
+}
+
+// This constructor creates a new message bag like the MessageBag() constructor
+// does but stores each message in a file in the specified directory so that
+// the messages survive a restart. Any messages already stored in the directory
+// are loaded into the new bag, including those that were leased when the
+// previous bag was abandoned, since their leases cannot have survived it.
+func DurableBag(directory string, capacity int, lease abs.DurationLike) abs.MessageBagLike {
+	if len(directory) == 0 {
+		panic("A durable message bag requires a directory.")
+	}
+	if !sts.HasSuffix(directory, "/") {
+		directory += "/"
+	}
+	var err = osx.MkdirAll(directory, 0700)
+	if err != nil {
+		var message = fmt.Sprintf("Could not create the message bag directory: %v.", err)
+		panic(message)
+	}
+	var v = newMessageBag(directory, capacity, lease)
+	v.loadMessages()
+	return v
+}
+
+// This private constructor creates a new message bag that stores its messages
+// in the specified directory, or only in memory if the directory is empty.
+func newMessageBag(directory string, capacity int, lease abs.DurationLike) *messageBag {
+	if capacity < 1 {
+		var message = fmt.Sprintf("A message bag requires a positive capacity but was given %v.", capacity)
+		panic(message)
+	}
+	if lease == nil || lease.AsInteger() < 1 {
+		var message = fmt.Sprintf("A message bag requires a positive lease duration but was given %v.", lease)
+		panic(message)
+	}
+	return &messageBag{
+		directory: directory,
+		capacity:  capacity,
+		lease:     lease,
+		leased:    make(map[int]*envelope),
+	}
+}
+
+// This type defines the structure and methods associated with a message bag.
+type messageBag struct {
+	mutex     syn.Mutex
+	directory string
+	capacity  int
+	lease     abs.DurationLike
+	sequence  int
+	leases    int
+	available []*envelope
+	leased    map[int]*envelope
+}
+
+// This type defines the structure of a message that is held in a message bag.
+// The lease identifies the current lease on the message, if it is leased.
+type envelope struct {
+	identifier int
+	message    abs.ComponentLike
+	lease      int
+	expiration tim.Time
+}
+
+// This type defines the structure of a message component that has been leased
+// from a message bag. It behaves like the message itself but also records the
+// lease that it was retrieved under.
+type leasedMessage struct {
+	abs.ComponentLike
+	lease int
+}
+
+// LIMITED INTERFACE
+
+// This method returns the maximum number of messages that this bag can hold.
+func (v *messageBag) GetCapacity() int {
+	return v.capacity
+}
+
+// This method returns the duration of the lease on each retrieved message.
+func (v *messageBag) GetLease() abs.DurationLike {
+	return v.lease
+}
+
+// This method returns the number of messages in this bag, including those that
+// are currently leased.
+func (v *messageBag) GetSize() int {
+	v.mutex.Lock()
+	defer v.mutex.Unlock()
+	v.expireLeases()
+	return len(v.available) + len(v.leased)
+}
+
+// This method returns the number of messages in this bag that are available to
+// be retrieved.
+func (v *messageBag) GetAvailable() int {
+	v.mutex.Lock()
+	defer v.mutex.Unlock()
+	v.expireLeases()
+	return len(v.available)
+}
+
+// LEASABLE INTERFACE
+
+// This method adds the specified message to this bag. A message cannot be
+// posted to a bag that is already at its capacity.
+func (v *messageBag) PostMessage(message abs.ComponentLike) {
+	if message == nil {
+		panic("A message bag cannot hold a nil message.")
+	}
+	v.mutex.Lock()
+	defer v.mutex.Unlock()
+	v.expireLeases()
+	if len(v.available)+len(v.leased) >= v.capacity {
+		var message = fmt.Sprintf("The message bag has reached its capacity of %v messages.", v.capacity)
+		panic(message)
+	}
+	v.sequence++
+	var next = &envelope{identifier: v.sequence, message: message}
+	v.writeMessage(next)
+	v.available = append(v.available, next)
+}
+
+// This method leases the next available message in this bag to the caller and
+// returns it, or returns nil if no message is available.
+func (v *messageBag) RetrieveMessage() abs.ComponentLike {
+	v.mutex.Lock()
+	defer v.mutex.Unlock()
+	v.expireLeases()
+	if len(v.available) == 0 {
+		return nil
+	}
+	var next = v.available[0]
+	v.available[0] = nil
+	v.available = v.available[1:]
+	var duration = tim.Duration(v.lease.AsInteger()) * tim.Millisecond
+	next.expiration = tim.Now().Add(duration)
+	v.renameMessage(next, messageSuffix, leaseSuffix)
+	v.leases++
+	next.lease = v.leases
+	v.leased[next.lease] = next
+	return &leasedMessage{next.message, next.lease}
+}
+
+// This method ends the lease on the specified message, which must have been
+// returned by the RetrieveMessage() method, and removes it from this bag
+// permanently.
+func (v *messageBag) AcceptMessage(message abs.ComponentLike) {
+	v.mutex.Lock()
+	defer v.mutex.Unlock()
+	var leased = v.endLease(message)
+	v.removeMessage(leased)
+}
+
+// This method ends the lease on the specified message, which must have been
+// returned by the RetrieveMessage() method, and returns it to this bag so that
+// it may be retrieved again.
+func (v *messageBag) RejectMessage(message abs.ComponentLike) {
+	v.mutex.Lock()
+	defer v.mutex.Unlock()
+	var leased = v.endLease(message)
+	v.renameMessage(leased, leaseSuffix, messageSuffix)
+	v.available = append(v.available, leased)
+}
+
+// PRIVATE METHODS
+
+// This private method ends the current lease on the specified message and
+// returns its envelope. The lease must not have expired.
+func (v *messageBag) endLease(message abs.ComponentLike) *envelope {
+	v.expireLeases()
+	var retrieved, ok = message.(*leasedMessage)
+	var leased *envelope
+	if ok {
+		leased, ok = v.leased[retrieved.lease]
+	}
+	if !ok {
+		panic("The message bag does not hold a current lease on the message.")
+	}
+	delete(v.leased, retrieved.lease)
+	return leased
+}
+
+// This private method returns each leased message whose lease has expired to
+// the available messages in this bag, in the order they were posted.
+func (v *messageBag) expireLeases() {
+	var now = tim.Now()
+	var expired []*envelope
+	for lease, leased := range v.leased {
+		if now.After(leased.expiration) {
+			delete(v.leased, lease)
+			expired = append(expired, leased)
+		}
+	}
+	sor.Slice(expired, func(i, j int) bool {
+		return expired[i].identifier < expired[j].identifier
+	})
+	for _, leased := range expired {
+		v.renameMessage(leased, leaseSuffix, messageSuffix)
+		v.available = append(v.available, leased)
+	}
+}
+
+// This private method returns the name of the file that holds the specified
+// letter with the specified suffix.
+func (v *messageBag) getFilename(letter *envelope, suffix string) string {
+	return fmt.Sprintf("%v%012d%v", v.directory, letter.identifier, suffix)
+}
+
+// This private method loads the messages stored in the directory of this bag
+// in the order they were posted.
+func (v *messageBag) loadMessages() {
+	var entries, err = osx.ReadDir(v.directory)
+	if err != nil {
+		var message = fmt.Sprintf("Could not read the message bag directory: %v.", err)
+		panic(message)
+	}
+	for _, entry := range entries {
+		var name = entry.Name()
+		var suffix = messageSuffix
+		if sts.HasSuffix(name, leaseSuffix) {
+			suffix = leaseSuffix
+		}
+		var identifier, err = stc.Atoi(sts.TrimSuffix(name, suffix))
+		if entry.IsDir() || !sts.HasSuffix(name, suffix) || err != nil {
+			continue
+		}
+		var next = &envelope{identifier: identifier}
+		var document []byte
+		document, err = osx.ReadFile(v.getFilename(next, suffix))
+		if err != nil {
+			var message = fmt.Sprintf("Could not read the message file: %v.", err)
+			panic(message)
+		}
+		next.message = bal.ParseDocument(document)
+		v.renameMessage(next, suffix, messageSuffix)
+		v.available = append(v.available, next)
+		if identifier > v.sequence {
+			v.sequence = identifier
+		}
+	}
+	sor.Slice(v.available, func(i, j int) bool {
+		return v.available[i].identifier < v.available[j].identifier
+	})
+	if len(v.available) > v.capacity {
+		var message = fmt.Sprintf("The message bag directory holds %v messages which exceeds the capacity of %v.", len(v.available), v.capacity)
+		panic(message)
+	}
+}
+
+// This private method stores the specified message in a new file if this bag
+// is durable. The file is written atomically so that it is never left holding
+// a partial message.
+func (v *messageBag) writeMessage(letter *envelope) {
+	if len(v.directory) == 0 {
+		return
+	}
+	var document = bal.FormatDocument(letter.message)
+	var err = uti.WriteFile(v.getFilename(letter, messageSuffix), document, 0600)
+	if err != nil {
+		var message = fmt.Sprintf("Could not create the message file: %v.", err)
+		panic(message)
+	}
+}
+
+// This private method changes the suffix of the file that holds the specified
+// message if this bag is durable.
+func (v *messageBag) renameMessage(letter *envelope, from, to string) {
+	if len(v.directory) == 0 || from == to {
+		return
+	}
+	var err = osx.Rename(v.getFilename(letter, from), v.getFilename(letter, to))
+	if err != nil {
+		var message = fmt.Sprintf("Could not rename the message file: %v.", err)
+		panic(message)
+	}
+}
+
+// This private method deletes the file that holds the specified leased message
+// if this bag is durable.
+func (v *messageBag) removeMessage(letter *envelope) {
+	if len(v.directory) == 0 {
+		return
+	}
+	var err = osx.Remove(v.getFilename(letter, leaseSuffix))
+	if err != nil {
+		var message = fmt.Sprintf("Could not delete the message file: %v.", err)
+		panic(message)
+	}
+}
@@ -0,0 +1,139 @@
+/*******************************************************************************
+ *   Copyright (c) 2009-2023 Crater Dog Technologies™.  All Rights Reserved.   *
+ *******************************************************************************
+ * DO NOT ALTER OR REMOVE COPYRIGHT NOTICES OR THIS FILE HEADER.               *
+ *                                                                             *
+ * This code is free software; you can redistribute it and/or modify it under  *
+ * the terms of The MIT License (MIT), as published by the Open Source         *
+ * Initiative. (See http://opensource.org/licenses/MIT)                        *
+ *******************************************************************************/
+
+package bags_test
+
+import (
+	abs "github.com/bali-nebula/go-component-framework/v2/abstractions"
+	bag "github.com/bali-nebula/go-component-framework/v2/bags"
+	com "github.com/bali-nebula/go-component-framework/v2/components"
+	ele "github.com/bali-nebula/go-component-framework/v2/elements"
+	str "github.com/bali-nebula/go-component-framework/v2/strings"
+	ass "github.com/stretchr/testify/assert"
+	tes "testing"
+	tim "time"
+)
+
+func quote(value string) abs.ComponentLike {
+	return com.Component(str.QuoteFromArray([]rune(value)))
+}
+
+func TestMessageBagLeases(t *tes.T) {
+	var messages = bag.MessageBag(3, ele.Duration().FromMilliseconds(60000))
+	ass.Equal(t, 3, messages.GetCapacity())
+	ass.Equal(t, 60000, messages.GetLease().AsInteger())
+	ass.Nil(t, messages.RetrieveMessage())
+
+	messages.PostMessage(quote("first"))
+	messages.PostMessage(quote("second"))
+	ass.Equal(t, 2, messages.GetSize())
+	ass.Equal(t, 2, messages.GetAvailable())
+
+	var first = messages.RetrieveMessage()
+	ass.Equal(t, "first", first.ExtractQuote().AsString())
+	ass.Equal(t, 2, messages.GetSize())
+	ass.Equal(t, 1, messages.GetAvailable())
+	messages.RejectMessage(first)
+	ass.Equal(t, 2, messages.GetAvailable())
+
+	var second = messages.RetrieveMessage()
+	ass.Equal(t, "second", second.ExtractQuote().AsString())
+	messages.AcceptMessage(second)
+	ass.Equal(t, 1, messages.GetSize())
+	first = messages.RetrieveMessage()
+	ass.Equal(t, "first", first.ExtractQuote().AsString())
+	messages.AcceptMessage(first)
+	ass.Equal(t, 0, messages.GetSize())
+
+	defer func() {
+		if e := recover(); e != nil {
+			ass.Equal(t, "The message bag does not hold a current lease on the message.", e)
+		} else {
+			ass.Fail(t, "Test should result in recovered panic.")
+		}
+	}()
+	messages.AcceptMessage(first) // This should panic.
+}
+
+func TestMessageBagExpiration(t *tes.T) {
+	var messages = bag.MessageBag(1, ele.Duration().FromMilliseconds(10))
+	messages.PostMessage(quote("late"))
+	var message = messages.RetrieveMessage()
+	ass.Equal(t, 0, messages.GetAvailable())
+	tim.Sleep(20 * tim.Millisecond)
+	ass.Equal(t, 1, messages.GetAvailable())
+
+	defer func() {
+		if e := recover(); e != nil {
+			ass.Equal(t, "The message bag does not hold a current lease on the message.", e)
+		} else {
+			ass.Fail(t, "Test should result in recovered panic.")
+		}
+	}()
+	messages.AcceptMessage(message) // This should panic.
+}
+
+func TestMessageBagCapacity(t *tes.T) {
+	var messages = bag.MessageBag(1, ele.Duration().FromMilliseconds(60000))
+	messages.PostMessage(quote("only"))
+	messages.RetrieveMessage()
+	defer func() {
+		if e := recover(); e != nil {
+			ass.Equal(t, "The message bag has reached its capacity of 1 messages.", e)
+		} else {
+			ass.Fail(t, "Test should result in recovered panic.")
+		}
+	}()
+	messages.PostMessage(quote("extra")) // This should panic.
+}
+
+func TestDurableBag(t *tes.T) {
+	var directory = t.TempDir()
+	var lease = ele.Duration().FromMilliseconds(60000)
+	var messages = bag.DurableBag(directory, 5, lease)
+	messages.PostMessage(quote("first"))
+	messages.PostMessage(quote("second"))
+	messages.PostMessage(quote("third"))
+	messages.AcceptMessage(messages.RetrieveMessage())
+	messages.RetrieveMessage()
+
+	// The abandoned lease on the second message does not survive a restart.
+	messages = bag.DurableBag(directory, 5, lease)
+	ass.Equal(t, 2, messages.GetAvailable())
+	var second = messages.RetrieveMessage()
+	ass.Equal(t, "second", second.ExtractQuote().AsString())
+	messages.PostMessage(quote("fourth"))
+	messages.AcceptMessage(second)
+
+	messages = bag.DurableBag(directory, 5, lease)
+	ass.Equal(t, "third", messages.RetrieveMessage().ExtractQuote().AsString())
+	ass.Equal(t, "fourth", messages.RetrieveMessage().ExtractQuote().AsString())
+	ass.Nil(t, messages.RetrieveMessage())
+}
+
+func TestMessageBagDuplicates(t *tes.T) {
+	var messages = bag.MessageBag(2, ele.Duration().FromMilliseconds(60000))
+	var message = quote("twice")
+	messages.PostMessage(message)
+	messages.PostMessage(message)
+
+	var first = messages.RetrieveMessage()
+	var second = messages.RetrieveMessage()
+	ass.Equal(t, "twice", first.ExtractQuote().AsString())
+	ass.Equal(t, "twice", second.ExtractQuote().AsString())
+	ass.Equal(t, 0, messages.GetAvailable())
+
+	messages.RejectMessage(first)
+	ass.Equal(t, 1, messages.GetAvailable())
+	messages.AcceptMessage(second)
+	ass.Equal(t, 1, messages.GetSize())
+	messages.AcceptMessage(messages.RetrieveMessage())
+	ass.Equal(t, 0, messages.GetSize())
+}
@@ -125,12 +125,10 @@ func (v *parser) parseAssociation() (abs.AssociationLike, *Token, bool) {
 	// This must be an association.
 	value, token, ok = v.parseComponent()
 	if !ok {
-		var message = v.formatError(token)
-		message += generateGrammar("value",
+		panic(v.syntaxError(token, "value",
 			"$association",
 			"$key",
-			"$value")
-		panic(message)
+			"$value"))
 	}
 	var association = col.Association(key, value)
 	return association, token, true
@@ -171,13 +169,11 @@ func (v *parser) parseCollection() (abs.Collection, *Token, bool) {
 	}
 	_, token, ok = v.parseDelimiter("]")
 	if !ok {
-		var message = v.formatError(token)
-		message += generateGrammar("]",
+		panic(v.syntaxError(token, "]",
 			"$collection",
 			"$associations",
 			"$association",
-		)
-		panic(message)
+		))
 	}
 	return collection, token, true
 }
@@ -206,13 +202,11 @@ func (v *parser) parseInlineAssociations() (abs.AssociationsLike, *Token, bool)
 		}
 		association, token, ok = v.parseAssociation()
 		if !ok {
-			var message = v.formatError(token)
-			message += generateGrammar("association",
+			panic(v.syntaxError(token, "association",
 				"$collection",
 				"$associations",
 				"$association",
-			)
-			panic(message)
+			))
 		}
 	}
 }
@@ -240,13 +234,11 @@ func (v *parser) parseInlineValues() (abs.ValuesLike, *Token, bool) {
 		}
 		value, token, ok = v.parseComponent()
 		if !ok {
-			var message = v.formatError(token)
-			message += generateGrammar("value",
+			panic(v.syntaxError(token, "value",
 				"$collection",
 				"$values",
 				"$value",
-			)
-			panic(message)
+			))
 		}
 	}
 }
@@ -285,13 +277,24 @@ func (v *parser) parseAssociations() (abs.AssociationsLike, *Token, bool) {
 func (v *formatter) formatAssociations(associations abs.AssociationsLike) {
 	v.AppendString("[")
 	var iterator = col.AssociationIterator(associations)
-	switch associations.GetSize() {
-	case 0:
+	var size = associations.GetSize()
+	switch {
+	case size == 0:
 		v.AppendString(":")
-	case 1:
-		var association = iterator.GetNext()
-		v.formatAssociation(association)
+	case v.appendInline(associations, size, v.options.InlineAssociations, func(inline *formatter) bool {
+		iterator.ToStart()
+		for iterator.HasNext() {
+			var association = iterator.GetNext()
+			inline.formatAssociation(association)
+			if iterator.HasNext() {
+				inline.AppendString(", ")
+			}
+		}
+		return true
+	}):
+		// The associations were formatted inline.
 	default:
+		iterator.ToStart()
 		v.depth++
 		for iterator.HasNext() {
 			v.AppendNewline()
@@ -321,13 +324,11 @@ func (v *parser) parseMultilineAssociations() (abs.AssociationsLike, *Token, boo
 	// Every association must be followed by an EOL.
 	_, token, ok = v.parseEOL()
 	if !ok {
-		var message = v.formatError(token)
-		message += generateGrammar("EOL",
+		panic(v.syntaxError(token, "EOL",
 			"$collection",
 			"$associations",
 			"$association",
-		)
-		panic(message)
+		))
 	}
 	for {
 		var key = association.GetKey()
@@ -341,13 +342,11 @@ func (v *parser) parseMultilineAssociations() (abs.AssociationsLike, *Token, boo
 		// Every association must be followed by an EOL.
 		_, token, ok = v.parseEOL()
 		if !ok {
-			var message = v.formatError(token)
-			message += generateGrammar("EOL",
+			panic(v.syntaxError(token, "EOL",
 				"$collection",
 				"$associations",
 				"$association",
-			)
-			panic(message)
+			))
 		}
 	}
 }
@@ -369,13 +368,11 @@ func (v *parser) parseMultilineValues() (abs.ValuesLike, *Token, bool) {
 	// Every value must be followed by an EOL.
 	_, token, ok = v.parseEOL()
 	if !ok {
-		var message = v.formatError(token)
-		message += generateGrammar("EOL",
+		panic(v.syntaxError(token, "EOL",
 			"$collection",
 			"$values",
 			"$value",
-		)
-		panic(message)
+		))
 	}
 	for {
 		values.AddValue(value)
@@ -387,13 +384,11 @@ func (v *parser) parseMultilineValues() (abs.ValuesLike, *Token, bool) {
 		// Every value must be followed by an EOL.
 		_, token, ok = v.parseEOL()
 		if !ok {
-			var message = v.formatError(token)
-			message += generateGrammar("EOL",
+			panic(v.syntaxError(token, "EOL",
 				"$collection",
 				"$values",
 				"$value",
-			)
-			panic(message)
+			))
 		}
 	}
 }
@@ -496,13 +491,24 @@ func (v *parser) parseValues() (abs.ValuesLike, *Token, bool) {
 func (v *formatter) formatValues(values abs.ValuesLike) {
 	v.AppendString("[")
 	var iterator = com.ComponentIterator(values)
-	switch values.GetSize() {
-	case 0:
+	var size = values.GetSize()
+	switch {
+	case size == 0:
 		v.AppendString(" ")
-	case 1:
-		var value = iterator.GetNext()
-		v.formatComponent(value)
+	case v.appendInline(values, size, v.options.InlineValues, func(inline *formatter) bool {
+		iterator.ToStart()
+		for iterator.HasNext() {
+			var value = iterator.GetNext()
+			inline.formatComponent(value)
+			if iterator.HasNext() {
+				inline.AppendString(", ")
+			}
+		}
+		return true
+	}):
+		// The values were formatted inline.
 	default:
+		iterator.ToStart()
 		v.depth++
 		for iterator.HasNext() {
 			v.AppendNewline()
@@ -131,6 +131,7 @@ func (v *parser) parseComponent() (abs.ComponentLike, *Token, bool) {
 	var component abs.ComponentLike
 	var context abs.ContextLike
 	var note abs.NoteLike
+	var start = v.nextLocation()
 	var entity, token, ok = v.parseEntity()
 	if !ok {
 		return component, token, false
@@ -140,12 +141,16 @@ func (v *parser) parseComponent() (abs.ComponentLike, *Token, bool) {
 	note, token, _ = v.parseNote()         // The note is optional.
 	component = com.ComponentWithContext(entity, context)
 	component.SetNote(note)
+	v.locateNode(component, start)
 	return component, token, true
 }
 
 // This method adds the canonical format for the specified component to the
 // state of the formatter.
 func (v *formatter) formatComponent(component abs.ComponentLike) {
+	if v.preserveNode(component, "component") {
+		return
+	}
 	var entity = component.GetEntity()
 	var context = adjustContext(component)
 	v.formatEntity(entity)
@@ -166,6 +171,7 @@ func (v *parser) parseContext() (abs.ContextLike, *Token, bool) {
 	var ok bool
 	var token *Token
 	var context abs.ContextLike
+	var start = v.nextLocation()
 	_, token, ok = v.parseDelimiter("(")
 	if !ok {
 		return context, token, false
@@ -181,34 +187,45 @@ func (v *parser) parseContext() (abs.ContextLike, *Token, bool) {
 	} else {
 		context, token, ok = v.parseMultilineParameters()
 		if !ok {
-			var message = v.formatError(token)
-			message += generateGrammar("parameter",
+			panic(v.syntaxError(token, "parameter",
 				"$context",
-				"$parameters")
-			panic(message)
+				"$parameters"))
 		}
 	}
 	_, token, ok = v.parseDelimiter(")")
 	if !ok {
-		var message = v.formatError(token)
-		message += generateGrammar(")",
+		panic(v.syntaxError(token, ")",
 			"$context",
-			"$parameters")
-		panic(message)
+			"$parameters"))
 	}
+	v.locateNode(context, start)
 	return context, token, true
 }
 
 // This method adds the canonical format for the specified context to the
 // state of the formatter.
 func (v *formatter) formatContext(context abs.ContextLike) {
+	if v.preserveNode(context, "context") {
+		return
+	}
 	v.AppendString("(")
 	var iterator = com.ParameterIterator(context)
-	switch context.GetSize() {
-	case 1:
-		var parameter = iterator.GetNext()
-		v.formatParameter(parameter)
+	var size = context.GetSize()
+	switch {
+	case size > 0 && v.appendInline(context, size, v.options.InlineParameters, func(inline *formatter) bool {
+		iterator.ToStart()
+		for iterator.HasNext() {
+			var parameter = iterator.GetNext()
+			inline.formatParameter(parameter)
+			if iterator.HasNext() {
+				inline.AppendString(", ")
+			}
+		}
+		return true
+	}):
+		// The parameters were formatted inline.
 	default:
+		iterator.ToStart()
 		v.depth++
 		for iterator.HasNext() {
 			v.AppendNewline()
@@ -289,6 +306,8 @@ func (v *formatter) formatEntity(entity abs.Entity) {
 		v.formatBoolean(value)
 	case abs.PatternLike:
 		v.formatPattern(value)
+	case abs.CitationLike:
+		v.formatCitation(value)
 	case abs.ResourceLike:
 		v.formatResource(value)
 	case abs.TagLike:
@@ -363,14 +382,12 @@ func (v *parser) parseInlineParameters() (abs.ContextLike, *Token, bool) {
 	_, token, ok = v.parseDelimiter(":")
 	if ok {
 		// A context must have at least one parameter.
-		var message = v.formatError(token)
-		message += generateGrammar("parameter",
+		panic(v.syntaxError(token, "parameter",
 			"$context",
 			"$parameters",
 			"$parameter",
 			"$symbol",
-			"$value")
-		panic(message)
+			"$value"))
 	}
 	parameter, token, ok = v.parseParameter()
 	if !ok {
@@ -389,14 +406,12 @@ func (v *parser) parseInlineParameters() (abs.ContextLike, *Token, bool) {
 		}
 		parameter, token, ok = v.parseParameter()
 		if !ok {
-			var message = v.formatError(token)
-			message += generateGrammar("parameter",
+			panic(v.syntaxError(token, "parameter",
 				"$context",
 				"$parameters",
 				"$parameter",
 				"$symbol",
-				"$value")
-			panic(message)
+				"$value"))
 		}
 	}
 	return context, token, true
@@ -424,14 +439,12 @@ func (v *parser) parseMultilineParameters() (abs.ContextLike, *Token, bool) {
 	parameter, token, ok = v.parseParameter()
 	if !ok {
 		// A context must have at least one parameter.
-		var message = v.formatError(token)
-		message += generateGrammar("parameter",
+		panic(v.syntaxError(token, "parameter",
 			"$context",
 			"$parameters",
 			"$parameter",
 			"$symbol",
-			"$value")
-		panic(message)
+			"$value"))
 	}
 	for {
 		var key = parameter.GetKey()
@@ -440,11 +453,9 @@ func (v *parser) parseMultilineParameters() (abs.ContextLike, *Token, bool) {
 		// Every parameter must be followed by an EOL.
 		_, token, ok = v.parseEOL()
 		if !ok {
-			var message = v.formatError(token)
-			message += generateGrammar("EOL",
+			panic(v.syntaxError(token, "EOL",
 				"$context",
-				"$parameters")
-			panic(message)
+				"$parameters"))
 		}
 		parameter, token, ok = v.parseParameter()
 		if !ok {
@@ -471,6 +482,7 @@ func (v *parser) parseNote() (abs.NoteLike, *Token, bool) {
 // This method adds the canonical format for the specified annotation to the
 // state of the formatter.
 func (v *formatter) formatNote(note abs.NoteLike) {
+	v.noted = true
 	v.AppendString("! ")
 	v.AppendString(string(note.AsArray()))
 }
@@ -633,7 +645,7 @@ func adjustContext(component abs.ComponentLike) abs.ContextLike {
 			context = com.ContextFromSequence(context)
 		}
 		component.SetContext(context)
-		var symbol = Symbol("$type")
+		var symbol = Symbol("type")
 		var value = Component(type_)
 		context.SetValue(symbol, value)
 	}
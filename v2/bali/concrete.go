@@ -0,0 +1,244 @@
+/*******************************************************************************
+ *   Copyright (c) 2009-2023 Crater Dog Technologies™.  All Rights Reserved.   *
+ *******************************************************************************
+ * DO NOT ALTER OR REMOVE COPYRIGHT NOTICES OR THIS FILE HEADER.               *
+ *                                                                             *
+ * This code is free software; you can redistribute it and/or modify it under  *
+ * the terms of The MIT License (MIT), as published by the Open Source         *
+ * Initiative. (See http://opensource.org/licenses/MIT)                        *
+ *******************************************************************************/
+
+package bali
+
+import (
+	fmt "fmt"
+	abs "github.com/bali-nebula/go-component-framework/v2/abstractions"
+	sor "sort"
+	sts "strings"
+)
+
+// CONCRETE TREE INTERFACE
+
+// This function parses the specified BDN document into a concrete syntax tree.
+// Unlike the abstract syntax tree returned by the ParseDocument() function, the
+// concrete syntax tree retains the source bytes of the document along with the
+// span of every located node, so every token, blank line and comment in the
+// document is kept. The component returned by the GetComponent() method of the
+// tree may be edited in place and the FormatDocument() method then reproduces
+// every node that was not touched byte-for-byte from the original document.
+// This function panics with a colored error message if the document contains
+// a syntax error.
+func ParseConcreteTree(document []byte) *concreteTree {
+	defer panicOnSyntaxError()
+	return parseConcreteTree(document)
+}
+
+// This function parses the specified BDN document like the ParseConcreteTree()
+// function but returns an error rather than panicking if the document is
+// invalid. Any syntax error is returned as a *SyntaxError.
+func TryParseConcreteTree(document []byte) (tree *concreteTree, err error) {
+	defer recoverError(&err)
+	return parseConcreteTree(document), err
+}
+
+// CONCRETE TREE IMPLEMENTATION
+
+// This type defines the structure and methods for a concrete syntax tree. Each
+// located node that was parsed from the source bytes is recorded along with the
+// skeleton of its canonical format. The skeleton replaces the canonical format
+// of each located child node with a placeholder so that a change to the node
+// itself can be distinguished from a change to one of its children.
+type concreteTree struct {
+	source    []byte
+	component abs.ComponentLike
+	nodes     map[any]*concreteNode
+}
+
+// This type defines the information that is recorded for each located node in
+// a concrete syntax tree.
+type concreteNode struct {
+	index    int      // The index of the node used in its placeholder.
+	kind     string   // The kind of formatting that is used for the node.
+	span     abs.Span // The span of the source bytes from which the node was parsed.
+	skeleton string   // The canonical format of the node with child placeholders.
+	children []any    // The located child nodes in the order they were formatted.
+}
+
+// This method returns the source bytes from which this concrete syntax tree was
+// parsed.
+func (v *concreteTree) GetSource() []byte {
+	return v.source
+}
+
+// This method returns the component at the root of this concrete syntax tree.
+// The component may be edited in place.
+func (v *concreteTree) GetComponent() abs.ComponentLike {
+	return v.component
+}
+
+// This method returns every token in the source bytes of this concrete syntax
+// tree, including the whitespace tokens that the parser ignores. The values of
+// the tokens concatenate back into the source bytes.
+func (v *concreteTree) GetTokens() []Token {
+	var tokens []Token
+	var scanner = Scanner(v.source)
+	var end = abs.Location{Line: 1, Column: 1}
+	for {
+		var token = scanner.NextToken()
+		if token.Type == TokenEOF || token.Type == TokenERROR {
+			break
+		}
+		if token.Offset > end.Offset {
+			var whitespace = string(v.source[end.Offset:token.Offset])
+			tokens = append(tokens, Token{TokenWHITESPACE, whitespace, end.Line, end.Column, end.Offset})
+		}
+		tokens = append(tokens, token)
+		end = tokenEnd(&token)
+	}
+	if end.Offset < len(v.source) {
+		var whitespace = string(v.source[end.Offset:])
+		tokens = append(tokens, Token{TokenWHITESPACE, whitespace, end.Line, end.Column, end.Offset})
+	}
+	return tokens
+}
+
+// This method returns the BDN bytes for the current state of the component in
+// this concrete syntax tree. Each node that has not been changed since it was
+// parsed is reproduced byte-for-byte from the original source bytes. A node
+// whose own structure has changed is formatted canonically, but each of its
+// unchanged child nodes is still reproduced from the original source bytes.
+func (v *concreteTree) FormatDocument() []byte {
+	var root, ok = v.nodes[v.component]
+	if !ok {
+		return FormatDocument(v.component)
+	}
+	var start = root.span.Start.Offset
+	var end = root.span.End.Offset
+	var result = string(v.source[:start]) + v.formatNode(v.component) + string(v.source[end:])
+	return []byte(result)
+}
+
+// PRIVATE METHODS
+
+// This private method returns the BDN string for the specified located node.
+func (v *concreteTree) formatNode(node any) string {
+	var record = v.nodes[node]
+	var skeleton, children = v.formatSkeleton(node, record.kind)
+	if skeleton != record.skeleton {
+		// The node itself has changed so its skeleton is formatted canonically.
+		var indentation = v.lineIndentation(record.span.Start.Offset)
+		var result = sts.ReplaceAll(skeleton, EOL, EOL+indentation)
+		for _, child := range children {
+			var placeholder = v.placeholder(child)
+			result = sts.Replace(result, placeholder, v.formatNode(child), 1)
+		}
+		return result
+	}
+
+	// Only the child nodes may have changed so they are spliced into the
+	// original source bytes for the node.
+	sor.Slice(children, func(i, j int) bool {
+		return v.nodes[children[i]].span.Start.Offset < v.nodes[children[j]].span.Start.Offset
+	})
+	var result sts.Builder
+	var next = record.span.Start.Offset
+	for _, child := range children {
+		var span = v.nodes[child].span
+		result.Write(v.source[next:span.Start.Offset])
+		result.WriteString(v.formatNode(child))
+		next = span.End.Offset
+	}
+	result.Write(v.source[next:record.span.End.Offset])
+	return result.String()
+}
+
+// This private method returns the canonical format for the specified node of
+// the specified kind in which each located child node has been replaced with a
+// placeholder. It also returns the located child nodes in the order in which
+// they were encountered.
+func (v *concreteTree) formatSkeleton(node any, kind string) (string, []any) {
+	var children []any
+	var formatter = Formatter(0)
+	formatter.preserve = func(child any, kind string) (string, bool) {
+		if child == node {
+			return "", false
+		}
+		var record, ok = v.nodes[child]
+		if !ok {
+			// This is a new node so it gets formatted as part of the skeleton.
+			return "", false
+		}
+		record.kind = kind
+		children = append(children, child)
+		return v.placeholder(child), true
+	}
+	switch kind {
+	case "attribute":
+		formatter.formatAttribute(node.(abs.AttributeLike))
+	case "block":
+		formatter.formatBlock(node.(abs.BlockLike))
+	case "clause":
+		formatter.formatMainClause(node.(abs.Clause))
+	case "component":
+		formatter.formatComponent(node.(abs.ComponentLike))
+	case "context":
+		formatter.formatContext(node.(abs.ContextLike))
+	case "expression":
+		formatter.formatExpression(node.(abs.Expression))
+	case "statement":
+		formatter.formatStatement(node.(abs.StatementLike))
+	default:
+		panic(fmt.Sprintf("An invalid node kind was found in a concrete syntax tree: %v", kind))
+	}
+	return formatter.GetResult(), children
+}
+
+// This private method records the skeleton for the specified located node and
+// for each of its located descendants.
+func (v *concreteTree) recordSkeleton(node any) {
+	var record = v.nodes[node]
+	record.skeleton, record.children = v.formatSkeleton(node, record.kind)
+	for _, child := range record.children {
+		v.recordSkeleton(child)
+	}
+}
+
+// This private method returns the placeholder that stands in for the specified
+// located node in the skeleton of its parent node.
+func (v *concreteTree) placeholder(node any) string {
+	return fmt.Sprintf("\x00%d\x00", v.nodes[node].index)
+}
+
+// This private method returns the whitespace that indents the line containing
+// the specified byte offset in the source bytes.
+func (v *concreteTree) lineIndentation(offset int) string {
+	var start = sts.LastIndex(string(v.source[:offset]), EOL) + 1
+	var end = start
+	for end < offset && (v.source[end] == ' ' || v.source[end] == '\t') {
+		end++
+	}
+	return string(v.source[start:end])
+}
+
+// PRIVATE FUNCTIONS
+
+// This private function parses the specified document into a concrete syntax
+// tree.
+func parseConcreteTree(document []byte) *concreteTree {
+	var parser = Parser(document)
+	parser.spans = make(map[any]abs.Span)
+	var component = parser.parseDocument()
+	var v = &concreteTree{
+		source:    document,
+		component: component,
+		nodes:     make(map[any]*concreteNode, len(parser.spans)),
+	}
+	for node, span := range parser.spans {
+		v.nodes[node] = &concreteNode{index: len(v.nodes), span: span}
+	}
+	if record, ok := v.nodes[component]; ok {
+		record.kind = "component"
+		v.recordSkeleton(component)
+	}
+	return v
+}
@@ -0,0 +1,91 @@
+/*******************************************************************************
+ *   Copyright (c) 2009-2023 Crater Dog Technologies™.  All Rights Reserved.   *
+ *******************************************************************************
+ * DO NOT ALTER OR REMOVE COPYRIGHT NOTICES OR THIS FILE HEADER.               *
+ *                                                                             *
+ * This code is free software; you can redistribute it and/or modify it under  *
+ * the terms of The MIT License (MIT), as published by the Open Source         *
+ * Initiative. (See http://opensource.org/licenses/MIT)                        *
+ *******************************************************************************/
+
+package bali_test
+
+import (
+	abs "github.com/bali-nebula/go-component-framework/v2/abstractions"
+	bal "github.com/bali-nebula/go-component-framework/v2/bali"
+	exp "github.com/bali-nebula/go-component-framework/v2/expressions"
+	ass "github.com/stretchr/testify/assert"
+	sts "strings"
+	tes "testing"
+)
+
+const concreteSource = `[
+    $name: "Jane"     ! The customer name.
+    $age:  42
+    $process: {
+        !>
+            Compute the total.
+        <!
+        let $total := price   *   quantity
+
+        return total  ! Done.
+    }
+]
+`
+
+func TestConcreteTreeIsLossless(t *tes.T) {
+	var tree = bal.ParseConcreteTree([]byte(concreteSource))
+	ass.Equal(t, concreteSource, string(tree.FormatDocument()))
+
+	var source sts.Builder
+	for _, token := range tree.GetTokens() {
+		source.WriteString(token.Value)
+	}
+	ass.Equal(t, concreteSource, source.String())
+}
+
+func TestConcreteTreeEdits(t *tes.T) {
+	var tree = bal.ParseConcreteTree([]byte(concreteSource))
+	var catalog = tree.GetComponent().ExtractCatalog()
+	var process = catalog.GetValue(bal.Symbol("process")).ExtractProcedure()
+	var clause = process.AsArray()[0].GetMainClause().(abs.LetClauseLike)
+	clause.SetExpression(exp.Variable("cost"))
+	var age = catalog.GetValue(bal.Symbol("age"))
+	age.SetEntity(bal.Number(43))
+	ass.Equal(t, `[
+    $name: "Jane"     ! The customer name.
+    $age:  43
+    $process: {
+        !>
+            Compute the total.
+        <!
+        let $total := cost
+
+        return total  ! Done.
+    }
+]
+`, string(tree.FormatDocument()))
+
+	catalog.SetValue(bal.Symbol("city"), bal.Component(bal.Quote("Springfield")))
+	ass.Equal(t, `[
+    $name: "Jane"     ! The customer name.
+    $age: 43
+    $process: {
+        !>
+            Compute the total.
+        <!
+        let $total := cost
+
+        return total  ! Done.
+    }
+    $city: "Springfield"
+]
+`, string(tree.FormatDocument()))
+}
+
+func TestTryParseConcreteTree(t *tes.T) {
+	var tree, err = bal.TryParseConcreteTree([]byte("[\n    $a: \n]\n"))
+	ass.Nil(t, tree)
+	var _, ok = err.(*bal.SyntaxError)
+	ass.True(t, ok)
+}
@@ -352,7 +352,7 @@ func (v *parser) parseAngle() (abs.AngleLike, *Token, bool) {
 // of the formatter.
 func (v *formatter) formatAngle(angle abs.AngleLike) {
 	var string_ = angle.AsString()
-	v.AppendString(string_)
+	v.AppendString(v.spellSymbols(string_))
 }
 
 // This method attempts to parse a boolean element. It returns the boolean
@@ -376,6 +376,27 @@ func (v *formatter) formatBoolean(boolean abs.BooleanLike) {
 	v.AppendString(string_)
 }
 
+// This method attempts to parse a citation element. It returns the citation
+// element and whether or not the citation element was successfully parsed.
+func (v *parser) parseCitation() (abs.CitationLike, *Token, bool) {
+	var token *Token
+	var citation abs.CitationLike
+	token = v.nextToken()
+	if token.Type != TokenCITATION {
+		v.backupOne(token)
+		return citation, token, false
+	}
+	citation = ele.CitationFromString(token.Value)
+	return citation, token, true
+}
+
+// This method adds the canonical format for the specified element to the state
+// of the formatter.
+func (v *formatter) formatCitation(citation abs.CitationLike) {
+	var string_ = citation.AsString()
+	v.AppendString(string_)
+}
+
 // This method attempts to parse a duration element. It returns the duration
 // element and whether or not the duration element was successfully parsed.
 func (v *parser) parseDuration() (abs.DurationLike, *Token, bool) {
@@ -408,6 +429,9 @@ func (v *parser) parseElement() (abs.Element, *Token, bool) {
 	if !ok {
 		element, token, ok = v.parseBoolean()
 	}
+	if !ok {
+		element, token, ok = v.parseCitation()
+	}
 	if !ok {
 		element, token, ok = v.parseDuration()
 	}
@@ -474,7 +498,7 @@ func (v *parser) parseNumber() (abs.NumberLike, *Token, bool) {
 // of the formatter.
 func (v *formatter) formatNumber(number abs.NumberLike) {
 	var string_ = number.AsString()
-	v.AppendString(string_)
+	v.AppendString(v.spellSymbols(string_))
 }
 
 // This method attempts to parse a pattern element. It returns the pattern
@@ -516,7 +540,7 @@ func (v *parser) parsePercentage() (abs.PercentageLike, *Token, bool) {
 // of the formatter.
 func (v *formatter) formatPercentage(percentage abs.PercentageLike) {
 	var string_ = percentage.AsString()
-	v.AppendString(string_)
+	v.AppendString(v.spellSymbols(string_))
 }
 
 // This method attempts to parse a probability element. It returns the probability
@@ -0,0 +1,439 @@
+/*******************************************************************************
+ *   Copyright (c) 2009-2023 Crater Dog Technologies™.  All Rights Reserved.   *
+ *******************************************************************************
+ * DO NOT ALTER OR REMOVE COPYRIGHT NOTICES OR THIS FILE HEADER.               *
+ *                                                                             *
+ * This code is free software; you can redistribute it and/or modify it under  *
+ * the terms of The MIT License (MIT), as published by the Open Source         *
+ * Initiative. (See http://opensource.org/licenses/MIT)                        *
+ *******************************************************************************/
+
+package bali
+
+import (
+	byt "bytes"
+	enc "encoding/binary"
+	fmt "fmt"
+	abs "github.com/bali-nebula/go-component-framework/v2/abstractions"
+	col "github.com/bali-nebula/go-component-framework/v2/collections"
+	com "github.com/bali-nebula/go-component-framework/v2/components"
+	ele "github.com/bali-nebula/go-component-framework/v2/elements"
+	str "github.com/bali-nebula/go-component-framework/v2/strings"
+	mat "math"
+)
+
+// ENCODING INTERFACE
+
+// This function returns a compact, self-describing binary encoding of the
+// specified component. The encoding begins with the four byte header "BDN\x01"
+// followed by the encoded component. Each component is encoded as a flags byte
+// indicating whether or not it has a context and a note, followed by its
+// entity, its context parameters (if any) and its note (if any):
+//
+//	component:  flags entity [count (symbol component)...] [note]
+//	entity:     type-tag payload
+//	catalog:    count (entity component)...
+//	list:       count component...
+//	string:     length bytes
+//
+// All counts and lengths are unsigned varints. Each element and string type has
+// its own type tag and a payload that uses its native value where one exists,
+// for example an IEEE 754 float for an angle, the number of milliseconds for a
+// duration or moment and the raw bytes for a binary string. Narratives, ranges
+// and procedures are encoded as strings containing their canonical Bali source.
+func EncodeComponent(component abs.ComponentLike) []byte {
+	var v = &binaryEncoder{}
+	v.buffer.Write(encodingHeader)
+	v.encodeComponent(component)
+	return v.buffer.Bytes()
+}
+
+// This function decodes the specified binary encoding, as produced by the
+// EncodeComponent() function, and returns the corresponding component.
+func DecodeComponent(encoding []byte) abs.ComponentLike {
+	if !byt.HasPrefix(encoding, encodingHeader) {
+		panic("The binary encoding does not begin with a valid header.")
+	}
+	var v = &binaryDecoder{encoding: encoding, next: len(encodingHeader)}
+	var component = v.decodeComponent()
+	if v.next != len(encoding) {
+		var message = fmt.Sprintf("The binary encoding contains %v unexpected trailing bytes.", len(encoding)-v.next)
+		panic(message)
+	}
+	return component
+}
+
+// BINARY ENCODING DEFINITIONS
+
+// This header identifies (and versions) the binary encoding.
+var encodingHeader = []byte("BDN\x01")
+
+// These flags identify the optional parts of an encoded component.
+const (
+	flagCONTEXT byte = 0x01
+	flagNOTE    byte = 0x02
+)
+
+// These type tags identify the type of each encoded entity. Their values are
+// part of the binary encoding and must never be changed.
+const (
+	tagANGLE       byte = 0x01
+	tagBOOLEAN     byte = 0x02
+	tagCITATION    byte = 0x03
+	tagDURATION    byte = 0x04
+	tagMOMENT      byte = 0x05
+	tagNUMBER      byte = 0x06
+	tagPATTERN     byte = 0x07
+	tagPERCENTAGE  byte = 0x08
+	tagPROBABILITY byte = 0x09
+	tagRESOURCE    byte = 0x0A
+	tagBINARY      byte = 0x10
+	tagBYTECODE    byte = 0x11
+	tagNAME        byte = 0x12
+	tagNARRATIVE   byte = 0x13
+	tagQUOTE       byte = 0x14
+	tagSYMBOL      byte = 0x15
+	tagTAG         byte = 0x16
+	tagVERSION     byte = 0x17
+	tagCATALOG     byte = 0x20
+	tagLIST        byte = 0x21
+	tagINTERVAL    byte = 0x30
+	tagSPECTRUM    byte = 0x31
+	tagCONTINUUM   byte = 0x32
+	tagPROCEDURE   byte = 0x40
+)
+
+// BINARY ENCODER IMPLEMENTATION
+
+// This type defines the structure and methods for a binary encoding agent.
+type binaryEncoder struct {
+	buffer byt.Buffer
+}
+
+// This private method encodes the specified component.
+func (v *binaryEncoder) encodeComponent(component abs.ComponentLike) {
+	var context = adjustContext(component)
+	var flags byte
+	if component.IsParameterized() {
+		flags |= flagCONTEXT
+	}
+	if component.IsAnnotated() {
+		flags |= flagNOTE
+	}
+	v.buffer.WriteByte(flags)
+	v.encodeEntity(component.GetEntity())
+	if component.IsParameterized() {
+		v.encodeCount(context.GetSize())
+		var iterator = com.ParameterIterator(context)
+		for iterator.HasNext() {
+			var parameter = iterator.GetNext()
+			v.encodeString(parameter.GetKey().AsString())
+			v.encodeComponent(parameter.GetValue())
+		}
+	}
+	if component.IsAnnotated() {
+		v.encodeString(string(component.GetNote().AsArray()))
+	}
+}
+
+// This private method encodes the specified entity. The order of the cases
+// must match the order used by the formatter.
+func (v *binaryEncoder) encodeEntity(entity abs.Entity) {
+	switch value := entity.(type) {
+	case abs.BinaryLike:
+		v.buffer.WriteByte(tagBINARY)
+		v.encodeBytes(value.AsArray())
+	case abs.BytecodeLike:
+		v.buffer.WriteByte(tagBYTECODE)
+		var instructions = value.AsArray()
+		v.encodeCount(len(instructions))
+		for _, instruction := range instructions {
+			v.buffer.WriteByte(instruction.GetLeftByte())
+			v.buffer.WriteByte(instruction.GetRightByte())
+		}
+	case abs.NameLike:
+		v.buffer.WriteByte(tagNAME)
+		v.encodeString(value.AsString())
+	case abs.NarrativeLike:
+		v.buffer.WriteByte(tagNARRATIVE)
+		v.encodeString(FormatEntity(value))
+	case abs.QuoteLike:
+		v.buffer.WriteByte(tagQUOTE)
+		v.encodeString(value.AsString())
+	case abs.VersionLike:
+		v.buffer.WriteByte(tagVERSION)
+		var ordinals = value.AsArray()
+		v.encodeCount(len(ordinals))
+		for _, ordinal := range ordinals {
+			v.encodeCount(int(ordinal))
+		}
+	case abs.DurationLike:
+		v.buffer.WriteByte(tagDURATION)
+		v.encodeInteger(value.AsInteger())
+	case abs.MomentLike:
+		v.buffer.WriteByte(tagMOMENT)
+		v.encodeInteger(value.AsInteger())
+	case abs.NumberLike:
+		v.buffer.WriteByte(tagNUMBER)
+		v.encodeFloat(value.GetReal())
+		v.encodeFloat(value.GetImaginary())
+	case abs.PercentageLike:
+		// The percentage is encoded as a string since converting it to a fraction
+		// and back may lose precision.
+		v.buffer.WriteByte(tagPERCENTAGE)
+		v.encodeString(value.AsString())
+	case abs.ProbabilityLike:
+		v.buffer.WriteByte(tagPROBABILITY)
+		v.encodeFloat(value.AsFloat())
+	case abs.AngleLike:
+		v.buffer.WriteByte(tagANGLE)
+		v.encodeFloat(value.AsFloat())
+	case abs.BooleanLike:
+		v.buffer.WriteByte(tagBOOLEAN)
+		if value.AsBoolean() {
+			v.buffer.WriteByte(1)
+		} else {
+			v.buffer.WriteByte(0)
+		}
+	case abs.PatternLike:
+		v.buffer.WriteByte(tagPATTERN)
+		v.encodeString(value.AsString())
+	case abs.CitationLike:
+		v.buffer.WriteByte(tagCITATION)
+		v.encodeString(value.AsString())
+	case abs.ResourceLike:
+		v.buffer.WriteByte(tagRESOURCE)
+		v.encodeString(value.AsString())
+	case abs.TagLike:
+		// The tag is encoded as a string since its base 32 encoding need not
+		// contain a whole number of bytes.
+		v.buffer.WriteByte(tagTAG)
+		v.encodeString(value.AsString())
+	case abs.SymbolLike:
+		v.buffer.WriteByte(tagSYMBOL)
+		v.encodeString(value.AsString())
+	case abs.ValuesLike:
+		v.buffer.WriteByte(tagLIST)
+		v.encodeCount(value.GetSize())
+		var iterator = com.ComponentIterator(value)
+		for iterator.HasNext() {
+			v.encodeComponent(iterator.GetNext())
+		}
+	case abs.AssociationsLike:
+		v.buffer.WriteByte(tagCATALOG)
+		v.encodeCount(value.GetSize())
+		var iterator = col.AssociationIterator(value)
+		for iterator.HasNext() {
+			var association = iterator.GetNext()
+			v.encodeEntity(association.GetKey())
+			v.encodeComponent(association.GetValue())
+		}
+	case abs.IntervalLike:
+		v.buffer.WriteByte(tagINTERVAL)
+		v.encodeString(FormatEntity(value))
+	case abs.SpectrumLike:
+		v.buffer.WriteByte(tagSPECTRUM)
+		v.encodeString(FormatEntity(value))
+	case abs.ContinuumLike:
+		v.buffer.WriteByte(tagCONTINUUM)
+		v.encodeString(FormatEntity(value))
+	case abs.ProcedureLike:
+		v.buffer.WriteByte(tagPROCEDURE)
+		v.encodeString(FormatEntity(value))
+	default:
+		var message = fmt.Sprintf("An invalid entity (of type %T) was passed to the encoder: %v", entity, entity)
+		panic(message)
+	}
+}
+
+// This private method encodes the specified count or length.
+func (v *binaryEncoder) encodeCount(count int) {
+	v.buffer.Write(enc.AppendUvarint(nil, uint64(count)))
+}
+
+// This private method encodes the specified signed integer.
+func (v *binaryEncoder) encodeInteger(integer int) {
+	v.buffer.Write(enc.AppendVarint(nil, int64(integer)))
+}
+
+// This private method encodes the specified floating point number.
+func (v *binaryEncoder) encodeFloat(float float64) {
+	v.buffer.Write(enc.BigEndian.AppendUint64(nil, mat.Float64bits(float)))
+}
+
+// This private method encodes the specified length prefixed bytes.
+func (v *binaryEncoder) encodeBytes(bytes []byte) {
+	v.encodeCount(len(bytes))
+	v.buffer.Write(bytes)
+}
+
+// This private method encodes the specified length prefixed string.
+func (v *binaryEncoder) encodeString(string_ string) {
+	v.encodeBytes([]byte(string_))
+}
+
+// BINARY DECODER IMPLEMENTATION
+
+// This type defines the structure and methods for a binary decoding agent.
+type binaryDecoder struct {
+	encoding []byte
+	next     int // The index of the next byte to be decoded.
+}
+
+// This private method decodes the next component.
+func (v *binaryDecoder) decodeComponent() abs.ComponentLike {
+	var flags = v.decodeByte()
+	var component = com.Component(v.decodeEntity())
+	if flags&flagCONTEXT != 0 {
+		var context = com.Context()
+		var count = v.decodeCount()
+		for index := 0; index < count; index++ {
+			var symbol = Symbol(v.decodeString())
+			context.SetValue(symbol, v.decodeComponent())
+		}
+		component.SetContext(context)
+	}
+	if flags&flagNOTE != 0 {
+		component.SetNote(com.Note(v.decodeString()))
+	}
+	return component
+}
+
+// This private method decodes the next entity.
+func (v *binaryDecoder) decodeEntity() abs.Entity {
+	var entity abs.Entity
+	var tag = v.decodeByte()
+	switch tag {
+	case tagANGLE:
+		entity = Angle(v.decodeFloat())
+	case tagBOOLEAN:
+		entity = Boolean(v.decodeByte() != 0)
+	case tagCITATION:
+		entity = ele.CitationFromString(v.decodeString())
+	case tagDURATION:
+		entity = Duration(v.decodeInteger())
+	case tagMOMENT:
+		entity = Moment(v.decodeInteger())
+	case tagNUMBER:
+		var real_ = v.decodeFloat()
+		var imaginary = v.decodeFloat()
+		entity = Number(complex(real_, imaginary))
+	case tagPATTERN:
+		entity = Pattern(v.decodeString())
+	case tagPERCENTAGE:
+		entity = Percentage(v.decodeString())
+	case tagPROBABILITY:
+		entity = Probability(v.decodeFloat())
+	case tagRESOURCE:
+		entity = Resource(v.decodeString())
+	case tagBINARY:
+		entity = Binary(v.decodeBytes())
+	case tagBYTECODE:
+		var count = v.decodeCount()
+		var instructions = make([]abs.Instruction, count)
+		for index := range instructions {
+			var leftByte = v.decodeByte()
+			var rightByte = v.decodeByte()
+			instructions[index] = abs.InstructionFromBytes(leftByte, rightByte)
+		}
+		entity = Bytecode(instructions)
+	case tagNAME:
+		entity = str.NameFromString(v.decodeString())
+	case tagQUOTE:
+		entity = Quote(v.decodeString())
+	case tagSYMBOL:
+		entity = Symbol(v.decodeString())
+	case tagTAG:
+		entity = Tag(v.decodeString())
+	case tagVERSION:
+		var count = v.decodeCount()
+		var ordinals = make([]abs.Ordinal, count)
+		for index := range ordinals {
+			ordinals[index] = abs.Ordinal(v.decodeCount())
+		}
+		entity = Version(ordinals)
+	case tagCATALOG:
+		var catalog = col.Catalog()
+		var count = v.decodeCount()
+		for index := 0; index < count; index++ {
+			var key = v.decodeEntity()
+			catalog.SetValue(key, v.decodeComponent())
+		}
+		entity = catalog
+	case tagLIST:
+		var list = col.List()
+		var count = v.decodeCount()
+		for index := 0; index < count; index++ {
+			list.AddValue(v.decodeComponent())
+		}
+		entity = list
+	case tagNARRATIVE, tagINTERVAL, tagSPECTRUM, tagCONTINUUM, tagPROCEDURE:
+		entity = ParseEntity(v.decodeString())
+	default:
+		var message = fmt.Sprintf("The binary encoding contains an invalid type tag at byte %v: %#02x", v.next-1, tag)
+		panic(message)
+	}
+	return entity
+}
+
+// This private method decodes the next byte.
+func (v *binaryDecoder) decodeByte() byte {
+	v.checkLength(1)
+	var b = v.encoding[v.next]
+	v.next++
+	return b
+}
+
+// This private method decodes the next count or length.
+func (v *binaryDecoder) decodeCount() int {
+	var count, size = enc.Uvarint(v.encoding[v.next:])
+	if size <= 0 || count > uint64(len(v.encoding)) {
+		var message = fmt.Sprintf("The binary encoding contains an invalid count at byte %v.", v.next)
+		panic(message)
+	}
+	v.next += size
+	return int(count)
+}
+
+// This private method decodes the next signed integer.
+func (v *binaryDecoder) decodeInteger() int {
+	var integer, size = enc.Varint(v.encoding[v.next:])
+	if size <= 0 {
+		var message = fmt.Sprintf("The binary encoding contains an invalid integer at byte %v.", v.next)
+		panic(message)
+	}
+	v.next += size
+	return int(integer)
+}
+
+// This private method decodes the next floating point number.
+func (v *binaryDecoder) decodeFloat() float64 {
+	v.checkLength(8)
+	var bits = enc.BigEndian.Uint64(v.encoding[v.next:])
+	v.next += 8
+	return mat.Float64frombits(bits)
+}
+
+// This private method decodes the next length prefixed bytes.
+func (v *binaryDecoder) decodeBytes() []byte {
+	var length = v.decodeCount()
+	v.checkLength(length)
+	var bytes = make([]byte, length)
+	copy(bytes, v.encoding[v.next:v.next+length])
+	v.next += length
+	return bytes
+}
+
+// This private method decodes the next length prefixed string.
+func (v *binaryDecoder) decodeString() string {
+	return string(v.decodeBytes())
+}
+
+// This private method checks that the encoding contains at least the specified
+// number of bytes that have not yet been decoded.
+func (v *binaryDecoder) checkLength(length int) {
+	if v.next+length > len(v.encoding) {
+		var message = fmt.Sprintf("The binary encoding ended unexpectedly at byte %v.", len(v.encoding))
+		panic(message)
+	}
+}
@@ -0,0 +1,90 @@
+/*******************************************************************************
+ *   Copyright (c) 2009-2023 Crater Dog Technologies™.  All Rights Reserved.   *
+ *******************************************************************************
+ * DO NOT ALTER OR REMOVE COPYRIGHT NOTICES OR THIS FILE HEADER.               *
+ *                                                                             *
+ * This code is free software; you can redistribute it and/or modify it under  *
+ * the terms of The MIT License (MIT), as published by the Open Source         *
+ * Initiative. (See http://opensource.org/licenses/MIT)                        *
+ *******************************************************************************/
+
+package bali_test
+
+import (
+	abs "github.com/bali-nebula/go-component-framework/v2/abstractions"
+	bal "github.com/bali-nebula/go-component-framework/v2/bali"
+	ass "github.com/stretchr/testify/assert"
+	osx "os"
+	sts "strings"
+	tes "testing"
+)
+
+func TestEncodingRoundtrips(t *tes.T) {
+	var files, err = osx.ReadDir(testDirectory)
+	if err != nil {
+		panic("Could not find the ./test directory.")
+	}
+
+	for _, file := range files {
+		var filename = testDirectory + file.Name()
+		if sts.HasSuffix(filename, ".bali") {
+			var expected, _ = osx.ReadFile(filename)
+			var component = bal.ParseDocument(expected)
+			var encoding = bal.EncodeComponent(component)
+			component = bal.DecodeComponent(encoding)
+			ass.Equal(t, string(expected), string(bal.FormatDocument(component)), filename)
+		}
+	}
+}
+
+func TestEncodingTypes(t *tes.T) {
+	var source = `[
+    $angle: ~1.5($units: $radians)
+    $boolean: true
+    $citation: /acme/types/Order/v1
+    $duration: ~P3DT4H
+    $moment: <2023-05-12T10:30>
+    $number: (3, 4i)
+    $pattern: "ab+c"?
+    $percentage: 12.5%
+    $resource: <https://acme.com/orders?id=5>
+    $binary: '>
+        AAECAwQF
+    <'
+    $bytecode: '0102 0304'
+    $name: /acme/orders
+    $quote: "Hello World"
+    $symbol: $foo
+    $version: v1.2.3
+    $list: ["a"]
+    $catalog: [:]
+]  ! A note.`
+	var component = bal.ParseComponent(source)
+	var encoding = bal.EncodeComponent(component)
+	ass.Equal(t, "BDN\x01", string(encoding[:4]))
+	ass.Less(t, len(encoding), len(source))
+	ass.Equal(t, source, bal.FormatComponent(bal.DecodeComponent(encoding)))
+
+	var entities = []abs.Entity{
+		bal.NewTag(),
+		bal.Probability(0.25),
+	}
+	for _, entity := range entities {
+		component = bal.Component(entity)
+		encoding = bal.EncodeComponent(component)
+		ass.Equal(t, bal.FormatComponent(component), bal.FormatComponent(bal.DecodeComponent(encoding)))
+	}
+}
+
+func TestEncodingErrors(t *tes.T) {
+	var encoding = bal.EncodeComponent(bal.ParseComponent(`["alpha", "beta"]`))
+
+	defer func() {
+		if e := recover(); e != nil {
+			ass.Equal(t, "The binary encoding ended unexpectedly at byte 21.", e)
+		} else {
+			ass.Fail(t, "Test should result in recovered panic.")
+		}
+	}()
+	bal.DecodeComponent(encoding[:len(encoding)-1]) // This should panic.
+}
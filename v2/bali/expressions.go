@@ -46,20 +46,16 @@ func (v *parser) parseArguments() (abs.Sequential[abs.Expression], *Token, bool)
 		}
 		argument, token, ok = v.parseExpression()
 		if !ok {
-			var message = v.formatError(token)
-			message += generateGrammar("expression",
+			panic(v.syntaxError(token, "expression",
 				"$arguments",
-				"$expression")
-			panic(message)
+				"$expression"))
 		}
 	}
 	_, token, ok = v.parseDelimiter(")")
 	if !ok {
-		var message = v.formatError(token)
-		message += generateGrammar(")",
+		panic(v.syntaxError(token, ")",
 			"$intrinsic",
-			"$function")
-		panic(message)
+			"$function"))
 	}
 	return arguments, token, true
 }
@@ -102,13 +98,12 @@ func (v *parser) parseArithmetic(first abs.Expression) (abs.Expression, *Token,
 	}
 	second, token, ok = v.parseExpression()
 	if !ok {
-		var message = v.formatError(token)
-		message += generateGrammar("expression",
+		panic(v.syntaxError(token, "expression",
 			"$arithmetic",
-			"$expression")
-		panic(message)
+			"$expression"))
 	}
 	expression = exp.Arithmetic(first, operator, second)
+	v.locateNode(expression, startLocation(first))
 	return expression, token, true
 }
 
@@ -146,13 +141,12 @@ func (v *parser) parseChaining(first abs.Expression) (abs.Expression, *Token, bo
 	}
 	second, token, ok = v.parseExpression()
 	if !ok {
-		var message = v.formatError(token)
-		message += generateGrammar("expression",
+		panic(v.syntaxError(token, "expression",
 			"$chaining",
-			"$expression")
-		panic(message)
+			"$expression"))
 	}
 	expression = exp.Chaining(first, operator, second)
+	v.locateNode(expression, startLocation(first))
 	return expression, token, true
 }
 
@@ -190,13 +184,12 @@ func (v *parser) parseComparison(first abs.Expression) (abs.Expression, *Token,
 	}
 	second, token, ok = v.parseExpression()
 	if !ok {
-		var message = v.formatError(token)
-		message += generateGrammar("expression",
+		panic(v.syntaxError(token, "expression",
 			"$comparison",
-			"$expression")
-		panic(message)
+			"$expression"))
 	}
 	expression = exp.Comparison(first, operator, second)
+	v.locateNode(expression, startLocation(first))
 	return expression, token, true
 }
 
@@ -222,6 +215,7 @@ func (v *parser) parseComplement() (abs.UnaryOperationLike, *Token, bool) {
 	var operator abs.Operator
 	var logical abs.Expression
 	var expression abs.UnaryOperationLike
+	var start = v.nextLocation()
 	operator, token, ok = v.parseOperator()
 	if !ok {
 		// This is not a complement expression.
@@ -234,13 +228,12 @@ func (v *parser) parseComplement() (abs.UnaryOperationLike, *Token, bool) {
 	}
 	logical, token, ok = v.parseExpression()
 	if !ok {
-		var message = v.formatError(token)
-		message += generateGrammar("expression",
+		panic(v.syntaxError(token, "expression",
 			"$complement",
-			"$expression")
-		panic(message)
+			"$expression"))
 	}
 	expression = exp.Complement(operator, logical)
+	v.locateNode(expression, start)
 	return expression, token, true
 }
 
@@ -263,6 +256,7 @@ func (v *parser) parseDereference() (abs.UnaryOperationLike, *Token, bool) {
 	var operator abs.Operator
 	var reference abs.Expression
 	var expression abs.UnaryOperationLike
+	var start = v.nextLocation()
 	operator, token, ok = v.parseOperator()
 	if !ok {
 		// This is not a dereference expression.
@@ -275,13 +269,12 @@ func (v *parser) parseDereference() (abs.UnaryOperationLike, *Token, bool) {
 	}
 	reference, token, ok = v.parseExpression()
 	if !ok {
-		var message = v.formatError(token)
-		message += generateGrammar("expression",
+		panic(v.syntaxError(token, "expression",
 			"$dereference",
-			"$expression")
-		panic(message)
+			"$expression"))
 	}
 	expression = exp.Dereference(operator, reference)
+	v.locateNode(expression, start)
 	return expression, token, true
 }
 
@@ -315,13 +308,12 @@ func (v *parser) parseExponential(base abs.Expression) (abs.Expression, *Token,
 	}
 	exponent, token, ok = v.parseExpression()
 	if !ok {
-		var message = v.formatError(token)
-		message += generateGrammar("expression",
+		panic(v.syntaxError(token, "expression",
 			"$exponential",
-			"$expression")
-		panic(message)
+			"$expression"))
 	}
 	expression = exp.Exponential(base, operator, exponent)
+	v.locateNode(expression, startLocation(base))
 	return expression, token, true
 }
 
@@ -376,6 +368,9 @@ func (v *parser) parseExpression() (abs.Expression, *Token, bool) {
 // This method adds the canonical format for the specified expression to the
 // state of the formatter.
 func (v *formatter) formatExpression(expression abs.Expression) {
+	if v.preserveNode(expression, "expression") {
+		return
+	}
 	switch exp.GetType(expression) {
 	case "ValueExpression":
 		var value = expression.(abs.ValueLike)
@@ -437,6 +432,7 @@ func (v *parser) parseIntrinsic() (abs.IntrinsicLike, *Token, bool) {
 	var function string
 	var arguments abs.Sequential[abs.Expression]
 	var expression abs.IntrinsicLike
+	var start = v.nextLocation()
 	function, token, ok = v.parseIdentifier()
 	if !ok {
 		// This is not a function expression.
@@ -449,6 +445,7 @@ func (v *parser) parseIntrinsic() (abs.IntrinsicLike, *Token, bool) {
 		return expression, token, false
 	}
 	expression = exp.Intrinsic(function, arguments)
+	v.locateNode(expression, start)
 	return expression, token, true
 }
 
@@ -470,6 +467,7 @@ func (v *parser) parseInversion() (abs.UnaryOperationLike, *Token, bool) {
 	var operator abs.Operator
 	var numeric abs.Expression
 	var expression abs.UnaryOperationLike
+	var start = v.nextLocation()
 	operator, token, ok = v.parseOperator()
 	if !ok {
 		// This is not an inversion expression.
@@ -482,13 +480,12 @@ func (v *parser) parseInversion() (abs.UnaryOperationLike, *Token, bool) {
 	}
 	numeric, token, ok = v.parseExpression()
 	if !ok {
-		var message = v.formatError(token)
-		message += generateGrammar("expression",
+		panic(v.syntaxError(token, "expression",
 			"$inversion",
-			"$expression")
-		panic(message)
+			"$expression"))
 	}
 	expression = exp.Inversion(operator, numeric)
+	v.locateNode(expression, start)
 	return expression, token, true
 }
 
@@ -523,24 +520,21 @@ func (v *parser) parseInvocation(target abs.Expression) (abs.Expression, *Token,
 	}
 	message, token, ok = v.parseIdentifier()
 	if !ok {
-		var message = v.formatError(token)
-		message += generateGrammar("method",
+		panic(v.syntaxError(token, "method",
 			"$invocation",
 			"$method",
-			"$arguments")
-		panic(message)
+			"$arguments"))
 	}
 	arguments, token, ok = v.parseArguments()
 	if !ok {
-		var message = v.formatError(token)
-		message += generateGrammar("expression",
+		panic(v.syntaxError(token, "expression",
 			"$invocation",
 			"$method",
 			"$arguments",
-			"$expression")
-		panic(message)
+			"$expression"))
 	}
 	expression = exp.Invocation(target, operator, message, arguments)
+	v.locateNode(expression, startLocation(target))
 	return expression, token, true
 }
 
@@ -570,6 +564,7 @@ func (v *parser) parseSubcomponent(composite abs.Expression) (abs.Expression, *T
 		return expression, token, false
 	}
 	expression = exp.Subcomponent(composite, indices)
+	v.locateNode(expression, startLocation(composite))
 	return expression, token, true
 }
 
@@ -603,13 +598,12 @@ func (v *parser) parseLogical(first abs.Expression) (abs.Expression, *Token, boo
 	}
 	second, token, ok = v.parseExpression()
 	if !ok {
-		var message = v.formatError(token)
-		message += generateGrammar("expression",
+		panic(v.syntaxError(token, "expression",
 			"$logical",
-			"$expression")
-		panic(message)
+			"$expression"))
 	}
 	expression = exp.Logical(first, operator, second)
+	v.locateNode(expression, startLocation(first))
 	return expression, token, true
 }
 
@@ -634,6 +628,7 @@ func (v *parser) parseMagnitude() (abs.UnaryOperationLike, *Token, bool) {
 	var token *Token
 	var numeric abs.Expression
 	var expression abs.UnaryOperationLike
+	var start = v.nextLocation()
 	_, token, ok = v.parseDelimiter("|")
 	if !ok {
 		// This is not a magnitude expression.
@@ -641,20 +636,17 @@ func (v *parser) parseMagnitude() (abs.UnaryOperationLike, *Token, bool) {
 	}
 	numeric, token, ok = v.parseExpression()
 	if !ok {
-		var message = v.formatError(token)
-		message += generateGrammar("expression",
+		panic(v.syntaxError(token, "expression",
 			"$magnitude",
-			"$expression")
-		panic(message)
+			"$expression"))
 	}
 	_, token, ok = v.parseDelimiter("|")
 	if !ok {
-		var message = v.formatError(token)
-		message += generateGrammar("|",
-			"$magnitude")
-		panic(message)
+		panic(v.syntaxError(token, "|",
+			"$magnitude"))
 	}
 	expression = exp.Magnitude(numeric)
+	v.locateNode(expression, start)
 	return expression, token, true
 }
 
@@ -675,6 +667,7 @@ func (v *parser) parsePrecedence() (abs.UnaryOperationLike, *Token, bool) {
 	var token *Token
 	var inner abs.Expression
 	var expression abs.UnaryOperationLike
+	var start = v.nextLocation()
 	_, token, ok = v.parseDelimiter("(")
 	if !ok {
 		// This is not a precedence expression.
@@ -682,21 +675,18 @@ func (v *parser) parsePrecedence() (abs.UnaryOperationLike, *Token, bool) {
 	}
 	inner, token, ok = v.parseExpression()
 	if !ok {
-		var message = v.formatError(token)
-		message += generateGrammar("expression",
+		panic(v.syntaxError(token, "expression",
 			"$precedence",
-			"$expression")
-		panic(message)
+			"$expression"))
 	}
 	_, token, ok = v.parseDelimiter(")")
 	if !ok {
-		var message = v.formatError(token)
-		message += generateGrammar("expression",
+		panic(v.syntaxError(token, "expression",
 			"$precedence",
-			"$expression")
-		panic(message)
+			"$expression"))
 	}
 	expression = exp.Precedence(inner)
+	v.locateNode(expression, start)
 	return expression, token, true
 }
 
@@ -812,7 +802,7 @@ func (v *parser) parseOperator() (abs.Operator, *Token, bool) {
 			operator = abs.SUM
 		case "~":
 			operator = abs.TILDA
-		case "≠":
+		case "≠", "!=":
 			operator = abs.UNEQUAL
 		default:
 			// The token is not an operator.
@@ -885,6 +875,10 @@ func (v *formatter) formatOperator(operator abs.Operator) {
 	case abs.TILDA:
 		v.AppendString("~")
 	case abs.UNEQUAL:
+		if v.options.ASCII {
+			v.AppendString("!=")
+			break
+		}
 		v.AppendString("≠")
 	case abs.XOR:
 		v.AppendString("XOR")
@@ -901,12 +895,14 @@ func (v *parser) parseValue() (abs.ValueLike, *Token, bool) {
 	var token *Token
 	var value abs.ValueLike
 	var component abs.ComponentLike
+	var start = v.nextLocation()
 	component, token, ok = v.parseComponent()
 	if !ok {
 		// This is not a value.
 		return value, token, false
 	}
 	value = exp.Value(component)
+	v.locateNode(value, start)
 	return value, token, true
 }
 
@@ -914,12 +910,14 @@ func (v *parser) parseValue() (abs.ValueLike, *Token, bool) {
 // string and whether or not the variable was successfully parsed.
 func (v *parser) parseVariable() (abs.VariableLike, *Token, bool) {
 	var variable abs.VariableLike
+	var start = v.nextLocation()
 	var token = v.nextToken()
 	if token.Type != TokenIDENTIFIER {
 		v.backupOne(token)
 		return variable, token, false
 	}
 	variable = exp.Variable(token.Value)
+	v.locateNode(variable, start)
 	return variable, token, true
 }
 
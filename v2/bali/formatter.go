@@ -12,14 +12,51 @@ package bali
 
 import (
 	abs "github.com/bali-nebula/go-component-framework/v2/abstractions"
+	ref "reflect"
 	sts "strings"
+	utf "unicode/utf8"
 )
 
 // FORMATTER INTERFACE
 
+// This type defines the options that control the style of the BDN strings that
+// are generated by a formatter. Each inline option specifies the maximum number
+// of items in a sequence that may be formatted on a single line, a sequence
+// with more items is formatted with one item per line. A sequence that would
+// not fit within the maximum line width is also formatted with one item per
+// line. The options returned by the DefaultFormatterOptions() function are used
+// to generate canonical BDN strings.
+type FormatterOptions struct {
+	Indentation        int  // The number of levels by which every line is indented.
+	MaximumWidth       int  // The maximum number of runes in a line, or zero for no maximum.
+	InlineValues       int  // The maximum number of values in an inline list.
+	InlineAssociations int  // The maximum number of associations in an inline catalog.
+	InlineParameters   int  // The maximum number of parameters in an inline context.
+	InlineStatements   int  // The maximum number of ";" separated statements in an inline procedure.
+	ASCII              bool // Whether to use ASCII spellings rather than Unicode symbols like "≠" and "π".
+}
+
+// This function returns the formatter options that generate canonical BDN
+// strings.
+func DefaultFormatterOptions() FormatterOptions {
+	var options = FormatterOptions{
+		InlineValues:       1,
+		InlineAssociations: 1,
+		InlineParameters:   1,
+	}
+	return options
+}
+
 // This constructor creates a new formatter using the specified indentation.
 func Formatter(indentation int) *formatter {
-	var v = &formatter{indentation: indentation, depth: 0}
+	var options = DefaultFormatterOptions()
+	options.Indentation = indentation
+	return FormatterWithOptions(options)
+}
+
+// This constructor creates a new formatter using the specified options.
+func FormatterWithOptions(options FormatterOptions) *formatter {
+	var v = &formatter{options: options, depth: 0}
 	return v
 }
 
@@ -49,6 +86,21 @@ func FormatComponentWithIndentation(component abs.ComponentLike, indentation int
 	return v.FormatComponent(component)
 }
 
+// This function returns a BDN string for the specified component using the
+// specified formatter options.
+func FormatComponentWithOptions(component abs.ComponentLike, options FormatterOptions) string {
+	var v = FormatterWithOptions(options)
+	return v.FormatComponent(component)
+}
+
+// This function returns a canonical BDN string for the specified clause of a
+// procedure statement.
+func FormatClause(clause abs.Clause) string {
+	var v = Formatter(0)
+	v.formatMainClause(clause)
+	return v.GetResult()
+}
+
 // This function returns a canonical BDN bytes for the specified component
 // including the POSIX standard trailing EOL.
 func FormatDocument(component abs.ComponentLike) []byte {
@@ -60,36 +112,60 @@ func FormatDocument(component abs.ComponentLike) []byte {
 
 // This type defines the structure and methods for a canonical formatting agent.
 type formatter struct {
-	indentation int
-	depth       int
-	result      sts.Builder
+	options   FormatterOptions
+	depth     int
+	result    sts.Builder
+	lineStart int                                        // The byte index of the current line in the result.
+	noted     bool                                       // Whether or not a note has been formatted.
+	preserve  func(node any, kind string) (string, bool) // An optional hook for preserved nodes.
+	measures  map[any]measure                            // The measured inline formats of sequences.
+	measuring bool                                       // Whether or not sequences are only measured.
+	extra     int                                        // The width of the measured sequences.
+}
+
+// This type defines the structure of the measurements of the inline format of
+// a sequence.
+type measure struct {
+	width int  // The number of runes in the inline format.
+	flat  bool // Whether or not the inline format is a single line without notes.
 }
 
 // This method returns the number of levels that each line is indented in the
 // resulting canonical string.
 func (v *formatter) GetIndentation() int {
-	return v.indentation
+	return v.options.Indentation
+}
+
+// This method returns the options used by this formatter.
+func (v *formatter) GetOptions() FormatterOptions {
+	return v.options
 }
 
 // This method appends the specified string to the result.
 func (v *formatter) AppendString(string_ string) {
+	var lastEOL = sts.LastIndex(string_, EOL)
+	if lastEOL >= 0 {
+		v.lineStart = v.result.Len() + lastEOL + 1
+	}
 	v.result.WriteString(string_)
 }
 
 // This method appends a properly indented newline to the result.
 func (v *formatter) AppendNewline() {
 	var separator = EOL
-	var levels = v.depth + v.indentation
+	var levels = v.depth + v.options.Indentation
 	for level := 0; level < levels; level++ {
 		separator += "    "
 	}
-	v.result.WriteString(separator)
+	v.AppendString(separator)
 }
 
 // This method returns the canonically formatted string result.
 func (v *formatter) GetResult() string {
 	var result = v.result.String()
 	v.result.Reset()
+	v.lineStart = 0
+	v.measures = nil
 	return result
 }
 
@@ -104,3 +180,133 @@ func (v *formatter) FormatComponent(component abs.ComponentLike) string {
 	v.formatComponent(component)
 	return v.GetResult()
 }
+
+// PRIVATE METHODS
+
+// This private method appends to the result the preserved text that the
+// preserve hook of this formatter returns for the specified node of the
+// specified kind. It returns whether or not preserved text was appended, in
+// which case the node itself must not be formatted.
+func (v *formatter) preserveNode(node any, kind string) bool {
+	if v.preserve == nil {
+		return false
+	}
+	var text, ok = v.preserve(node, kind)
+	if ok {
+		v.AppendString(text)
+	}
+	return ok
+}
+
+// This private method attempts to append to the result the inline format of the
+// specified sequence containing the specified number of items which is
+// generated by the specified function. The inline format is only appended if
+// the number of items does not exceed the specified limit and the inline format
+// fits within the maximum line width. A sequence with more than one item must
+// also fit on a single line without any notes. This method returns whether or
+// not the inline format was appended. The width of the single line inline
+// format of each sequence is measured only once, so a sequence that fits on the
+// current line is formatted directly and one that cannot be inline is rejected
+// without formatting it again.
+func (v *formatter) appendInline(sequence any, size int, limit int, format func(inline *formatter) bool) bool {
+	var measure = v.measureInline(sequence, size, limit, format)
+	if v.measuring {
+		// Only the width of the enclosing sequence is needed, and a sequence
+		// spanning lines means that the enclosing one does too.
+		if measure.flat {
+			v.extra += measure.width
+		} else {
+			v.AppendString(EOL)
+		}
+		return true
+	}
+	if size > limit || (size > 1 && !measure.flat) {
+		return false
+	}
+	if measure.flat && v.fitsLine(measure.width) {
+		return format(v)
+	}
+	if size > 1 {
+		return false
+	}
+	// A sequence with a single item may still be inline if the first line of
+	// its format fits, even though the item itself spans several lines.
+	var inline = &formatter{options: v.options, depth: v.depth, preserve: v.preserve, measures: v.measures}
+	inline.AppendString(v.result.String()[v.lineStart:])
+	var prefix = inline.result.Len()
+	if !format(inline) {
+		return false
+	}
+	var result = inline.GetResult()
+	var line = result
+	var firstEOL = sts.Index(result, EOL)
+	if firstEOL >= 0 {
+		line = result[:firstEOL]
+	}
+	if v.options.MaximumWidth > 0 && utf.RuneCountInString(line) > v.options.MaximumWidth {
+		return false
+	}
+	v.noted = v.noted || inline.noted
+	v.AppendString(result[prefix:])
+	return true
+}
+
+// This private method returns the measurements of the inline format of the
+// specified sequence, which is generated by the specified function, ignoring
+// the maximum line width. A sequence with more items than the specified limit
+// is never flat. The measurements of a sequence that is referenced by a pointer
+// are remembered until the result of this formatter is retrieved.
+func (v *formatter) measureInline(sequence any, size int, limit int, format func(inline *formatter) bool) measure {
+	var cached = ref.TypeOf(sequence).Kind() == ref.Pointer
+	if cached {
+		if v.measures == nil {
+			v.measures = make(map[any]measure)
+		}
+		if result, ok := v.measures[sequence]; ok {
+			return result
+		}
+	}
+	var result measure
+	if size <= limit {
+		var probe = &formatter{options: v.options, depth: v.depth, preserve: v.preserve, measures: v.measures, measuring: true}
+		if format(probe) && !probe.noted {
+			var text = probe.result.String()
+			result.flat = !sts.Contains(text, EOL)
+			result.width = utf.RuneCountInString(text) + probe.extra
+		}
+	}
+	if cached {
+		v.measures[sequence] = result
+	}
+	return result
+}
+
+// This private method determines whether or not the specified number of runes
+// fit on the current line without exceeding the maximum line width.
+func (v *formatter) fitsLine(width int) bool {
+	if v.options.MaximumWidth == 0 {
+		return true
+	}
+	var line = v.result.String()[v.lineStart:]
+	return utf.RuneCountInString(line)+width <= v.options.MaximumWidth
+}
+
+// This private method returns the ASCII spelling of the Unicode symbols in the
+// specified element string if the formatter options call for ASCII spellings.
+func (v *formatter) spellSymbols(string_ string) string {
+	if v.options.ASCII {
+		string_ = asciiSpellings.Replace(string_)
+	}
+	return string_
+}
+
+// FORMATTER DEFINITIONS
+
+// This private variable replaces each Unicode symbol in an element string with
+// its ASCII spelling.
+var asciiSpellings = sts.NewReplacer(
+	"π", "pi",
+	"φ", "phi",
+	"τ", "tau",
+	"∞", "infinity",
+)
@@ -0,0 +1,110 @@
+/*******************************************************************************
+ *   Copyright (c) 2009-2023 Crater Dog Technologies™.  All Rights Reserved.   *
+ *******************************************************************************
+ * DO NOT ALTER OR REMOVE COPYRIGHT NOTICES OR THIS FILE HEADER.               *
+ *                                                                             *
+ * This code is free software; you can redistribute it and/or modify it under  *
+ * the terms of The MIT License (MIT), as published by the Open Source         *
+ * Initiative. (See http://opensource.org/licenses/MIT)                        *
+ *******************************************************************************/
+
+package bali_test
+
+import (
+	bal "github.com/bali-nebula/go-component-framework/v2/bali"
+	ass "github.com/stretchr/testify/assert"
+	sts "strings"
+	tes "testing"
+)
+
+const styledSource = `[
+    $values: [
+        1
+        2
+        3
+    ]
+    $point: [
+        $x: ~π
+        $y: ∞
+    ](
+        $type: /acme/types/Point/v1
+        $version: v1
+    )
+    $check: {
+        if a ≠ b do {
+            return a
+        }
+        return b
+    }
+]`
+
+func TestDefaultFormatterOptions(t *tes.T) {
+	var component = bal.ParseComponent(styledSource)
+	var options = bal.DefaultFormatterOptions()
+	ass.Equal(t, styledSource, bal.FormatComponentWithOptions(component, options))
+}
+
+func TestInlineFormatterOptions(t *tes.T) {
+	var component = bal.ParseComponent(styledSource)
+	var options = bal.FormatterOptions{
+		InlineValues:       5,
+		InlineAssociations: 2,
+		InlineParameters:   2,
+		InlineStatements:   2,
+		ASCII:              true,
+	}
+	var source = bal.FormatComponentWithOptions(component, options)
+	ass.Equal(t, `[
+    $values: [1, 2, 3]
+    $point: [$x: ~pi, $y: infinity]($type: /acme/types/Point/v1, $version: v1)
+    $check: {if a != b do {return a}; return b}
+]`, source)
+	ass.Equal(t, styledSource, bal.FormatComponent(bal.ParseComponent(source)))
+}
+
+func TestMaximumWidthFormatterOption(t *tes.T) {
+	var component = bal.ParseComponent(styledSource)
+	var options = bal.FormatterOptions{
+		MaximumWidth:       40,
+		InlineValues:       5,
+		InlineAssociations: 5,
+		InlineParameters:   5,
+		InlineStatements:   5,
+	}
+	ass.Equal(t, `[
+    $values: [1, 2, 3]
+    $point: [$x: ~π, $y: ∞](
+        $type: /acme/types/Point/v1
+        $version: v1
+    )
+    $check: {
+        if a ≠ b do {return a}
+        return b
+    }
+]`, bal.FormatComponentWithOptions(component, options))
+}
+
+func TestMaximumWidthDeeplyNested(t *tes.T) {
+	// Each nested list is measured once, so deeply nested lists that do not
+	// fit on a line are formatted in linear time.
+	var depth = 300
+	var component = bal.ParseComponent(sts.Repeat("[1, ", depth) + "2" + sts.Repeat("]", depth))
+	var options = bal.FormatterOptions{
+		MaximumWidth: 40,
+		InlineValues: 2,
+	}
+	var source = bal.FormatComponentWithOptions(component, options)
+	ass.True(t, sts.HasPrefix(source, "[\n    1\n    [\n        1\n"))
+	ass.Equal(t, 3*depth+1, len(sts.Split(source, "\n")))
+	ass.Equal(t, bal.FormatComponent(component), bal.FormatComponent(bal.ParseComponent(source)))
+}
+
+func TestInlineFormatterOptionsWithNotes(t *tes.T) {
+	var source = `[
+    1  ! The first value.
+    2
+]`
+	var options = bal.DefaultFormatterOptions()
+	options.InlineValues = 2
+	ass.Equal(t, source, bal.FormatComponentWithOptions(bal.ParseComponent(source), options))
+}
@@ -12,6 +12,8 @@ package bali
 
 import (
 	fmt "fmt"
+	sor "sort"
+	sts "strings"
 )
 
 // This map captures the syntax rules for the Bali Document Notation™ (Bali)
@@ -111,7 +113,7 @@ var grammar = map[string]string{
 	"$chaining":       `expression "&" expression`,
 	"$checkoutClause": `"checkout" recipient ("at" "level" level)? "from" name`,
 	"$collection":     `"[" (associations | values) "]"`,
-	"$comparison":     `expression ("<" | "=" | ">" | "≠" | "IS" | "MATCHES") expression`,
+	"$comparison":     `expression ("<" | "=" | ">" | "≠" | "!=" | "IS" | "MATCHES") expression`,
 	"$complement":     `"NOT" expression`,
 	"$component":      `entity context? NOTE?`,
 	"$composite":      `expression`,
@@ -148,7 +150,7 @@ var grammar = map[string]string{
     | inversion  ! ("-" | "/" | "*") expression
     | arithmetic  ! expression ("*" | "/" | "//" | "+" | "-") expression
     | magnitude  ! "|" expression "|"
-    | comparison  ! expression ("<" | "=" | ">" | "≠" | "IS" | "MATCHES") expression
+    | comparison  ! expression ("<" | "=" | ">" | "≠" | "!=" | "IS" | "MATCHES") expression
     | complement  ! "NOT" expression
     | logical  ! expression ("AND" | "SANS" | "OR" | "XOR") expression`,
 	"$failure":    `SYMBOL`,
@@ -221,12 +223,63 @@ var grammar = map[string]string{
 	"$withClause":  `"with" "each" item "in" sequence "do" procedure`,
 }
 
+// GRAMMAR INTERFACE
+
+// This function returns the definition of the specified grammar rule, for
+// example "$ifClause", and whether or not the rule exists.
+func GetGrammarRule(rule string) (string, bool) {
+	var definition, ok = grammar[rule]
+	return definition, ok
+}
+
+// This function returns the sorted names of the grammar rules that define the
+// specified token. A keyword or delimiter token is defined by each rule that
+// contains its literal value, any other token is defined by the rule for its
+// token type.
+func GetTokenRules(token Token) []string {
+	var rules []string
+	switch token.Type {
+	case TokenKEYWORD, TokenDELIMITER:
+		var literal = fmt.Sprintf("%q", token.Value)
+		for rule, definition := range grammar {
+			if sts.Contains(stripRemarks(definition), literal) {
+				rules = append(rules, rule)
+			}
+		}
+		sor.Strings(rules)
+	case TokenINTRINSIC:
+		rules = append(rules, "$intrinsic")
+	default:
+		var rule = "$" + string(token.Type)
+		if _, ok := grammar[rule]; ok {
+			rules = append(rules, rule)
+		}
+	}
+	return rules
+}
+
 // PRIVATE FUNCTIONS
 
-func generateGrammar(expected string, symbols ...string) string {
+// This private function removes the remarks that follow the alternatives in
+// the specified rule definition so that only the rule itself remains.
+func stripRemarks(definition string) string {
+	var lines = sts.Split(definition, EOL)
+	for index, line := range lines {
+		if remark := sts.Index(line, "  ! "); remark >= 0 {
+			lines[index] = line[:remark]
+		}
+	}
+	return sts.Join(lines, EOL)
+}
+
+func generateGrammar(colored bool, expected string, symbols ...string) string {
+	var green, yellow, reset string
+	if colored {
+		green, yellow, reset = "\033[32m", "\033[33m", "\033[0m"
+	}
 	var message = "Was expecting '" + expected + "' from:\n"
 	for _, symbol := range symbols {
-		message += fmt.Sprintf("  \033[32m%v: \033[33m%v\033[0m\n\n", symbol, grammar[symbol])
+		message += fmt.Sprintf("  %v%v: %v%v%v\n\n", green, symbol, yellow, grammar[symbol], reset)
 	}
 	return message
 }
@@ -0,0 +1,206 @@
+/*******************************************************************************
+ *   Copyright (c) 2009-2023 Crater Dog Technologies™.  All Rights Reserved.   *
+ *******************************************************************************
+ * DO NOT ALTER OR REMOVE COPYRIGHT NOTICES OR THIS FILE HEADER.               *
+ *                                                                             *
+ * This code is free software; you can redistribute it and/or modify it under  *
+ * the terms of The MIT License (MIT), as published by the Open Source         *
+ * Initiative. (See http://opensource.org/licenses/MIT)                        *
+ *******************************************************************************/
+
+package bali
+
+import (
+	abs "github.com/bali-nebula/go-component-framework/v2/abstractions"
+	htm "html"
+	stc "strconv"
+	sts "strings"
+)
+
+// HTML FORMATTER INTERFACE
+
+// This constructor creates a new HTML formatter using the specified
+// indentation. The HTML formatter renders the canonical BDN string for an
+// entity or component as a <pre class="bali"> element in which each token is
+// wrapped in a <span> element whose CSS class names its token type, for
+// example:
+//
+//	<span class="bali-angle">~π</span>
+//	<span class="bali-keyword">return</span>
+//
+// Each symbol that is the key of an association in a catalog is also given an
+// anchor whose identifier is the path of keys leading to it, for example
+// "bali-customer-address" for the $address attribute of the $customer
+// attribute, so that nested attributes can be linked to directly. The path
+// includes the ordinal index of each list item along the way, for example
+// "bali-orders-2-total" for the $total attribute of the second item in the
+// $orders list, so that each identifier is unique.
+//
+// The items in each collection that spans multiple lines are wrapped in an
+// open <details class="bali-collection"> element whose <summary> element is
+// the opening bracket of the collection, so that the collection can be
+// collapsed. A stylesheet should display both of these elements inline.
+func HTMLFormatter(indentation int) *htmlFormatter {
+	var v = &htmlFormatter{formatter: Formatter(indentation)}
+	return v
+}
+
+// This function returns an HTML rendering of the canonical BDN string for the
+// specified component including the POSIX standard trailing EOL.
+func FormatHTML(component abs.ComponentLike) []byte {
+	var v = HTMLFormatter(0)
+	var s = v.FormatComponent(component) + EOL
+	return []byte(s)
+}
+
+// HTML FORMATTER IMPLEMENTATION
+
+// This type defines the structure and methods for an HTML formatting agent.
+type htmlFormatter struct {
+	formatter *formatter
+}
+
+// This method returns the HTML rendering of the canonical string for the
+// specified entity.
+func (v *htmlFormatter) FormatEntity(entity abs.Entity) string {
+	return v.highlightSource(v.formatter.FormatEntity(entity))
+}
+
+// This method returns the HTML rendering of the canonical string for the
+// specified component.
+func (v *htmlFormatter) FormatComponent(component abs.ComponentLike) string {
+	return v.highlightSource(v.formatter.FormatComponent(component))
+}
+
+// PRIVATE METHODS
+
+// This type defines the structure of a bracket that encloses the tokens that
+// are being rendered.
+type enclosure struct {
+	bracket     string // The opening bracket.
+	segment     string // The key or list index of the current item, if any.
+	items       int    // The number of items seen so far.
+	atItem      bool   // Whether or not the next token begins an item.
+	collapsible bool   // Whether or not the items are wrapped in a <details>.
+}
+
+// This private method returns the HTML rendering of the specified canonical
+// source string.
+func (v *htmlFormatter) highlightSource(source string) string {
+	var tokens = v.scanTokens(source)
+	var enclosures []*enclosure // The stack of open brackets surrounding each token.
+	var result sts.Builder
+	result.WriteString(`<pre class="bali">`)
+	var next int // The byte offset of the next unrendered byte in the source.
+	for index, token := range tokens {
+		if token.Type == TokenEOF || token.Type == TokenERROR {
+			break
+		}
+		var start = token.Offset
+		if start >= len(source) {
+			break // Skip the EOL that was appended for the scanner.
+		}
+		result.WriteString(htm.EscapeString(source[next:start]))
+		next = start + len(token.Value)
+		var value = htm.EscapeString(token.Value)
+		var top *enclosure
+		if len(enclosures) > 0 {
+			top = enclosures[len(enclosures)-1]
+			if top.bracket == "[" && top.atItem && v.beginsItem(token) {
+				top.atItem = false
+				top.items++
+				top.segment = stc.Itoa(top.items)
+			}
+		}
+		switch token.Type {
+		case TokenEOL:
+			if top != nil {
+				top.atItem = true
+			}
+			result.WriteString(value)
+		case TokenDELIMITER:
+			var span = `<span class="bali-delimiter">` + value + `</span>`
+			switch token.Value {
+			case "[", "(", "{":
+				var opened = &enclosure{bracket: token.Value, atItem: true}
+				opened.collapsible = token.Value == "[" &&
+					index+1 < len(tokens) && tokens[index+1].Type == TokenEOL
+				enclosures = append(enclosures, opened)
+				if opened.collapsible {
+					span = `<details class="bali-collection" open><summary>` + span + `</summary>`
+				}
+			case "]", ")", "}":
+				if top != nil {
+					enclosures = enclosures[:len(enclosures)-1]
+					if top.collapsible {
+						span = `</details>` + span
+					}
+				}
+			case ",":
+				if top != nil {
+					top.atItem = true
+				}
+			}
+			result.WriteString(span)
+		case TokenSYMBOL:
+			var isKey = top != nil && top.bracket == "[" &&
+				index+1 < len(tokens) && tokens[index+1].Type == TokenDELIMITER &&
+				tokens[index+1].Value == ":"
+			if isKey {
+				top.segment = token.Value[1:] // Remove the leading "$".
+				var anchor = "bali-" + sts.Join(v.segments(enclosures), "-")
+				result.WriteString(`<a class="bali-symbol" id="` + anchor + `" href="#` + anchor + `">` + value + `</a>`)
+				break
+			}
+			result.WriteString(`<span class="bali-symbol">` + value + `</span>`)
+		default:
+			result.WriteString(`<span class="bali-` + sts.ToLower(string(token.Type)) + `">` + value + `</span>`)
+		}
+	}
+	if next < len(source) {
+		result.WriteString(htm.EscapeString(source[next:]))
+	}
+	result.WriteString(`</pre>`)
+	return result.String()
+}
+
+// This private method determines whether or not the specified token can begin
+// an item in a collection.
+func (v *htmlFormatter) beginsItem(token Token) bool {
+	switch token.Type {
+	case TokenEOL, TokenNOTE, TokenCOMMENT, TokenWHITESPACE:
+		return false
+	case TokenDELIMITER:
+		switch token.Value {
+		case "]", ")", "}", ",":
+			return false
+		}
+	}
+	return true
+}
+
+// This private method returns all of the tokens in the specified source string
+// up to and including the EOF or error token.
+func (v *htmlFormatter) scanTokens(source string) []Token {
+	var scanner = Scanner([]byte(source + EOL))
+	var tokens []Token
+	for {
+		var token = scanner.NextToken()
+		tokens = append(tokens, token)
+		if token.Type == TokenEOF || token.Type == TokenERROR {
+			return tokens
+		}
+	}
+}
+
+// This private method returns the path of keys and list indices leading to
+// the current item in the specified enclosures.
+func (v *htmlFormatter) segments(enclosures []*enclosure) []string {
+	var result []string
+	for _, enclosure := range enclosures {
+		if len(enclosure.segment) > 0 {
+			result = append(result, enclosure.segment)
+		}
+	}
+	return result
+}
@@ -0,0 +1,60 @@
+/*******************************************************************************
+ *   Copyright (c) 2009-2023 Crater Dog Technologies™.  All Rights Reserved.   *
+ *******************************************************************************
+ * DO NOT ALTER OR REMOVE COPYRIGHT NOTICES OR THIS FILE HEADER.               *
+ *                                                                             *
+ * This code is free software; you can redistribute it and/or modify it under  *
+ * the terms of The MIT License (MIT), as published by the Open Source         *
+ * Initiative. (See http://opensource.org/licenses/MIT)                        *
+ *******************************************************************************/
+
+package bali_test
+
+import (
+	bal "github.com/bali-nebula/go-component-framework/v2/bali"
+	ass "github.com/stretchr/testify/assert"
+	tes "testing"
+)
+
+func TestHTMLFormatter(t *tes.T) {
+	var component = bal.ParseComponent(`[
+    $customer: [
+        $name: "<Jane>"
+        $since: <2020-05-12>
+    ]
+    $angle: ~π($units: $radians)
+    $valid: true  ! A note.
+]`)
+	ass.Equal(t, `<pre class="bali"><details class="bali-collection" open><summary><span class="bali-delimiter">[</span></summary>
+    <a class="bali-symbol" id="bali-customer" href="#bali-customer">$customer</a><span class="bali-delimiter">:</span> <details class="bali-collection" open><summary><span class="bali-delimiter">[</span></summary>
+        <a class="bali-symbol" id="bali-customer-name" href="#bali-customer-name">$name</a><span class="bali-delimiter">:</span> <span class="bali-quote">&#34;&lt;Jane&gt;&#34;</span>
+        <a class="bali-symbol" id="bali-customer-since" href="#bali-customer-since">$since</a><span class="bali-delimiter">:</span> <span class="bali-moment">&lt;2020-05-12&gt;</span>
+    </details><span class="bali-delimiter">]</span>
+    <a class="bali-symbol" id="bali-angle" href="#bali-angle">$angle</a><span class="bali-delimiter">:</span> <span class="bali-angle">~π</span><span class="bali-delimiter">(</span><span class="bali-symbol">$units</span><span class="bali-delimiter">:</span> <span class="bali-symbol">$radians</span><span class="bali-delimiter">)</span>
+    <a class="bali-symbol" id="bali-valid" href="#bali-valid">$valid</a><span class="bali-delimiter">:</span> <span class="bali-boolean">true</span>  <span class="bali-note">! A note.</span>
+</details><span class="bali-delimiter">]</span></pre>
+`, string(bal.FormatHTML(component)))
+}
+
+func TestHTMLListAnchors(t *tes.T) {
+	var component = bal.ParseComponent(`[$orders: [[$total: 5], [$total: 7]]]`)
+	ass.Equal(t, `<pre class="bali"><span class="bali-delimiter">[</span><a class="bali-symbol" id="bali-orders" href="#bali-orders">$orders</a><span class="bali-delimiter">:</span> <details class="bali-collection" open><summary><span class="bali-delimiter">[</span></summary>
+    <span class="bali-delimiter">[</span><a class="bali-symbol" id="bali-orders-1-total" href="#bali-orders-1-total">$total</a><span class="bali-delimiter">:</span> <span class="bali-number">5</span><span class="bali-delimiter">]</span>
+    <span class="bali-delimiter">[</span><a class="bali-symbol" id="bali-orders-2-total" href="#bali-orders-2-total">$total</a><span class="bali-delimiter">:</span> <span class="bali-number">7</span><span class="bali-delimiter">]</span>
+</details><span class="bali-delimiter">]</span><span class="bali-delimiter">]</span></pre>
+`, string(bal.FormatHTML(component)))
+}
+
+func TestHTMLProcedures(t *tes.T) {
+	var procedure = bal.ParseEntity(`{
+    if $x < 5 do {
+        return none
+    }
+}`)
+	var formatter = bal.HTMLFormatter(0)
+	ass.Equal(t, `<pre class="bali"><span class="bali-delimiter">{</span>
+    <span class="bali-keyword">if</span> <span class="bali-symbol">$x</span> <span class="bali-delimiter">&lt;</span> <span class="bali-number">5</span> <span class="bali-keyword">do</span> <span class="bali-delimiter">{</span>
+        <span class="bali-keyword">return</span> <span class="bali-pattern">none</span>
+    <span class="bali-delimiter">}</span>
+<span class="bali-delimiter">}</span></pre>`, formatter.FormatEntity(procedure))
+}
@@ -0,0 +1,491 @@
+/*******************************************************************************
+ *   Copyright (c) 2009-2023 Crater Dog Technologies™.  All Rights Reserved.   *
+ *******************************************************************************
+ * DO NOT ALTER OR REMOVE COPYRIGHT NOTICES OR THIS FILE HEADER.               *
+ *                                                                             *
+ * This code is free software; you can redistribute it and/or modify it under  *
+ * the terms of The MIT License (MIT), as published by the Open Source         *
+ * Initiative. (See http://opensource.org/licenses/MIT)                        *
+ *******************************************************************************/
+
+package bali
+
+import (
+	byt "bytes"
+	jsn "encoding/json"
+	fmt "fmt"
+	abs "github.com/bali-nebula/go-component-framework/v2/abstractions"
+	col "github.com/bali-nebula/go-component-framework/v2/collections"
+	com "github.com/bali-nebula/go-component-framework/v2/components"
+	uti "github.com/bali-nebula/go-component-framework/v2/utilities"
+	mat "math"
+	stc "strconv"
+	sts "strings"
+)
+
+// JSON INTERFACE
+
+// This function returns an idiomatic JSON document for the specified component
+// that is meant for consumers that do not understand Bali Document Notation™.
+// A catalog becomes an object whose keys are the identifiers of its symbols, a
+// list becomes an array, a boolean becomes true or false, a real number becomes
+// a JSON number, a quote or narrative becomes a string of its text and the
+// none pattern becomes null. Any other entity becomes a string containing its
+// canonical Bali source form. The contexts and notes of the components are
+// not included.
+func FormatJSON(component abs.ComponentLike) []byte {
+	var v = &jsonEncoder{lossless: false}
+	v.encodeComponent(component)
+	return v.getResult()
+}
+
+// This function returns a lossless JSON document for the specified component.
+// Each component becomes an object of the form:
+//
+//	{
+//	    "type": "catalog",
+//	    "value": [{"key": <component>, "value": <component>}, ...],
+//	    "parameters": [{"key": "$type", "value": <component>}, ...],
+//	    "note": "! ..."
+//	}
+//
+// where the type names the kind of entity, the value of a catalog is an array
+// of its associations, the value of a list is an array of its components and
+// the value of any other entity is a string containing its canonical Bali
+// source form. The parameters and note are only included when the component
+// has them. The ParseLosslessJSON() function restores the original component.
+func FormatLosslessJSON(component abs.ComponentLike) []byte {
+	var v = &jsonEncoder{lossless: true}
+	v.encodeComponent(component)
+	return v.getResult()
+}
+
+// This function parses the specified idiomatic JSON document and returns the
+// corresponding component. An object becomes a catalog whose keys are symbols
+// (or quotes if they are not valid identifiers), an array becomes a list, true
+// and false become booleans, a number becomes a number, a string becomes a
+// quote and null becomes the none pattern.
+func ParseJSON(document []byte) abs.ComponentLike {
+	var v = &jsonDecoder{lossless: false}
+	return v.decodeComponent(v.readDocument(document))
+}
+
+// This function parses the specified lossless JSON document, as produced by
+// the FormatLosslessJSON() function, and returns the corresponding component.
+func ParseLosslessJSON(document []byte) abs.ComponentLike {
+	var v = &jsonDecoder{lossless: true}
+	return v.decodeComponent(v.readDocument(document))
+}
+
+// JSON ENCODER IMPLEMENTATION
+
+// This type defines the structure and methods for a JSON encoding agent.
+type jsonEncoder struct {
+	lossless bool
+	result   sts.Builder
+}
+
+// This private method returns the indented JSON document that was encoded
+// including the POSIX standard trailing EOL.
+func (v *jsonEncoder) getResult() []byte {
+	var buffer byt.Buffer
+	var err = jsn.Indent(&buffer, []byte(v.result.String()), "", "    ")
+	if err != nil {
+		var message = fmt.Sprintf("The JSON encoder produced an invalid document: %v", err)
+		panic(message)
+	}
+	buffer.WriteString(EOL)
+	return buffer.Bytes()
+}
+
+// This private method encodes the specified component.
+func (v *jsonEncoder) encodeComponent(component abs.ComponentLike) {
+	if !v.lossless {
+		v.encodeEntity(component.GetEntity())
+		return
+	}
+	var context = adjustContext(component)
+	v.result.WriteString(`{"type":`)
+	v.encodeString(entityType(component.GetEntity()))
+	v.result.WriteString(`,"value":`)
+	v.encodeEntity(component.GetEntity())
+	if component.IsParameterized() {
+		v.result.WriteString(`,"parameters":[`)
+		var iterator = com.ParameterIterator(context)
+		for iterator.HasNext() {
+			var parameter = iterator.GetNext()
+			v.result.WriteString(`{"key":`)
+			v.encodeString(FormatEntity(parameter.GetKey()))
+			v.result.WriteString(`,"value":`)
+			v.encodeComponent(parameter.GetValue())
+			v.result.WriteString(`}`)
+			if iterator.HasNext() {
+				v.result.WriteString(`,`)
+			}
+		}
+		v.result.WriteString(`]`)
+	}
+	if component.IsAnnotated() {
+		v.result.WriteString(`,"note":`)
+		v.encodeString("! " + string(component.GetNote().AsArray()))
+	}
+	v.result.WriteString(`}`)
+}
+
+// This private method encodes the specified entity.
+func (v *jsonEncoder) encodeEntity(entity abs.Entity) {
+	switch value := entity.(type) {
+	case abs.AssociationsLike:
+		v.encodeAssociations(value)
+	case abs.ValuesLike:
+		v.encodeValues(value)
+	default:
+		if v.lossless {
+			v.encodeString(FormatEntity(value))
+			return
+		}
+		v.encodePlain(value)
+	}
+}
+
+// This private method encodes the specified associations as an object, or as
+// an array of key and value pairs if the encoding is lossless.
+func (v *jsonEncoder) encodeAssociations(associations abs.AssociationsLike) {
+	if v.lossless {
+		v.result.WriteString(`[`)
+	} else {
+		v.result.WriteString(`{`)
+	}
+	var iterator = col.AssociationIterator(associations)
+	for iterator.HasNext() {
+		var association = iterator.GetNext()
+		if v.lossless {
+			v.result.WriteString(`{"key":`)
+			v.encodeComponent(com.Component(association.GetKey()))
+			v.result.WriteString(`,"value":`)
+			v.encodeComponent(association.GetValue())
+			v.result.WriteString(`}`)
+		} else {
+			v.encodeString(formatKey(association.GetKey()))
+			v.result.WriteString(`:`)
+			v.encodeComponent(association.GetValue())
+		}
+		if iterator.HasNext() {
+			v.result.WriteString(`,`)
+		}
+	}
+	if v.lossless {
+		v.result.WriteString(`]`)
+	} else {
+		v.result.WriteString(`}`)
+	}
+}
+
+// This private method encodes the specified values as an array.
+func (v *jsonEncoder) encodeValues(values abs.ValuesLike) {
+	v.result.WriteString(`[`)
+	var iterator = com.ComponentIterator(values)
+	for iterator.HasNext() {
+		v.encodeComponent(iterator.GetNext())
+		if iterator.HasNext() {
+			v.result.WriteString(`,`)
+		}
+	}
+	v.result.WriteString(`]`)
+}
+
+// This private method encodes the specified entity as an idiomatic JSON value.
+func (v *jsonEncoder) encodePlain(entity abs.Entity) {
+	switch value := entity.(type) {
+	case abs.NarrativeLike:
+		v.encodeString(value.AsString())
+	case abs.QuoteLike:
+		v.encodeString(value.AsString())
+	case abs.DurationLike, abs.MomentLike:
+		v.encodeString(FormatEntity(value))
+	case abs.NumberLike:
+		var real_ = value.GetReal()
+		if value.GetImaginary() != 0 || mat.IsNaN(real_) || mat.IsInf(real_, 0) {
+			v.encodeString(FormatEntity(value))
+			return
+		}
+		v.result.WriteString(stc.FormatFloat(real_, 'g', -1, 64))
+	case abs.BooleanLike:
+		v.result.WriteString(value.AsString())
+	case abs.PatternLike:
+		if value.AsString() == "none" {
+			v.result.WriteString(`null`)
+			return
+		}
+		v.encodeString(FormatEntity(value))
+	default:
+		v.encodeString(FormatEntity(value))
+	}
+}
+
+// This private method encodes the specified string as a JSON string.
+func (v *jsonEncoder) encodeString(string_ string) {
+	var buffer byt.Buffer
+	var encoder = jsn.NewEncoder(&buffer)
+	encoder.SetEscapeHTML(false)
+	var err = encoder.Encode(string_)
+	if err != nil {
+		var message = fmt.Sprintf("The JSON encoder could not encode the string: %v", err)
+		panic(message)
+	}
+	v.result.WriteString(sts.TrimSuffix(buffer.String(), "\n"))
+}
+
+// JSON DECODER IMPLEMENTATION
+
+// This type defines the structure and methods for a JSON decoding agent.
+type jsonDecoder struct {
+	lossless bool
+	decoder  *jsn.Decoder
+}
+
+// This type defines the structure of a JSON object that preserves the order of
+// its members.
+type jsonObject []jsonMember
+
+// This type defines the structure of a member of a JSON object.
+type jsonMember struct {
+	key   string
+	value any
+}
+
+// This private method returns the value of the named member of the specified
+// JSON object, or nil if the object does not have that member.
+func (v jsonObject) getValue(key string) any {
+	for _, member := range v {
+		if member.key == key {
+			return member.value
+		}
+	}
+	return nil
+}
+
+// This private method reads the specified JSON document into a tree of JSON
+// values.
+func (v *jsonDecoder) readDocument(document []byte) any {
+	v.decoder = jsn.NewDecoder(byt.NewReader(document))
+	v.decoder.UseNumber()
+	var value = v.readValue()
+	if v.decoder.More() {
+		panic("The JSON document contains more than one value.")
+	}
+	return value
+}
+
+// This private method reads the next JSON value.
+func (v *jsonDecoder) readValue() any {
+	var token, err = v.decoder.Token()
+	if err != nil {
+		var message = fmt.Sprintf("The JSON document is invalid: %v", err)
+		panic(message)
+	}
+	switch token {
+	case jsn.Delim('{'):
+		var object = jsonObject{}
+		for v.decoder.More() {
+			var key = v.readValue()
+			var value = v.readValue()
+			object = append(object, jsonMember{key: key.(string), value: value})
+		}
+		v.decoder.Token() // Consume the closing '}' delimiter.
+		return object
+	case jsn.Delim('['):
+		var array = []any{}
+		for v.decoder.More() {
+			array = append(array, v.readValue())
+		}
+		v.decoder.Token() // Consume the closing ']' delimiter.
+		return array
+	default:
+		return token
+	}
+}
+
+// This private method decodes the specified JSON value into a component.
+func (v *jsonDecoder) decodeComponent(value any) abs.ComponentLike {
+	if v.lossless {
+		return v.decodeLossless(value)
+	}
+	switch actual := value.(type) {
+	case jsonObject:
+		var catalog = col.Catalog()
+		for _, member := range actual {
+			catalog.SetValue(parseKey(member.key), v.decodeComponent(member.value))
+		}
+		return com.Component(catalog)
+	case []any:
+		var list = col.List()
+		for _, item := range actual {
+			list.AddValue(v.decodeComponent(item))
+		}
+		return com.Component(list)
+	case string:
+		return com.Component(Quote(actual))
+	case jsn.Number:
+		var real_, err = actual.Float64()
+		if err != nil {
+			var message = fmt.Sprintf("The JSON number is invalid: %v", actual)
+			panic(message)
+		}
+		return com.Component(Number(real_))
+	case bool:
+		return com.Component(Boolean(actual))
+	default:
+		return com.Component(Pattern("none"))
+	}
+}
+
+// This private method decodes the specified lossless JSON value into a
+// component.
+func (v *jsonDecoder) decodeLossless(value any) abs.ComponentLike {
+	var object, ok = value.(jsonObject)
+	if !ok {
+		var message = fmt.Sprintf("A lossless JSON component must be an object: %v", value)
+		panic(message)
+	}
+	var type_, _ = object.getValue("type").(string)
+	var entity abs.Entity
+	switch type_ {
+	case "catalog":
+		var catalog = col.Catalog()
+		for _, item := range v.getArray(object) {
+			var association, _ = item.(jsonObject)
+			var key = v.decodeLossless(association.getValue("key")).GetEntity()
+			catalog.SetValue(key, v.decodeLossless(association.getValue("value")))
+		}
+		entity = catalog
+	case "list":
+		var list = col.List()
+		for _, item := range v.getArray(object) {
+			list.AddValue(v.decodeLossless(item))
+		}
+		entity = list
+	default:
+		var source, ok = object.getValue("value").(string)
+		if !ok {
+			var message = fmt.Sprintf("The value of a lossless JSON %v must be a string.", type_)
+			panic(message)
+		}
+		entity = ParseEntity(source)
+		if entityType(entity) != type_ {
+			var message = fmt.Sprintf("The lossless JSON value is not of type %v: %v", type_, source)
+			panic(message)
+		}
+	}
+	var component = com.Component(entity)
+	if parameters, ok := object.getValue("parameters").([]any); ok {
+		var context = com.Context()
+		for _, item := range parameters {
+			var parameter, _ = item.(jsonObject)
+			var key, _ = parameter.getValue("key").(string)
+			context.SetValue(Symbol(sts.TrimPrefix(key, "$")), v.decodeLossless(parameter.getValue("value")))
+		}
+		component.SetContext(context)
+	}
+	if note, ok := object.getValue("note").(string); ok {
+		component.SetNote(com.Note(sts.TrimPrefix(note, "! ")))
+	}
+	return component
+}
+
+// This private method returns the array that is the value of the specified
+// lossless JSON collection.
+func (v *jsonDecoder) getArray(object jsonObject) []any {
+	var array, ok = object.getValue("value").([]any)
+	if !ok {
+		var message = fmt.Sprintf("The value of a lossless JSON %v must be an array.", object.getValue("type"))
+		panic(message)
+	}
+	return array
+}
+
+// PRIVATE FUNCTIONS
+
+// This private function returns the string form of the specified catalog key.
+// The string form of a symbol is its identifier, the string form of a quote is
+// its text and the string form of any other primitive is its canonical source.
+func formatKey(key abs.Primitive) string {
+	switch value := key.(type) {
+	case abs.SymbolLike:
+		return value.AsString()
+	case abs.QuoteLike:
+		return value.AsString()
+	default:
+		return FormatEntity(value)
+	}
+}
+
+// This private function returns the catalog key for the specified string form.
+// The catalog key is a symbol if the string is a valid identifier, otherwise it
+// is a quote.
+func parseKey(key string) abs.Primitive {
+	var matches = uti.SymbolMatcher.FindStringSubmatch("$" + key)
+	if len(matches) > 0 && matches[0] == "$"+key {
+		return Symbol(key)
+	}
+	return Quote(key)
+}
+
+// This private function returns the name of the type of the specified entity
+// that is used by the lossless JSON and XML encodings.
+func entityType(entity abs.Entity) string {
+	switch entity.(type) {
+	// The order of these cases must match the order used by the formatter.
+	case abs.BinaryLike:
+		return "binary"
+	case abs.BytecodeLike:
+		return "bytecode"
+	case abs.NameLike:
+		return "name"
+	case abs.NarrativeLike:
+		return "narrative"
+	case abs.QuoteLike:
+		return "quote"
+	case abs.VersionLike:
+		return "version"
+	case abs.DurationLike:
+		return "duration"
+	case abs.MomentLike:
+		return "moment"
+	case abs.NumberLike:
+		return "number"
+	case abs.PercentageLike:
+		return "percentage"
+	case abs.ProbabilityLike:
+		return "probability"
+	case abs.AngleLike:
+		return "angle"
+	case abs.BooleanLike:
+		return "boolean"
+	case abs.PatternLike:
+		return "pattern"
+	case abs.CitationLike:
+		return "citation"
+	case abs.ResourceLike:
+		return "resource"
+	case abs.TagLike:
+		return "tag"
+	case abs.SymbolLike:
+		return "symbol"
+	case abs.ValuesLike:
+		return "list"
+	case abs.AssociationsLike:
+		return "catalog"
+	case abs.IntervalLike:
+		return "interval"
+	case abs.SpectrumLike:
+		return "spectrum"
+	case abs.ContinuumLike:
+		return "continuum"
+	case abs.ProcedureLike:
+		return "procedure"
+	default:
+		var message = fmt.Sprintf("An invalid entity (of type %T) was passed to the encoder: %v", entity, entity)
+		panic(message)
+	}
+}
@@ -0,0 +1,123 @@
+/*******************************************************************************
+ *   Copyright (c) 2009-2023 Crater Dog Technologies™.  All Rights Reserved.   *
+ *******************************************************************************
+ * DO NOT ALTER OR REMOVE COPYRIGHT NOTICES OR THIS FILE HEADER.               *
+ *                                                                             *
+ * This code is free software; you can redistribute it and/or modify it under  *
+ * the terms of The MIT License (MIT), as published by the Open Source         *
+ * Initiative. (See http://opensource.org/licenses/MIT)                        *
+ *******************************************************************************/
+
+package bali_test
+
+import (
+	bal "github.com/bali-nebula/go-component-framework/v2/bali"
+	ass "github.com/stretchr/testify/assert"
+	osx "os"
+	sts "strings"
+	tes "testing"
+)
+
+func TestLosslessJSONRoundtrips(t *tes.T) {
+	var files, err = osx.ReadDir(testDirectory)
+	if err != nil {
+		panic("Could not find the ./test directory.")
+	}
+
+	for _, file := range files {
+		var filename = testDirectory + file.Name()
+		if sts.HasSuffix(filename, ".bali") {
+			var expected, _ = osx.ReadFile(filename)
+			var component = bal.ParseDocument(expected)
+			var document = bal.FormatLosslessJSON(component)
+			component = bal.ParseLosslessJSON(document)
+			ass.Equal(t, string(expected), string(bal.FormatDocument(component)), filename)
+		}
+	}
+}
+
+func TestLosslessJSON(t *tes.T) {
+	var component = bal.ParseComponent(`[$total: 5, "key": ~π($units: $radians)]  ! A note.`)
+	var document = bal.FormatLosslessJSON(component)
+	ass.Equal(t, `{
+    "type": "catalog",
+    "value": [
+        {
+            "key": {
+                "type": "symbol",
+                "value": "$total"
+            },
+            "value": {
+                "type": "number",
+                "value": "5"
+            }
+        },
+        {
+            "key": {
+                "type": "quote",
+                "value": "\"key\""
+            },
+            "value": {
+                "type": "angle",
+                "value": "~π",
+                "parameters": [
+                    {
+                        "key": "$units",
+                        "value": {
+                            "type": "symbol",
+                            "value": "$radians"
+                        }
+                    }
+                ]
+            }
+        }
+    ],
+    "note": "! A note."
+}
+`, string(document))
+	ass.Equal(t, bal.FormatComponent(component), bal.FormatComponent(bal.ParseLosslessJSON(document)))
+
+	defer func() {
+		if e := recover(); e != nil {
+			ass.Equal(t, "The lossless JSON value is not of type moment: 5", e)
+		} else {
+			ass.Fail(t, "Test should result in recovered panic.")
+		}
+	}()
+	bal.ParseLosslessJSON([]byte(`{"type": "moment", "value": "5"}`)) // This should panic.
+}
+
+func TestPlainJSON(t *tes.T) {
+	var component = bal.ParseComponent(`[
+    $name: "Widget"
+    $price: 2.5
+    $available: true
+    $discount: none
+    $tags: ["a", "b"]
+    $made: <2023-05-12>
+    $notes: [:]
+]($type: /acme/types/Product/v1)`)
+	var document = bal.FormatJSON(component)
+	ass.Equal(t, `{
+    "name": "Widget",
+    "price": 2.5,
+    "available": true,
+    "discount": null,
+    "tags": [
+        "a",
+        "b"
+    ],
+    "made": "<2023-05-12>",
+    "notes": {}
+}
+`, string(document))
+
+	component = bal.ParseJSON([]byte(`{"name": "Widget", "price": 2.5, "in stock": false, "discount": null, "tags": ["a"]}`))
+	ass.Equal(t, `[
+    $name: "Widget"
+    $price: 2.5
+    "in stock": false
+    $discount: none
+    $tags: ["a"]
+]`, bal.FormatComponent(component))
+}
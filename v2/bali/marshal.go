@@ -0,0 +1,598 @@
+/*******************************************************************************
+ *   Copyright (c) 2009-2023 Crater Dog Technologies™.  All Rights Reserved.   *
+ *******************************************************************************
+ * DO NOT ALTER OR REMOVE COPYRIGHT NOTICES OR THIS FILE HEADER.               *
+ *                                                                             *
+ * This code is free software; you can redistribute it and/or modify it under  *
+ * the terms of The MIT License (MIT), as published by the Open Source         *
+ * Initiative. (See http://opensource.org/licenses/MIT)                        *
+ *******************************************************************************/
+
+package bali
+
+import (
+	fmt "fmt"
+	abs "github.com/bali-nebula/go-component-framework/v2/abstractions"
+	col "github.com/bali-nebula/go-component-framework/v2/collections"
+	com "github.com/bali-nebula/go-component-framework/v2/components"
+	mat "math"
+	uri "net/url"
+	ref "reflect"
+	sor "sort"
+	stc "strconv"
+	sts "strings"
+	tim "time"
+)
+
+// MARSHALLING INTERFACE
+
+// This function returns a component for the specified Go value. The Go types
+// are mapped to Bali types as follows:
+//
+//	bool                            -> boolean
+//	int, uint, float and complex    -> number
+//	string                          -> quote
+//	time.Time                       -> moment
+//	time.Duration                   -> duration
+//	[]byte                          -> binary
+//	url.URL and *url.URL            -> resource
+//	slices and arrays               -> list
+//	maps and structs                -> catalog
+//	nil pointers, slices and maps   -> none
+//
+// Components and Bali entities are used as is. Each exported field of a struct
+// becomes an attribute of the catalog whose symbol is taken from the "bali" tag
+// of the field, or is the name of the field if there is no tag:
+//
+//	type Customer struct {
+//	    Name  string    `bali:"$name"`
+//	    Since time.Time `bali:"$since,omitempty"`
+//	    Notes []string  `bali:"-"`
+//	}
+//
+// A field with the "omitempty" option is left out when it has its zero value
+// and a field whose tag is "-" is always left out. The keys of a map with
+// string keys become symbols (or quotes if they are not valid identifiers) and
+// the associations are sorted by key. A value that refers back to itself
+// through a pointer, map or slice cannot be marshalled.
+func Marshal(value any) abs.ComponentLike {
+	return marshalValue(ref.ValueOf(value), make(map[reference]bool))
+}
+
+// This function unmarshals the specified component into the Go value that is
+// pointed to by the specified target using a lenient unmarshaller. It reverses
+// the mapping used by the Marshal() function and returns an error if the
+// component cannot be unmarshalled into the target.
+func Unmarshal(component abs.ComponentLike, target any) error {
+	var v = Unmarshaller(false)
+	return v.Unmarshal(component, target)
+}
+
+// This constructor creates a new unmarshaller that is either strict or lenient.
+// A strict unmarshaller requires that the type of each entity exactly matches
+// the Go type of its target, and that each attribute in a catalog matches a
+// field in its target struct. A lenient unmarshaller ignores attributes that do
+// not match any field and allows the following additional conversions:
+//
+//	number with a fractional part    -> truncated integer
+//	quote containing a number        -> int, uint, float and complex
+//	quote containing a boolean       -> bool
+//	any other primitive              -> string containing its Bali source
+//	list with a different size       -> truncated or zero padded array
+//
+// A number that overflows its integer target is an error in either case.
+func Unmarshaller(strict bool) *unmarshaller {
+	var v = &unmarshaller{strict: strict}
+	return v
+}
+
+// UNMARSHALLER IMPLEMENTATION
+
+// This type defines the structure and methods for an unmarshalling agent.
+type unmarshaller struct {
+	strict bool
+}
+
+// This method unmarshals the specified component into the Go value that is
+// pointed to by the specified target. It returns an error if the component
+// cannot be unmarshalled into the target.
+func (v *unmarshaller) Unmarshal(component abs.ComponentLike, target any) (err error) {
+	var value = ref.ValueOf(target)
+	if value.Kind() != ref.Pointer || value.IsNil() {
+		return fmt.Errorf("The target of the unmarshaller must be a non-nil pointer: %T", target)
+	}
+	defer func() {
+		if e := recover(); e != nil {
+			var message, ok = e.(string)
+			if !ok {
+				panic(e) // This is not an unmarshalling error.
+			}
+			err = fmt.Errorf("%s", message)
+		}
+	}()
+	v.unmarshalComponent(component, value.Elem())
+	return err
+}
+
+// PRIVATE METHODS
+
+// This private method unmarshals the specified component into the specified
+// target value.
+func (v *unmarshaller) unmarshalComponent(component abs.ComponentLike, target ref.Value) {
+	var type_ = target.Type()
+	if type_ == componentType {
+		target.Set(ref.ValueOf(component))
+		return
+	}
+	var entity = component.GetEntity()
+	if isNone(entity) {
+		target.Set(ref.Zero(type_))
+		return
+	}
+	switch type_ {
+	case timeType:
+		if entityType(entity) != "moment" {
+			v.mismatch(entity, type_)
+		}
+		var moment = entity.(abs.MomentLike)
+		var time = tim.UnixMilli(int64(moment.AsInteger())).UTC()
+		target.Set(ref.ValueOf(time))
+		return
+	case durationType:
+		if entityType(entity) != "duration" {
+			v.mismatch(entity, type_)
+		}
+		var duration = entity.(abs.DurationLike)
+		target.SetInt(int64(duration.AsInteger()) * int64(tim.Millisecond))
+		return
+	case urlType:
+		target.Set(ref.ValueOf(v.resourceValue(entity, type_)).Elem())
+		return
+	case ref.PointerTo(urlType):
+		target.Set(ref.ValueOf(v.resourceValue(entity, type_)))
+		return
+	}
+	switch type_.Kind() {
+	case ref.Interface:
+		if type_.NumMethod() == 0 {
+			target.Set(ref.ValueOf(v.naturalValue(component)))
+			return
+		}
+		if !ref.TypeOf(entity).Implements(type_) {
+			v.mismatch(entity, type_)
+		}
+		target.Set(ref.ValueOf(entity))
+	case ref.Pointer:
+		if target.IsNil() {
+			target.Set(ref.New(type_.Elem()))
+		}
+		v.unmarshalComponent(component, target.Elem())
+	case ref.Bool:
+		target.SetBool(v.booleanValue(entity, type_))
+	case ref.Int, ref.Int8, ref.Int16, ref.Int32, ref.Int64:
+		var real_ = v.integerValue(entity, type_)
+		if real_ < mat.MinInt64 || real_ >= mat.MaxInt64 || target.OverflowInt(int64(real_)) {
+			v.overflow(entity, type_)
+		}
+		target.SetInt(int64(real_))
+	case ref.Uint, ref.Uint8, ref.Uint16, ref.Uint32, ref.Uint64, ref.Uintptr:
+		var real_ = v.integerValue(entity, type_)
+		if real_ < 0 || real_ >= mat.MaxUint64 || target.OverflowUint(uint64(real_)) {
+			v.overflow(entity, type_)
+		}
+		target.SetUint(uint64(real_))
+	case ref.Float32, ref.Float64:
+		var number = v.numberValue(entity, type_)
+		if imag(number) != 0 {
+			v.mismatch(entity, type_)
+		}
+		target.SetFloat(real(number))
+	case ref.Complex64, ref.Complex128:
+		target.SetComplex(v.numberValue(entity, type_))
+	case ref.String:
+		target.SetString(v.stringValue(entity, type_))
+	case ref.Slice:
+		if entityType(entity) == "binary" && type_.Elem().Kind() == ref.Uint8 {
+			target.SetBytes(entity.(abs.BinaryLike).AsArray())
+			return
+		}
+		var values = v.valuesValue(entity, type_)
+		var slice = ref.MakeSlice(type_, values.GetSize(), values.GetSize())
+		var iterator = com.ComponentIterator(values)
+		for index := 0; iterator.HasNext(); index++ {
+			v.unmarshalComponent(iterator.GetNext(), slice.Index(index))
+		}
+		target.Set(slice)
+	case ref.Array:
+		var values = v.valuesValue(entity, type_)
+		if v.strict && values.GetSize() != type_.Len() {
+			var message = fmt.Sprintf("The list has %v values but the %v has %v elements.", values.GetSize(), type_, type_.Len())
+			panic(message)
+		}
+		var array = ref.New(type_).Elem()
+		var iterator = com.ComponentIterator(values)
+		for index := 0; iterator.HasNext() && index < type_.Len(); index++ {
+			v.unmarshalComponent(iterator.GetNext(), array.Index(index))
+		}
+		target.Set(array)
+	case ref.Map:
+		var associations = v.associationsValue(entity, type_)
+		var map_ = ref.MakeMapWithSize(type_, associations.GetSize())
+		var iterator = col.AssociationIterator(associations)
+		for iterator.HasNext() {
+			var association = iterator.GetNext()
+			var key = ref.New(type_.Key()).Elem()
+			if type_.Key().Kind() == ref.String {
+				key.SetString(formatKey(association.GetKey()))
+			} else {
+				v.unmarshalComponent(com.Component(association.GetKey()), key)
+			}
+			var value = ref.New(type_.Elem()).Elem()
+			v.unmarshalComponent(association.GetValue(), value)
+			map_.SetMapIndex(key, value)
+		}
+		target.Set(map_)
+	case ref.Struct:
+		var associations = v.associationsValue(entity, type_)
+		var fields = make(map[string]structField)
+		for _, field := range structFields(type_) {
+			fields[field.name] = field
+		}
+		var iterator = col.AssociationIterator(associations)
+		for iterator.HasNext() {
+			var association = iterator.GetNext()
+			var name = formatKey(association.GetKey())
+			var field, ok = fields[name]
+			if !ok {
+				if v.strict {
+					var message = fmt.Sprintf("The %v attribute does not match any field in a Go %v.", FormatEntity(association.GetKey()), type_)
+					panic(message)
+				}
+				continue
+			}
+			v.unmarshalComponent(association.GetValue(), target.FieldByIndex(field.index))
+		}
+	default:
+		var message = fmt.Sprintf("A component cannot be unmarshalled into a %v.", type_)
+		panic(message)
+	}
+}
+
+// This private method returns the boolean value of the specified entity.
+func (v *unmarshaller) booleanValue(entity abs.Entity, type_ ref.Type) bool {
+	switch entityType(entity) {
+	case "boolean":
+		return entity.(abs.BooleanLike).AsBoolean()
+	case "quote":
+		if !v.strict {
+			var boolean, err = stc.ParseBool(entity.(abs.QuoteLike).AsString())
+			if err == nil {
+				return boolean
+			}
+		}
+	}
+	v.mismatch(entity, type_)
+	return false
+}
+
+// This private method returns the numeric value of the specified entity.
+func (v *unmarshaller) numberValue(entity abs.Entity, type_ ref.Type) complex128 {
+	switch entityType(entity) {
+	case "number":
+		return entity.(abs.NumberLike).AsComplex()
+	case "quote":
+		if !v.strict {
+			var number, err = stc.ParseComplex(entity.(abs.QuoteLike).AsString(), 128)
+			if err == nil {
+				return number
+			}
+		}
+	}
+	v.mismatch(entity, type_)
+	return 0
+}
+
+// This private method returns the integral value of the specified entity as a
+// real number. A lenient unmarshaller truncates any fractional part.
+func (v *unmarshaller) integerValue(entity abs.Entity, type_ ref.Type) float64 {
+	var number = v.numberValue(entity, type_)
+	var real_ = real(number)
+	if imag(number) != 0 || mat.IsNaN(real_) || (v.strict && real_ != mat.Trunc(real_)) {
+		v.mismatch(entity, type_)
+	}
+	return mat.Trunc(real_)
+}
+
+// This private method returns the string value of the specified entity.
+func (v *unmarshaller) stringValue(entity abs.Entity, type_ ref.Type) string {
+	switch entityType(entity) {
+	case "quote":
+		return entity.(abs.QuoteLike).AsString()
+	case "narrative":
+		return entity.(abs.NarrativeLike).AsString()
+	case "catalog", "list":
+		v.mismatch(entity, type_)
+	}
+	if v.strict {
+		v.mismatch(entity, type_)
+	}
+	return FormatEntity(entity)
+}
+
+// This private method returns the URL for the specified resource entity.
+func (v *unmarshaller) resourceValue(entity abs.Entity, type_ ref.Type) *uri.URL {
+	if entityType(entity) != "resource" {
+		v.mismatch(entity, type_)
+	}
+	var resource = entity.(abs.ResourceLike)
+	var url, err = uri.Parse(sts.Trim(resource.AsString(), "<>"))
+	if err != nil {
+		var message = fmt.Sprintf("The resource is not a valid URL: %v", resource.AsString())
+		panic(message)
+	}
+	return url
+}
+
+// This private method returns the specified entity as a sequence of values.
+func (v *unmarshaller) valuesValue(entity abs.Entity, type_ ref.Type) abs.ValuesLike {
+	if entityType(entity) != "list" {
+		v.mismatch(entity, type_)
+	}
+	return entity.(abs.ValuesLike)
+}
+
+// This private method returns the specified entity as a sequence of
+// associations.
+func (v *unmarshaller) associationsValue(entity abs.Entity, type_ ref.Type) abs.AssociationsLike {
+	if entityType(entity) != "catalog" {
+		v.mismatch(entity, type_)
+	}
+	return entity.(abs.AssociationsLike)
+}
+
+// This private method returns the natural Go value for the specified component
+// when the target of the unmarshalling is an empty interface. Entities that
+// have no natural Go value are returned as is.
+func (v *unmarshaller) naturalValue(component abs.ComponentLike) any {
+	var entity = component.GetEntity()
+	switch entityType(entity) {
+	case "boolean":
+		return entity.(abs.BooleanLike).AsBoolean()
+	case "number":
+		var number = entity.(abs.NumberLike)
+		if number.GetImaginary() != 0 {
+			return number.AsComplex()
+		}
+		return number.GetReal()
+	case "quote":
+		return entity.(abs.QuoteLike).AsString()
+	case "narrative":
+		return entity.(abs.NarrativeLike).AsString()
+	case "moment":
+		return tim.UnixMilli(int64(entity.(abs.MomentLike).AsInteger())).UTC()
+	case "duration":
+		return tim.Duration(entity.(abs.DurationLike).AsInteger()) * tim.Millisecond
+	case "binary":
+		return entity.(abs.BinaryLike).AsArray()
+	case "resource":
+		return v.resourceValue(entity, ref.PointerTo(urlType))
+	case "list":
+		var values = entity.(abs.ValuesLike)
+		var slice = make([]any, 0, values.GetSize())
+		var iterator = com.ComponentIterator(values)
+		for iterator.HasNext() {
+			var value any
+			v.unmarshalComponent(iterator.GetNext(), ref.ValueOf(&value).Elem())
+			slice = append(slice, value)
+		}
+		return slice
+	case "catalog":
+		var associations = entity.(abs.AssociationsLike)
+		var map_ = make(map[string]any, associations.GetSize())
+		var iterator = col.AssociationIterator(associations)
+		for iterator.HasNext() {
+			var association = iterator.GetNext()
+			var value any
+			v.unmarshalComponent(association.GetValue(), ref.ValueOf(&value).Elem())
+			map_[formatKey(association.GetKey())] = value
+		}
+		return map_
+	default:
+		return entity
+	}
+}
+
+// This private method panics with an error describing why the specified entity
+// cannot be unmarshalled into the specified Go type.
+func (v *unmarshaller) mismatch(entity abs.Entity, type_ ref.Type) {
+	var message = fmt.Sprintf("The %v value cannot be unmarshalled into a Go %v: %v", entityType(entity), type_, FormatEntity(entity))
+	panic(message)
+}
+
+// This private method panics with an error describing why the specified number
+// cannot be unmarshalled into the specified Go type.
+func (v *unmarshaller) overflow(entity abs.Entity, type_ ref.Type) {
+	var message = fmt.Sprintf("The number overflows a Go %v: %v", type_, FormatEntity(entity))
+	panic(message)
+}
+
+// PRIVATE TYPES
+
+// These are the Go types that have special mappings to Bali types.
+var (
+	componentType = ref.TypeOf((*abs.ComponentLike)(nil)).Elem()
+	durationType  = ref.TypeOf(tim.Duration(0))
+	timeType      = ref.TypeOf(tim.Time{})
+	urlType       = ref.TypeOf(uri.URL{})
+)
+
+// This type defines the structure of an exported struct field that is mapped
+// to a catalog attribute.
+type structField struct {
+	name      string // The identifier of the attribute symbol.
+	index     []int  // The index sequence of the field in its struct.
+	omitEmpty bool   // Whether or not to omit the field if it has its zero value.
+}
+
+// This type defines the structure of a reference to a pointer, map or slice
+// value that is being marshalled, so that a cycle of references can be found.
+type reference struct {
+	address uintptr  // The address that the value refers to.
+	type_   ref.Type // The type of the value.
+	length  int      // The length of the value if it is a slice.
+}
+
+// PRIVATE FUNCTIONS
+
+// This private function returns the component for the specified Go value. The
+// pointers, maps and slices that are currently being marshalled are recorded
+// in the specified map so that a value containing a cycle is rejected.
+func marshalValue(value ref.Value, visiting map[reference]bool) abs.ComponentLike {
+	switch value.Kind() {
+	case ref.Invalid:
+		return com.Component(Pattern("none"))
+	case ref.Interface, ref.Pointer, ref.Slice, ref.Map:
+		if value.IsNil() {
+			return com.Component(Pattern("none"))
+		}
+	}
+	switch actual := value.Interface().(type) {
+	case abs.ComponentLike:
+		return actual
+	case tim.Time:
+		return com.Component(Moment(actual.UnixMilli()))
+	case tim.Duration:
+		return com.Component(Duration(actual.Milliseconds()))
+	case uri.URL:
+		return com.Component(Resource(&actual))
+	case *uri.URL:
+		return com.Component(Resource(actual))
+	}
+	if isEntity(value.Interface()) {
+		return com.Component(value.Interface())
+	}
+	switch value.Kind() {
+	case ref.Pointer, ref.Map, ref.Slice:
+		var key = reference{address: value.Pointer(), type_: value.Type()}
+		if value.Kind() == ref.Slice {
+			key.length = value.Len()
+		}
+		if visiting[key] {
+			var message = fmt.Sprintf("The value (of type %v) contains a cycle and cannot be marshalled into a component.", value.Type())
+			panic(message)
+		}
+		visiting[key] = true
+		defer delete(visiting, key)
+	}
+	switch value.Kind() {
+	case ref.Interface, ref.Pointer:
+		return marshalValue(value.Elem(), visiting)
+	case ref.Bool:
+		return com.Component(Boolean(value.Bool()))
+	case ref.Int, ref.Int8, ref.Int16, ref.Int32, ref.Int64:
+		return com.Component(Number(value.Int()))
+	case ref.Uint, ref.Uint8, ref.Uint16, ref.Uint32, ref.Uint64, ref.Uintptr:
+		return com.Component(Number(value.Uint()))
+	case ref.Float32, ref.Float64:
+		return com.Component(Number(value.Float()))
+	case ref.Complex64, ref.Complex128:
+		return com.Component(Number(value.Complex()))
+	case ref.String:
+		return com.Component(Quote(value.String()))
+	case ref.Slice, ref.Array:
+		if value.Kind() == ref.Slice && value.Type().Elem().Kind() == ref.Uint8 {
+			return com.Component(Binary(value.Bytes()))
+		}
+		var list = col.List()
+		for index := 0; index < value.Len(); index++ {
+			list.AddValue(marshalValue(value.Index(index), visiting))
+		}
+		return com.Component(list)
+	case ref.Map:
+		var keys = make([]abs.Primitive, 0, value.Len())
+		var values = make(map[string]abs.ComponentLike, value.Len())
+		var iterator = value.MapRange()
+		for iterator.Next() {
+			var key abs.Primitive
+			if iterator.Key().Kind() == ref.String {
+				key = parseKey(iterator.Key().String())
+			} else {
+				key = marshalValue(iterator.Key(), visiting).GetEntity()
+			}
+			keys = append(keys, key)
+			values[FormatEntity(key)] = marshalValue(iterator.Value(), visiting)
+		}
+		sor.Slice(keys, func(i, j int) bool {
+			return FormatEntity(keys[i]) < FormatEntity(keys[j])
+		})
+		var catalog = col.Catalog()
+		for _, key := range keys {
+			catalog.SetValue(key, values[FormatEntity(key)])
+		}
+		return com.Component(catalog)
+	case ref.Struct:
+		var catalog = col.Catalog()
+		for _, field := range structFields(value.Type()) {
+			var fieldValue = value.FieldByIndex(field.index)
+			if field.omitEmpty && fieldValue.IsZero() {
+				continue
+			}
+			catalog.SetValue(Symbol(field.name), marshalValue(fieldValue, visiting))
+		}
+		return com.Component(catalog)
+	default:
+		var message = fmt.Sprintf("The value (of type %v) cannot be marshalled into a component.", value.Type())
+		panic(message)
+	}
+}
+
+// This private function returns the exported fields of the specified struct
+// type that are mapped to catalog attributes.
+func structFields(type_ ref.Type) []structField {
+	var fields []structField
+	for index := 0; index < type_.NumField(); index++ {
+		var field = type_.Field(index)
+		if !field.IsExported() {
+			continue
+		}
+		var tag = field.Tag.Get("bali")
+		if tag == "-" {
+			continue
+		}
+		var options = sts.Split(tag, ",")
+		var name = sts.TrimPrefix(options[0], "$")
+		if len(name) == 0 {
+			name = field.Name
+		}
+		var omitEmpty bool
+		for _, option := range options[1:] {
+			if option == "omitempty" {
+				omitEmpty = true
+			}
+		}
+		fields = append(fields, structField{name: name, index: field.Index, omitEmpty: omitEmpty})
+	}
+	return fields
+}
+
+// This private function determines whether or not the specified value is a
+// Bali entity.
+func isEntity(value any) bool {
+	switch value.(type) {
+	case abs.AngleLike, abs.BinaryLike, abs.BooleanLike, abs.BytecodeLike,
+		abs.CitationLike, abs.DurationLike, abs.MomentLike, abs.NameLike,
+		abs.NarrativeLike, abs.NumberLike, abs.PatternLike, abs.PercentageLike,
+		abs.ProbabilityLike, abs.QuoteLike, abs.ResourceLike, abs.SymbolLike,
+		abs.TagLike, abs.VersionLike, abs.AssociationsLike, abs.ValuesLike,
+		abs.IntervalLike, abs.SpectrumLike, abs.ContinuumLike, abs.ProcedureLike:
+		return true
+	default:
+		return false
+	}
+}
+
+// This private function determines whether or not the specified entity is the
+// none pattern.
+func isNone(entity abs.Entity) bool {
+	return entityType(entity) == "pattern" && entity.(abs.PatternLike).AsString() == "none"
+}
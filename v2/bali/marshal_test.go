@@ -0,0 +1,211 @@
+/*******************************************************************************
+ *   Copyright (c) 2009-2023 Crater Dog Technologies™.  All Rights Reserved.   *
+ *******************************************************************************
+ * DO NOT ALTER OR REMOVE COPYRIGHT NOTICES OR THIS FILE HEADER.               *
+ *                                                                             *
+ * This code is free software; you can redistribute it and/or modify it under  *
+ * the terms of The MIT License (MIT), as published by the Open Source         *
+ * Initiative. (See http://opensource.org/licenses/MIT)                        *
+ *******************************************************************************/
+
+package bali_test
+
+import (
+	abs "github.com/bali-nebula/go-component-framework/v2/abstractions"
+	bal "github.com/bali-nebula/go-component-framework/v2/bali"
+	ass "github.com/stretchr/testify/assert"
+	uri "net/url"
+	tes "testing"
+	tim "time"
+)
+
+type Address struct {
+	Street string `bali:"$street"`
+	City   string `bali:"$city"`
+}
+
+type Customer struct {
+	Name     string            `bali:"$name"`
+	Age      int               `bali:"$age"`
+	Rating   float64           `bali:"$rating"`
+	Active   bool              `bali:"$active"`
+	Since    tim.Time          `bali:"$since"`
+	Timeout  tim.Duration      `bali:"$timeout"`
+	Key      []byte            `bali:"$key"`
+	Homepage *uri.URL          `bali:"$homepage"`
+	Tags     []string          `bali:"$tags"`
+	Scores   map[string]int    `bali:"$scores"`
+	Address  *Address          `bali:"$address"`
+	Angle    abs.AngleLike     `bali:"$angle"`
+	Nickname string            `bali:"$nickname,omitempty"`
+	Secret   string            `bali:"-"`
+	Extras   map[string]any    `bali:"$extras"`
+	private  string            // This field is never marshalled.
+	Options  map[string]string // This field uses its Go name.
+}
+
+func TestMarshalRoundtrip(t *tes.T) {
+	var homepage, _ = uri.Parse("https://acme.com/customers/jane")
+	var customer = Customer{
+		Name:     "Jane",
+		Age:      42,
+		Rating:   4.5,
+		Active:   true,
+		Since:    tim.Date(2020, 5, 12, 10, 30, 0, 0, tim.UTC),
+		Timeout:  3 * tim.Hour,
+		Key:      []byte{0, 1, 2, 3, 4, 5},
+		Homepage: homepage,
+		Tags:     []string{"gold", "early"},
+		Scores:   map[string]int{"beta": 2, "alpha": 1},
+		Address:  &Address{Street: "1 Main St", City: "Springfield"},
+		Angle:    bal.Angle("~π"),
+		Secret:   "hidden",
+		Extras:   map[string]any{"level": 3.0, "vip": true},
+		private:  "private",
+	}
+	var component = bal.Marshal(customer)
+	ass.Equal(t, `[
+    $name: "Jane"
+    $age: 42
+    $rating: 4.5
+    $active: true
+    $since: <2020-05-12T10:30>
+    $timeout: ~PT3H
+    $key: '>
+        AAECAwQF
+    <'
+    $homepage: <https://acme.com/customers/jane>
+    $tags: [
+        "gold"
+        "early"
+    ]
+    $scores: [
+        $alpha: 1
+        $beta: 2
+    ]
+    $address: [
+        $street: "1 Main St"
+        $city: "Springfield"
+    ]
+    $angle: ~π
+    $extras: [
+        $level: 3
+        $vip: true
+    ]
+    $Options: none
+]`, bal.FormatComponent(component))
+
+	var decoded Customer
+	var err = bal.Unmarshaller(true).Unmarshal(component, &decoded)
+	ass.Nil(t, err)
+	customer.Secret = ""
+	customer.private = ""
+	ass.Equal(t, customer, decoded)
+}
+
+func TestUnmarshalInterfaces(t *tes.T) {
+	var component = bal.ParseComponent(`[
+    $list: [1, "two", true, none]
+    $moment: <2020-05-12>
+    $angle: ~π
+]`)
+	var value any
+	var err = bal.Unmarshal(component, &value)
+	ass.Nil(t, err)
+	var catalog = value.(map[string]any)
+	ass.Equal(t, []any{1.0, "two", true, nil}, catalog["list"])
+	ass.Equal(t, tim.Date(2020, 5, 12, 0, 0, 0, 0, tim.UTC), catalog["moment"])
+	ass.Equal(t, "~π", catalog["angle"].(abs.AngleLike).AsString())
+
+	var item abs.ComponentLike
+	err = bal.Unmarshal(component, &item)
+	ass.Nil(t, err)
+	ass.Equal(t, component, item)
+}
+
+func TestUnmarshalStrictAndLenient(t *tes.T) {
+	type Order struct {
+		Count int    `bali:"$count"`
+		Total uint8  `bali:"$total"`
+		Label string `bali:"$label"`
+		Ready bool   `bali:"$ready"`
+	}
+	var component = bal.ParseComponent(`[
+    $count: 2.75
+    $total: "12"
+    $label: v1.2
+    $ready: "true"
+    $unknown: 5
+]`)
+
+	var order Order
+	var err = bal.Unmarshal(component, &order)
+	ass.Nil(t, err)
+	ass.Equal(t, Order{Count: 2, Total: 12, Label: "v1.2", Ready: true}, order)
+
+	var strict = bal.Unmarshaller(true)
+	err = strict.Unmarshal(component, &order)
+	ass.EqualError(t, err, "The number value cannot be unmarshalled into a Go int: 2.75")
+	err = strict.Unmarshal(bal.ParseComponent(`[$total: "12"]`), &order)
+	ass.EqualError(t, err, `The quote value cannot be unmarshalled into a Go uint8: "12"`)
+	err = strict.Unmarshal(bal.ParseComponent(`[$label: v1.2]`), &order)
+	ass.EqualError(t, err, "The version value cannot be unmarshalled into a Go string: v1.2")
+	err = strict.Unmarshal(bal.ParseComponent(`[$unknown: 5]`), &order)
+	ass.EqualError(t, err, "The $unknown attribute does not match any field in a Go bali_test.Order.")
+
+	err = bal.Unmarshal(bal.ParseComponent(`[$total: 256]`), &order)
+	ass.EqualError(t, err, "The number overflows a Go uint8: 256")
+	err = bal.Unmarshal(bal.ParseComponent(`[$count: [:]]`), &order)
+	ass.EqualError(t, err, "The catalog value cannot be unmarshalled into a Go int: [:]")
+	err = bal.Unmarshal(component, order)
+	ass.EqualError(t, err, "The target of the unmarshaller must be a non-nil pointer: bali_test.Order")
+}
+
+func TestMarshalPanics(t *tes.T) {
+	defer func() {
+		if e := recover(); e != nil {
+			ass.Equal(t, "The value (of type chan int) cannot be marshalled into a component.", e)
+		} else {
+			ass.Fail(t, "Test should result in recovered panic.")
+		}
+	}()
+	bal.Marshal(make(chan int)) // This should panic.
+}
+
+type Node struct {
+	Name string `bali:"$name"`
+	Next *Node  `bali:"$next"`
+}
+
+func TestMarshalShared(t *tes.T) {
+	var shared = &Node{Name: "shared"}
+	var nodes = []*Node{{Name: "first", Next: shared}, {Name: "second", Next: shared}}
+	var component = bal.Marshal(nodes)
+	ass.Equal(t, 2, component.ExtractList().GetSize())
+}
+
+func TestMarshalCycles(t *tes.T) {
+	var node = &Node{Name: "loop"}
+	node.Next = node
+	defer func() {
+		if e := recover(); e != nil {
+			ass.Equal(t, "The value (of type *bali_test.Node) contains a cycle and cannot be marshalled into a component.", e)
+		} else {
+			ass.Fail(t, "Test should result in recovered panic.")
+		}
+	}()
+	bal.Marshal(node) // This should panic.
+}
+
+func TestMarshalMapCycles(t *tes.T) {
+	var extras = map[string]any{}
+	extras["self"] = extras
+	defer func() {
+		if e := recover(); e != nil {
+			ass.Equal(t, "The value (of type map[string]interface {}) contains a cycle and cannot be marshalled into a component.", e)
+		} else {
+			ass.Fail(t, "Test should result in recovered panic.")
+		}
+	}()
+	bal.Marshal(extras) // This should panic.
+}
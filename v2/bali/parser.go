@@ -11,10 +11,12 @@
 package bali
 
 import (
+	con "context"
 	fmt "fmt"
 	abs "github.com/bali-nebula/go-component-framework/v2/abstractions"
 	col "github.com/craterdog/go-collection-framework/v2"
 	sts "strings"
+	utf "unicode/utf8"
 )
 
 // PARSER INTERFACE
@@ -29,39 +31,11 @@ import (
 // tokens shown in UPPERCASE.
 //
 // A POSIX compliant file must end with a EOL character before the EOF marker.
+// This function panics with a colored error message if the document contains
+// a syntax error.
 func ParseDocument(document []byte) abs.ComponentLike {
-	var ok bool
-	var token *Token
-	var component abs.ComponentLike
-	var parser = Parser(document)
-	component, token, ok = parser.parseComponent()
-	if ok {
-		_, token, ok = parser.parseEOL() // Required by POSIX.
-		if !ok {
-			var message = parser.formatError(token)
-			message += generateGrammar("EOL",
-				"$source",
-				"$component")
-			panic(message)
-		}
-		_, token, ok = parser.parseEOF()
-		if !ok {
-			var message = parser.formatError(token)
-			message += generateGrammar("EOF",
-				"$source",
-				"$component")
-			panic(message)
-		}
-	} else {
-		var message = parser.formatError(token)
-		message += generateGrammar("component",
-			"$source",
-			"$component",
-			"$entity",
-			"$context")
-		panic(message)
-	}
-	return component
+	defer panicOnSyntaxError()
+	return parseDocument(document)
 }
 
 // This function parses a source string rather than the bytes from a BDN
@@ -73,116 +47,376 @@ func ParseComponent(source string) abs.ComponentLike {
 
 // This function parses an entity from a source string.
 func ParseEntity(source string) abs.Entity {
-	var ok bool
-	var token *Token
-	var entity abs.Entity
-	var parser = Parser([]byte(source + EOL))
-	entity, token, ok = parser.parseEntity()
-	if !ok {
-		var message = parser.formatError(token)
-		message += generateGrammar("entity",
-			"element",
-			"string",
-			"range",
-			"collection",
-			"procedure",
-		)
-		panic(message)
-	}
-	return entity
+	defer panicOnSyntaxError()
+	return parseEntity(source)
 }
 
 // This function parses an entity from a source string.
 func ParseContext(source string) abs.ContextLike {
-	var ok bool
-	var token *Token
-	var context abs.ContextLike
-	var parser = Parser([]byte(source))
-	context, token, ok = parser.parseContext()
-	if !ok {
-		var message = parser.formatError(token)
-		message += generateGrammar("context",
-			"$component",
-			"$entity",
-			"$context",
-			"$parameters")
-		panic(message)
+	defer panicOnSyntaxError()
+	return parseContext(source)
+}
+
+// This function parses the specified BDN document like the ParseDocument()
+// function but returns an error rather than panicking if the document is
+// invalid. Any syntax error is returned as a *SyntaxError.
+func TryParseDocument(document []byte) (component abs.ComponentLike, err error) {
+	defer recoverError(&err)
+	return parseDocument(document), err
+}
+
+// This function parses the specified BDN document like the TryParseDocument()
+// function but stops parsing and returns an error wrapping the context error if
+// the specified context is cancelled before the parsing is complete.
+func TryParseDocumentWithContext(ctx con.Context, document []byte) (component abs.ComponentLike, err error) {
+	defer recoverError(&err)
+	var parser = ParserWithContext(ctx, document)
+	return parser.parseDocument(), err
+}
+
+// This function parses the specified source string like the ParseComponent()
+// function but returns an error rather than panicking if the source string is
+// invalid. Any syntax error is returned as a *SyntaxError.
+func TryParseComponent(source string) (component abs.ComponentLike, err error) {
+	var document = []byte(source + EOL) // Append the POSIX compliant EOL character.
+	return TryParseDocument(document)
+}
+
+// This function parses the specified BDN document, which must contain a single
+// expression followed by the POSIX standard EOL character, and returns the
+// expression. It returns an error rather than panicking if the document is
+// invalid. Any syntax error is returned as a *SyntaxError.
+func TryParseExpression(document []byte) (expression abs.Expression, err error) {
+	defer recoverError(&err)
+	return parseExpression(document), err
+}
+
+// This function parses the specified source string like the ParseEntity()
+// function but returns an error rather than panicking if the source string is
+// invalid. Any syntax error is returned as a *SyntaxError.
+func TryParseEntity(source string) (entity abs.Entity, err error) {
+	defer recoverError(&err)
+	return parseEntity(source), err
+}
+
+// This function parses the specified source string like the ParseContext()
+// function but returns an error rather than panicking if the source string is
+// invalid. Any syntax error is returned as a *SyntaxError.
+func TryParseContext(source string) (context abs.ContextLike, err error) {
+	defer recoverError(&err)
+	return parseContext(source), err
+}
+
+// SYNTAX ERROR IMPLEMENTATION
+
+// This type defines the structure and methods for a syntax error that was
+// found by the parser. The expected token or rule and the grammar rules that
+// were being parsed are only known when the parser (rather than the scanner)
+// rejected the offending token.
+type SyntaxError struct {
+	Line     int               // The line number of the offending token.
+	Position int               // The position in the line of the offending token.
+	Token    Token             // The offending token.
+	Expected string            // The token or rule that was expected.
+	Rules    []string          // The names of the grammar rules being parsed.
+	Grammar  map[string]string // The definitions of the grammar rules being parsed.
+	excerpt  []string          // The source lines surrounding the offending token.
+	first    int               // The line number of the first line in the excerpt.
+}
+
+// This method returns the colored error message for this syntax error.
+func (v *SyntaxError) Error() string {
+	return v.Render(true)
+}
+
+// This method returns the error message for this syntax error including the
+// lines of source surrounding the offending token and the grammar rules that
+// were being parsed. The message only contains ANSI color escape sequences if
+// colored is true.
+func (v *SyntaxError) Render(colored bool) string {
+	var cyan, green, reset string
+	if colored {
+		cyan, green, reset = "\033[36m", "\033[32m", "\033[0m"
 	}
-	return context
+	var message = fmt.Sprintf("An unexpected token was received by the parser: %v\n", v.Token)
+	message += cyan
+	for index, line := range v.excerpt {
+		var number = v.first + index
+		message += fmt.Sprintf("%04d: ", number) + line + EOL
+		if number == v.Line {
+			message += " " + green + ">>>─"
+			message += sts.Repeat("─", v.Position)
+			message += "⌃" + cyan + "\n"
+		}
+	}
+	message += reset + "\n"
+	if len(v.Expected) > 0 {
+		message += generateGrammar(colored, v.Expected, v.Rules...)
+	}
+	return message
 }
 
 // PARSER IMPLEMENTATION
 
 // This constructor creates a new parser using the specified byte array.
 func Parser(source []byte) *parser {
-	var tokens = make(chan Token, 256)
-	ScanTokens(source, tokens) // Starts scanning in a separate go routine.
+	return ParserWithContext(con.Background(), source)
+}
+
+// This constructor creates a new parser using the specified byte array that
+// stops parsing if the specified context is cancelled. The parser pulls its
+// tokens synchronously from the scanner so no go routine is left behind when
+// parsing stops early.
+func ParserWithContext(ctx con.Context, source []byte) *parser {
 	var p = &parser{
-		source: source,
-		next:   col.StackWithCapacity[*Token](4),
-		tokens: tokens,
+		source:  source,
+		ctx:     ctx,
+		next:    col.StackWithCapacity[*consumed](4),
+		scanner: Scanner(source),
 	}
 	return p
 }
 
 // This type defines the structure and methods for the parser agent.
 type parser struct {
-	source []byte
-	next   col.StackLike[*Token] // The stack of the retrieved tokens that have been put back.
-	tokens chan Token            // The queue of unread tokens coming from the scanner.
+	source  []byte
+	ctx     con.Context              // The context that can be used to cancel the parsing.
+	next    col.StackLike[*consumed] // The stack of the retrieved tokens that have been put back.
+	scanner *scanner                 // The pull-based scanner providing the unread tokens.
+	last    *consumed                // The last token that was consumed, if any.
+	spans   map[any]abs.Span         // The spans of the located nodes when they are being recorded.
+}
+
+// This type defines the structure of a token that has been retrieved by the
+// parser. It links to the token that was consumed before it so that the last
+// consumed token is known again once the token has been put back.
+type consumed struct {
+	token    *Token
+	previous *consumed
 }
 
 // This method attempts to read the next token from the token stream and return
 // it.
 func (v *parser) nextToken() *Token {
-	var next *Token
+	var next *consumed
 	if v.next.IsEmpty() {
-		var token, ok = <-v.tokens
-		if !ok {
-			panic("The token channel terminated without an EOF or error token.")
+		if err := v.ctx.Err(); err != nil {
+			panic(err)
 		}
-		next = &token
-		if next.Type == TokenERROR {
-			var message = v.formatError(next)
-			panic(message)
+		var token = v.scanner.NextToken()
+		if token.Type == TokenERROR {
+			panic(v.syntaxError(&token, ""))
 		}
+		next = &consumed{token: &token, previous: v.last}
 	} else {
 		next = v.next.RemoveTop()
 	}
-	return next
+	v.last = next
+	return next.token
 }
 
 // This method puts back the current token onto the token stream so that it can
-// be retrieved by another parsing method.
+// be retrieved by another parsing method. The current token is the last token
+// that was consumed.
 func (v *parser) backupOne(token *Token) {
-	v.next.AddValue(token)
+	var previous *consumed
+	if v.last != nil {
+		previous = v.last.previous
+	}
+	v.next.AddValue(&consumed{token: token, previous: previous})
+	v.last = previous
+}
+
+// This method returns the location of the start of the next token in the token
+// stream without consuming it.
+func (v *parser) nextLocation() abs.Location {
+	var token = v.nextToken()
+	v.backupOne(token)
+	return abs.Location{Line: token.Line, Column: token.Position, Offset: token.Offset}
+}
+
+// This method records on the specified node, if it supports the Located
+// interface, the span of source bytes from the specified start location to the
+// end of the last token that was consumed by the parser.
+func (v *parser) locateNode(node any, start abs.Location) {
+	var located, ok = node.(abs.Located)
+	if !ok || v.last == nil {
+		return
+	}
+	var span = abs.Span{Start: start, End: tokenEnd(v.last.token)}
+	located.SetSpan(span)
+	if v.spans != nil {
+		v.spans[node] = span
+	}
 }
 
-// This method returns an error message containing the context for a parsing
-// error.
-func (v *parser) formatError(token *Token) string {
-	var message = fmt.Sprintf("An unexpected token was received by the parser: %v\n", token)
-	var line = token.Line
+// This method returns a syntax error for the specified offending token. The
+// syntax error includes the expected token or rule and the grammar rules that
+// were being parsed, if they are known.
+func (v *parser) syntaxError(token *Token, expected string, rules ...string) *SyntaxError {
 	var lines = sts.Split(string(v.source), EOL)
+	var first = token.Line - 1
+	if first < 1 {
+		first = 1
+	}
+	var last = token.Line + 1
+	if last > len(lines) {
+		last = len(lines)
+	}
+	var excerpt = lines[first-1 : last]
+	var grammar_ = make(map[string]string, len(rules))
+	for _, rule := range rules {
+		grammar_[rule] = grammar[rule]
+	}
+	var err = &SyntaxError{
+		Line:     token.Line,
+		Position: token.Position,
+		Token:    *token,
+		Expected: expected,
+		Rules:    rules,
+		Grammar:  grammar_,
+		excerpt:  excerpt,
+		first:    first,
+	}
+	return err
+}
+
+// This method parses a complete document. It panics with a syntax error if the
+// document is invalid.
+func (v *parser) parseDocument() abs.ComponentLike {
+	var ok bool
+	var token *Token
+	var component abs.ComponentLike
+	component, token, ok = v.parseComponent()
+	if !ok {
+		panic(v.syntaxError(token, "component",
+			"$source",
+			"$component",
+			"$entity",
+			"$context"))
+	}
+	_, token, ok = v.parseEOL() // Required by POSIX.
+	if !ok {
+		panic(v.syntaxError(token, "EOL",
+			"$source",
+			"$component"))
+	}
+	_, token, ok = v.parseEOF()
+	if !ok {
+		panic(v.syntaxError(token, "EOF",
+			"$source",
+			"$component"))
+	}
+	return component
+}
+
+// PRIVATE FUNCTIONS
+
+// This private function parses the specified document.
+func parseDocument(document []byte) abs.ComponentLike {
+	var parser = Parser(document)
+	return parser.parseDocument()
+}
+
+// This private function parses the expression in the specified document.
+func parseExpression(document []byte) abs.Expression {
+	var parser = Parser(document)
+	var expression, token, ok = parser.parseExpression()
+	if !ok {
+		panic(parser.syntaxError(token, "expression",
+			"$expression"))
+	}
+	_, token, ok = parser.parseEOL() // Required by POSIX.
+	if !ok {
+		panic(parser.syntaxError(token, "EOL",
+			"$expression"))
+	}
+	_, token, ok = parser.parseEOF()
+	if !ok {
+		panic(parser.syntaxError(token, "EOF",
+			"$expression"))
+	}
+	return expression
+}
+
+// This private function parses an entity from the specified source string.
+func parseEntity(source string) abs.Entity {
+	var parser = Parser([]byte(source + EOL))
+	var entity, token, ok = parser.parseEntity()
+	if !ok {
+		panic(parser.syntaxError(token, "entity",
+			"$entity",
+			"$element",
+			"$string",
+			"$range",
+			"$collection",
+			"$procedure"))
+	}
+	return entity
+}
+
+// This private function parses a context from the specified source string.
+func parseContext(source string) abs.ContextLike {
+	var parser = Parser([]byte(source))
+	var context, token, ok = parser.parseContext()
+	if !ok {
+		panic(parser.syntaxError(token, "context",
+			"$component",
+			"$entity",
+			"$context",
+			"$parameters"))
+	}
+	return context
+}
 
-	message += "\033[36m"
-	if line > 1 {
-		message += fmt.Sprintf("%04d: ", line-1) + string(lines[line-2]) + EOL
+// This private function returns the location just past the last rune of the
+// specified token.
+func tokenEnd(token *Token) abs.Location {
+	var end = abs.Location{
+		Line:   token.Line + sts.Count(token.Value, EOL),
+		Column: token.Position + utf.RuneCountInString(token.Value),
+		Offset: token.Offset + len(token.Value),
+	}
+	var lastEOL = sts.LastIndex(token.Value, EOL)
+	if lastEOL >= 0 {
+		end.Column = utf.RuneCountInString(token.Value[lastEOL+1:]) + 1
 	}
-	message += fmt.Sprintf("%04d: ", line) + string(lines[line-1]) + EOL
+	return end
+}
 
-	message += " \033[32m>>>─"
-	var count = 0
-	for count < token.Position {
-		message += "─"
-		count++
+// This private function returns the start location of the specified node if
+// it supports the Located interface.
+func startLocation(node any) abs.Location {
+	var location abs.Location
+	if located, ok := node.(abs.Located); ok {
+		location = located.GetSpan().Start
 	}
-	message += "⌃\033[36m\n"
+	return location
+}
 
-	if line < len(lines) {
-		message += fmt.Sprintf("%04d: ", line+1) + string(lines[line]) + EOL
+// This private function converts a syntax error that was raised by the parser
+// back into the colored error message that is raised by the panicking parse
+// functions. Any other panic is passed through unchanged.
+func panicOnSyntaxError() {
+	if e := recover(); e != nil {
+		if err, ok := e.(*SyntaxError); ok {
+			panic(err.Error())
+		}
+		panic(e)
 	}
-	message += "\033[0m\n"
+}
 
-	return message
+// This private function recovers from any panic raised while parsing invalid
+// source and returns it as an error instead.
+func recoverError(err *error) {
+	if e := recover(); e != nil {
+		switch actual := e.(type) {
+		case *SyntaxError:
+			*err = actual
+		case error:
+			*err = fmt.Errorf("The parser could not process the source: %w", actual)
+		default:
+			*err = fmt.Errorf("%v", actual)
+		}
+	}
 }
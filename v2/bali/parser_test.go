@@ -12,7 +12,9 @@ package bali_test
 
 import (
 	fmt "fmt"
+	abs "github.com/bali-nebula/go-component-framework/v2/abstractions"
 	bal "github.com/bali-nebula/go-component-framework/v2/bali"
+	pro "github.com/bali-nebula/go-component-framework/v2/procedures"
 	ass "github.com/stretchr/testify/assert"
 	osx "os"
 	sts "strings"
@@ -39,3 +41,185 @@ func TestParsingRoundtrips(t *tes.T) {
 		}
 	}
 }
+
+func TestTryParseComponent(t *tes.T) {
+	var component, err = bal.TryParseComponent(`[
+    $a: 1
+    $b: 
+]`)
+	ass.Nil(t, component)
+	var syntaxError, ok = err.(*bal.SyntaxError)
+	ass.True(t, ok)
+	ass.Equal(t, 3, syntaxError.Line)
+	ass.Equal(t, 9, syntaxError.Position)
+	ass.Equal(t, bal.TokenEOL, syntaxError.Token.Type)
+	ass.Equal(t, "value", syntaxError.Expected)
+	ass.Equal(t, []string{"$association", "$key", "$value"}, syntaxError.Rules)
+	ass.Equal(t, `key ":" value`, syntaxError.Grammar["$association"])
+	ass.Equal(t, `An unexpected token was received by the parser: Token [type: EOL, line: 3, position: 9]: "\n"
+0002:     $a: 1
+0003:     $b: 
+ >>>──────────⌃
+0004: ]
+
+Was expecting 'value' from:
+  $association: key ":" value
+
+  $key: primitive
+
+  $value: component
+
+`, syntaxError.Render(false))
+	ass.Contains(t, syntaxError.Error(), "\033[36m0002:     $a: 1\n")
+
+	component, err = bal.TryParseComponent(`[$a: 1]`)
+	ass.Nil(t, err)
+	ass.Equal(t, `[$a: 1]`, bal.FormatComponent(component))
+
+	defer func() {
+		if e := recover(); e != nil {
+			ass.Equal(t, syntaxError.Error(), e)
+		} else {
+			ass.Fail(t, "Test should result in recovered panic.")
+		}
+	}()
+	bal.ParseComponent(`[
+    $a: 1
+    $b: 
+]`) // This should panic.
+}
+
+func TestTryParseEmptyDocument(t *tes.T) {
+	var component, err = bal.TryParseDocument([]byte{})
+	ass.Nil(t, component)
+	var syntaxError, ok = err.(*bal.SyntaxError)
+	ass.True(t, ok)
+	ass.Equal(t, bal.TokenEOF, syntaxError.Token.Type)
+	ass.Equal(t, "component", syntaxError.Expected)
+	ass.Equal(t, 1, syntaxError.Line)
+	ass.Contains(t, syntaxError.Render(false), "Was expecting 'component' from:")
+}
+
+func TestTryParseEntityAndContext(t *tes.T) {
+	var entity, err = bal.TryParseEntity(`"abc`)
+	ass.Nil(t, entity)
+	var syntaxError = err.(*bal.SyntaxError)
+	ass.Equal(t, bal.TokenERROR, syntaxError.Token.Type)
+	ass.Equal(t, "", syntaxError.Expected)
+
+	entity, err = bal.TryParseEntity(`~π`)
+	ass.Nil(t, err)
+	ass.Equal(t, `~π`, bal.FormatEntity(entity))
+
+	var context abs.ContextLike
+	context, err = bal.TryParseContext(`($a 5)`)
+	ass.Nil(t, context)
+	syntaxError = err.(*bal.SyntaxError)
+	ass.Equal(t, "context", syntaxError.Expected)
+
+	context, err = bal.TryParseContext(`($a: 5)`)
+	ass.Nil(t, err)
+	ass.Equal(t, 1, context.GetSize())
+}
+
+func TestTryParseExpression(t *tes.T) {
+	var expression, err = bal.TryParseExpression([]byte("3 * (4 + 5)\n"))
+	ass.Nil(t, err)
+	ass.Equal(t, "return 3 * (4 + 5)", bal.FormatClause(pro.ReturnClause(expression)))
+
+	expression, err = bal.TryParseExpression([]byte("1} {2\n"))
+	ass.Nil(t, expression)
+	var syntaxError = err.(*bal.SyntaxError)
+	ass.Equal(t, "EOL", syntaxError.Expected)
+	ass.Equal(t, 1, syntaxError.Line)
+	ass.Equal(t, 2, syntaxError.Position)
+}
+
+func TestSourceSpans(t *tes.T) {
+	var source = `{
+    let $count := total + 1
+    return count
+}`
+	var component = bal.ParseComponent(source)
+	ass.Equal(t, source, bal.FormatComponent(component)) // The spans are ignored.
+	ass.Equal(t, abs.Span{
+		Start: abs.Location{Line: 1, Column: 1, Offset: 0},
+		End:   abs.Location{Line: 4, Column: 2, Offset: 48},
+	}, component.(abs.Located).GetSpan())
+
+	var statements = component.ExtractProcedure().AsArray()
+	ass.Equal(t, abs.Span{
+		Start: abs.Location{Line: 2, Column: 5, Offset: 6},
+		End:   abs.Location{Line: 2, Column: 28, Offset: 29},
+	}, statements[0].(abs.Located).GetSpan())
+	ass.Equal(t, abs.Span{
+		Start: abs.Location{Line: 3, Column: 5, Offset: 34},
+		End:   abs.Location{Line: 3, Column: 17, Offset: 46},
+	}, statements[1].(abs.Located).GetSpan())
+
+	var expression = statements[0].GetMainClause().(abs.LetClauseLike).GetExpression()
+	ass.Equal(t, abs.Span{
+		Start: abs.Location{Line: 2, Column: 19, Offset: 20},
+		End:   abs.Location{Line: 2, Column: 28, Offset: 29},
+	}, expression.(abs.Located).GetSpan())
+	var first = expression.(abs.BinaryOperationLike).GetFirst()
+	ass.Equal(t, abs.Span{
+		Start: abs.Location{Line: 2, Column: 19, Offset: 20},
+		End:   abs.Location{Line: 2, Column: 24, Offset: 25},
+	}, first.(abs.Located).GetSpan())
+	ass.Equal(t, "total", source[20:25])
+
+	var constructed = bal.Component(bal.Symbol("foo"))
+	ass.False(t, constructed.(abs.Located).GetSpan().IsValid())
+}
+
+func TestNameRoundtrip(t *tes.T) {
+	var source = "/bali/types/abstractions/String"
+	var component = bal.ParseComponent(source)
+	ass.Equal(t, source, bal.FormatComponent(component))
+}
+
+func TestCitationRoundtrip(t *tes.T) {
+	var source = "/bali/types/abstractions/String/v1.2.3"
+	var tokens = make(chan bal.Token, 8)
+	bal.ScanTokens([]byte(source+"\n"), tokens)
+	var token = <-tokens
+	ass.Equal(t, bal.TokenCITATION, token.Type)
+	ass.Equal(t, source, token.Value)
+	var component = bal.ParseComponent(source)
+	ass.Equal(t, source, bal.FormatComponent(component))
+}
+
+func TestBinaryRoundtrip(t *tes.T) {
+	var source = `'>
+    abcd1234
+<'`
+	ass.Equal(t, source, bal.FormatComponent(bal.ParseComponent(source)))
+	var long = `'>
+    abcdefghijklmnopqrstuvwxyz0123456789ABCDEFGHIJKLMNOPQRSTUVWX
+    abcdefghijklmnop
+<'`
+	ass.Equal(t, long, bal.FormatComponent(bal.ParseComponent(long)))
+	var unwrapped = "'>\n    abcdefghijklmnopqrstuvwxyz0123456789ABCDEFGHIJKLMNOPQRSTUVWXabcdefghijklmnop\n<'"
+	ass.Equal(t, long, bal.FormatComponent(bal.ParseComponent(unwrapped)))
+}
+
+func TestUnequalSpellings(t *tes.T) {
+	var expected = bal.FormatComponent(bal.ParseComponent("{return a ≠ b}"))
+	ass.Equal(t, expected, bal.FormatComponent(bal.ParseComponent("{return a != b}")))
+	ass.Contains(t, expected, "a ≠ b")
+}
+
+func TestInfinitySpellings(t *tes.T) {
+	ass.Equal(t, "∞", bal.FormatComponent(bal.ParseComponent("infinity")))
+	ass.Equal(t, "[∞]", bal.FormatComponent(bal.ParseComponent("[infinity]")))
+}
+
+func TestConstantSpellings(t *tes.T) {
+	ass.Equal(t, "π", bal.FormatComponent(bal.ParseComponent("pi")))
+	ass.Equal(t, "-π", bal.FormatComponent(bal.ParseComponent("-pi")))
+	ass.Equal(t, "φ", bal.FormatComponent(bal.ParseComponent("phi")))
+	ass.Equal(t, "-φ", bal.FormatComponent(bal.ParseComponent("-phi")))
+	ass.Equal(t, "τ", bal.FormatComponent(bal.ParseComponent("tau")))
+	ass.Equal(t, "πi", bal.FormatComponent(bal.ParseComponent("pii")))
+}
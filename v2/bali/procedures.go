@@ -24,6 +24,7 @@ func (v *parser) parseAcceptClause() (abs.AcceptClauseLike, *Token, bool) {
 	var token *Token
 	var message abs.Expression
 	var clause abs.AcceptClauseLike
+	var start = v.nextLocation()
 	_, token, ok = v.parseKeyword("accept")
 	if !ok {
 		// This is not a accept clause.
@@ -31,13 +32,12 @@ func (v *parser) parseAcceptClause() (abs.AcceptClauseLike, *Token, bool) {
 	}
 	message, token, ok = v.parseExpression()
 	if !ok {
-		var message = v.formatError(token)
-		message += generateGrammar("message",
+		panic(v.syntaxError(token, "message",
 			"$acceptClause",
-			"$message")
-		panic(message)
+			"$message"))
 	}
 	clause = pro.AcceptClause(message)
+	v.locateNode(clause, start)
 	return clause, token, true
 }
 
@@ -57,6 +57,7 @@ func (v *parser) parseAttribute() (abs.AttributeLike, *Token, bool) {
 	var variable string
 	var indices abs.Sequential[abs.Expression]
 	var attribute abs.AttributeLike
+	var start = v.nextLocation()
 	variable, token, ok = v.parseIdentifier()
 	if !ok {
 		// This is not an attribute.
@@ -69,12 +70,16 @@ func (v *parser) parseAttribute() (abs.AttributeLike, *Token, bool) {
 		return attribute, token, false
 	}
 	attribute = pro.Attribute(variable, indices)
+	v.locateNode(attribute, start)
 	return attribute, token, true
 }
 
 // This method adds the canonical format for the specified attribute to the
 // state of the formatter.
 func (v *formatter) formatAttribute(attribute abs.AttributeLike) {
+	if v.preserveNode(attribute, "attribute") {
+		return
+	}
 	var variable = attribute.GetVariable()
 	v.AppendString(variable)
 	var indices = attribute.GetIndices()
@@ -89,48 +94,47 @@ func (v *parser) parseBlock() (abs.BlockLike, *Token, bool) {
 	var expression abs.Expression
 	var procedure abs.ProcedureLike
 	var block abs.BlockLike
+	var start = v.nextLocation()
 	expression, token, ok = v.parseExpression()
 	if !ok {
-		var message = v.formatError(token)
-		message += generateGrammar("expression",
+		panic(v.syntaxError(token, "expression",
 			"$ifClause",
 			"$selectClause",
 			"$withClause",
 			"$whileClause",
 			"$onClause",
-			"$expression")
-		panic(message)
+			"$expression"))
 	}
 	_, token, ok = v.parseKeyword("do")
 	if !ok {
-		var message = v.formatError(token)
-		message += generateGrammar("do",
+		panic(v.syntaxError(token, "do",
 			"$ifClause",
 			"$selectClause",
 			"$withClause",
 			"$whileClause",
-			"$onClause")
-		panic(message)
+			"$onClause"))
 	}
 	procedure, token, ok = v.parseProcedure()
 	if !ok {
-		var message = v.formatError(token)
-		message += generateGrammar("procedure",
+		panic(v.syntaxError(token, "procedure",
 			"$ifClause",
 			"$selectClause",
 			"$withClause",
 			"$whileClause",
 			"$onClause",
-			"$procedure")
-		panic(message)
+			"$procedure"))
 	}
 	block = pro.Block(expression, procedure)
+	v.locateNode(block, start)
 	return block, token, true
 }
 
 // This method adds the canonical format for the specified accept clause to the
 // state of the formatter.
 func (v *formatter) formatBlock(block abs.BlockLike) {
+	if v.preserveNode(block, "block") {
+		return
+	}
 	var expression = block.GetExpression()
 	v.formatExpression(expression)
 	v.AppendString(" do ")
@@ -144,6 +148,7 @@ func (v *parser) parseBreakClause() (abs.BreakClauseLike, *Token, bool) {
 	var ok bool
 	var token *Token
 	var clause abs.BreakClauseLike
+	var start = v.nextLocation()
 	_, token, ok = v.parseKeyword("break")
 	if !ok {
 		// This is not a break clause.
@@ -151,12 +156,11 @@ func (v *parser) parseBreakClause() (abs.BreakClauseLike, *Token, bool) {
 	}
 	_, token, ok = v.parseKeyword("loop")
 	if !ok {
-		var message = v.formatError(token)
-		message += generateGrammar("loop",
-			"$breakClause")
-		panic(message)
+		panic(v.syntaxError(token, "loop",
+			"$breakClause"))
 	}
 	clause = pro.BreakClause()
+	v.locateNode(clause, start)
 	return clause, token, true
 }
 
@@ -175,6 +179,7 @@ func (v *parser) parseCheckoutClause() (abs.CheckoutClauseLike, *Token, bool) {
 	var level abs.Expression
 	var name abs.Expression
 	var clause abs.CheckoutClauseLike
+	var start = v.nextLocation()
 	_, token, ok = v.parseKeyword("checkout")
 	if !ok {
 		// This is not a checkout clause.
@@ -182,47 +187,38 @@ func (v *parser) parseCheckoutClause() (abs.CheckoutClauseLike, *Token, bool) {
 	}
 	recipient, token, ok = v.parseRecipient()
 	if !ok {
-		var message = v.formatError(token)
-		message += generateGrammar("recipient",
+		panic(v.syntaxError(token, "recipient",
 			"$checkoutClause",
-			"$recipient")
-		panic(message)
+			"$recipient"))
 	}
 	_, _, ok = v.parseKeyword("at")
 	if ok {
 		// There is an at level part to this clause.
 		_, token, ok = v.parseKeyword("level")
 		if !ok {
-			var message = v.formatError(token)
-			message += generateGrammar("level",
-				"$checkoutClause")
-			panic(message)
+			panic(v.syntaxError(token, "level",
+				"$checkoutClause"))
 		}
 		level, token, ok = v.parseExpression()
 		if !ok {
-			var message = v.formatError(token)
-			message += generateGrammar("ordinal",
+			panic(v.syntaxError(token, "ordinal",
 				"$checkoutClause",
-				"$ordinal")
-			panic(message)
+				"$ordinal"))
 		}
 	}
 	_, token, ok = v.parseKeyword("from")
 	if !ok {
-		var message = v.formatError(token)
-		message += generateGrammar("from",
-			"$checkoutClause")
-		panic(message)
+		panic(v.syntaxError(token, "from",
+			"$checkoutClause"))
 	}
 	name, token, ok = v.parseExpression()
 	if !ok {
-		var message = v.formatError(token)
-		message += generateGrammar("name",
+		panic(v.syntaxError(token, "name",
 			"$checkoutClause",
-			"$name")
-		panic(message)
+			"$name"))
 	}
 	clause = pro.CheckoutClause(recipient, level, name)
+	v.locateNode(clause, start)
 	return clause, token, true
 }
 
@@ -248,6 +244,7 @@ func (v *parser) parseContinueClause() (abs.ContinueClauseLike, *Token, bool) {
 	var ok bool
 	var token *Token
 	var clause abs.ContinueClauseLike
+	var start = v.nextLocation()
 	_, token, ok = v.parseKeyword("continue")
 	if !ok {
 		// This is not a continue clause.
@@ -255,12 +252,11 @@ func (v *parser) parseContinueClause() (abs.ContinueClauseLike, *Token, bool) {
 	}
 	_, token, ok = v.parseKeyword("loop")
 	if !ok {
-		var message = v.formatError(token)
-		message += generateGrammar("loop",
-			"$continueClause")
-		panic(message)
+		panic(v.syntaxError(token, "loop",
+			"$continueClause"))
 	}
 	clause = pro.ContinueClause()
+	v.locateNode(clause, start)
 	return clause, token, true
 }
 
@@ -277,6 +273,7 @@ func (v *parser) parseDiscardClause() (abs.DiscardClauseLike, *Token, bool) {
 	var token *Token
 	var document abs.Expression
 	var clause abs.DiscardClauseLike
+	var start = v.nextLocation()
 	_, token, ok = v.parseKeyword("discard")
 	if !ok {
 		// This is not a discard clause.
@@ -284,13 +281,12 @@ func (v *parser) parseDiscardClause() (abs.DiscardClauseLike, *Token, bool) {
 	}
 	document, token, ok = v.parseExpression()
 	if !ok {
-		var message = v.formatError(token)
-		message += generateGrammar("document",
+		panic(v.syntaxError(token, "document",
 			"$discardClause",
-			"$document")
-		panic(message)
+			"$document"))
 	}
 	clause = pro.DiscardClause(document)
+	v.locateNode(clause, start)
 	return clause, token, true
 }
 
@@ -309,6 +305,7 @@ func (v *parser) parseIfClause() (abs.IfClauseLike, *Token, bool) {
 	var token *Token
 	var block abs.BlockLike
 	var clause abs.IfClauseLike
+	var start = v.nextLocation()
 	_, token, ok = v.parseKeyword("if")
 	if !ok {
 		// This is not an if clause.
@@ -316,13 +313,12 @@ func (v *parser) parseIfClause() (abs.IfClauseLike, *Token, bool) {
 	}
 	block, token, ok = v.parseBlock()
 	if !ok {
-		var message = v.formatError(token)
-		message += generateGrammar("condition",
+		panic(v.syntaxError(token, "condition",
 			"$ifClause",
-			"$condition")
-		panic(message)
+			"$condition"))
 	}
 	clause = pro.IfClause(block)
+	v.locateNode(clause, start)
 	return clause, token, true
 }
 
@@ -349,11 +345,9 @@ func (v *parser) parseIndices() (abs.Sequential[abs.Expression], *Token, bool) {
 	index, token, ok = v.parseExpression()
 	// There must be at least one index.
 	if !ok {
-		var message = v.formatError(token)
-		message += generateGrammar("expression",
+		panic(v.syntaxError(token, "expression",
 			"$indices",
-			"$expression")
-		panic(message)
+			"$expression"))
 	}
 	for {
 		indices.AddValue(index)
@@ -365,19 +359,15 @@ func (v *parser) parseIndices() (abs.Sequential[abs.Expression], *Token, bool) {
 		}
 		index, token, ok = v.parseExpression()
 		if !ok {
-			var message = v.formatError(token)
-			message += generateGrammar("expression",
+			panic(v.syntaxError(token, "expression",
 				"$indices",
-				"$expression")
-			panic(message)
+				"$expression"))
 		}
 	}
 	_, token, ok = v.parseDelimiter("]")
 	if !ok {
-		var message = v.formatError(token)
-		message += generateGrammar("]",
-			"$indices")
-		panic(message)
+		panic(v.syntaxError(token, "]",
+			"$indices"))
 	}
 	return indices, token, true
 }
@@ -408,13 +398,11 @@ func (v *parser) parseInlineStatements() (abs.ProcedureLike, *Token, bool) {
 	statement, token, ok = v.parseStatement()
 	if !ok {
 		// A non-empty statements must have at least one statement.
-		var message = v.formatError(token)
-		message += generateGrammar("statement",
+		panic(v.syntaxError(token, "statement",
 			"$procedure",
 			"$statements",
 			"$statement",
-		)
-		panic(message)
+		))
 	}
 	for {
 		statements.AddValue(statement)
@@ -426,13 +414,11 @@ func (v *parser) parseInlineStatements() (abs.ProcedureLike, *Token, bool) {
 		}
 		statement, token, ok = v.parseStatement()
 		if !ok {
-			var message = v.formatError(token)
-			message += generateGrammar("statement",
+			panic(v.syntaxError(token, "statement",
 				"$procedure",
 				"$statements",
 				"$statement",
-			)
-			panic(message)
+			))
 		}
 	}
 }
@@ -446,24 +432,21 @@ func (v *parser) parseLetClause() (abs.LetClauseLike, *Token, bool) {
 	var operator abs.Operator
 	var expression abs.Expression
 	var clause abs.LetClauseLike
+	var start = v.nextLocation()
 	// The recipient part is optional.
 	_, _, ok = v.parseKeyword("let")
 	if ok {
 		recipient, token, ok = v.parseRecipient()
 		if !ok {
-			var message = v.formatError(token)
-			message += generateGrammar("recipient",
+			panic(v.syntaxError(token, "recipient",
 				"$letClause",
-				"$recipient")
-			panic(message)
+				"$recipient"))
 		}
 		// The recipient requires an operator.
 		operator, token, ok = v.parseOperator()
 		if !ok || operator < abs.ASSIGN || operator > abs.QUOTIENT {
-			var message = v.formatError(token)
-			message += generateGrammar("operator",
-				"$letClause")
-			panic(message)
+			panic(v.syntaxError(token, "operator",
+				"$letClause"))
 		}
 	}
 	expression, token, ok = v.parseExpression()
@@ -472,6 +455,7 @@ func (v *parser) parseLetClause() (abs.LetClauseLike, *Token, bool) {
 		return clause, token, false
 	}
 	clause = pro.LetClauseWithRecipient(recipient, operator, expression)
+	v.locateNode(clause, start)
 	return clause, token, true
 }
 
@@ -556,6 +540,9 @@ func (v *parser) parseMainClause() (abs.Clause, *Token, bool) {
 // This method adds the canonical format for the specified main clause to the
 // state of the formatter.
 func (v *formatter) formatMainClause(mainClause abs.Clause) {
+	if v.preserveNode(mainClause, "clause") {
+		return
+	}
 	switch pro.GetType(mainClause) {
 	case "AcceptClause":
 		var value = mainClause.(abs.AcceptClauseLike)
@@ -633,13 +620,11 @@ func (v *parser) parseMultilineStatements() (abs.ProcedureLike, *Token, bool) {
 		_, token, ok = v.parseEOL()
 		if !ok {
 			if statements.IsEmpty() {
-				var message = v.formatError(token)
-				message += generateGrammar("statement",
+				panic(v.syntaxError(token, "statement",
 					"$procedure",
 					"$statements",
 					"$statement",
-				)
-				panic(message)
+				))
 			}
 			// There were no more statements in this statements.
 			return statements, token, true
@@ -656,6 +641,7 @@ func (v *parser) parseNotarizeClause() (abs.NotarizeClauseLike, *Token, bool) {
 	var document abs.Expression
 	var name abs.Expression
 	var clause abs.NotarizeClauseLike
+	var start = v.nextLocation()
 	_, token, ok = v.parseKeyword("notarize")
 	if !ok {
 		// This is not a notarize clause.
@@ -663,28 +649,23 @@ func (v *parser) parseNotarizeClause() (abs.NotarizeClauseLike, *Token, bool) {
 	}
 	document, token, ok = v.parseExpression()
 	if !ok {
-		var message = v.formatError(token)
-		message += generateGrammar("document",
+		panic(v.syntaxError(token, "document",
 			"$notarizeClause",
-			"$document")
-		panic(message)
+			"$document"))
 	}
 	_, token, ok = v.parseKeyword("as")
 	if !ok {
-		var message = v.formatError(token)
-		message += generateGrammar("as",
-			"$notarizeClause")
-		panic(message)
+		panic(v.syntaxError(token, "as",
+			"$notarizeClause"))
 	}
 	name, token, ok = v.parseExpression()
 	if !ok {
-		var message = v.formatError(token)
-		message += generateGrammar("name",
+		panic(v.syntaxError(token, "name",
 			"$notarizeClause",
-			"$name")
-		panic(message)
+			"$name"))
 	}
 	clause = pro.NotarizeClause(document, name)
+	v.locateNode(clause, start)
 	return clause, token, true
 }
 
@@ -706,6 +687,7 @@ func (v *parser) parseOnClause() (abs.OnClauseLike, *Token, bool) {
 	var token *Token
 	var failure abs.SymbolLike
 	var block abs.BlockLike
+	var start = v.nextLocation()
 	var blocks = col.List[abs.BlockLike]()
 	var clause abs.OnClauseLike
 	_, token, ok = v.parseKeyword("on")
@@ -715,11 +697,9 @@ func (v *parser) parseOnClause() (abs.OnClauseLike, *Token, bool) {
 	}
 	failure, token, ok = v.parseSymbol()
 	if !ok {
-		var message = v.formatError(token)
-		message += generateGrammar("failure",
+		panic(v.syntaxError(token, "failure",
 			"$onClause",
-			"$failure")
-		panic(message)
+			"$failure"))
 	}
 	for {
 		_, token, ok = v.parseKeyword("matching")
@@ -728,23 +708,20 @@ func (v *parser) parseOnClause() (abs.OnClauseLike, *Token, bool) {
 		}
 		block, token, ok = v.parseBlock()
 		if !ok {
-			var message = v.formatError(token)
-			message += generateGrammar("pattern",
+			panic(v.syntaxError(token, "pattern",
 				"$onClause",
-				"$pattern")
-			panic(message)
+				"$pattern"))
 		}
 		blocks.AddValue(block)
 	}
 	// There must be at least one matching block expression.
 	if blocks.IsEmpty() {
-		var message = v.formatError(token)
-		message += generateGrammar("pattern",
+		panic(v.syntaxError(token, "pattern",
 			"$onClause",
-			"$pattern")
-		panic(message)
+			"$pattern"))
 	}
 	clause = pro.OnClause(failure, blocks)
+	v.locateNode(clause, start)
 	return clause, token, true
 }
 
@@ -773,6 +750,7 @@ func (v *parser) parsePostClause() (abs.PostClauseLike, *Token, bool) {
 	var message abs.Expression
 	var bag abs.Expression
 	var clause abs.PostClauseLike
+	var start = v.nextLocation()
 	_, token, ok = v.parseKeyword("post")
 	if !ok {
 		// This is not a post clause.
@@ -780,28 +758,23 @@ func (v *parser) parsePostClause() (abs.PostClauseLike, *Token, bool) {
 	}
 	message, token, ok = v.parseExpression()
 	if !ok {
-		var message = v.formatError(token)
-		message += generateGrammar("message",
+		panic(v.syntaxError(token, "message",
 			"$postClause",
-			"$message")
-		panic(message)
+			"$message"))
 	}
 	_, token, ok = v.parseKeyword("to")
 	if !ok {
-		var message = v.formatError(token)
-		message += generateGrammar("to",
-			"$postClause")
-		panic(message)
+		panic(v.syntaxError(token, "to",
+			"$postClause"))
 	}
 	bag, token, ok = v.parseExpression()
 	if !ok {
-		var message = v.formatError(token)
-		message += generateGrammar("bag",
+		panic(v.syntaxError(token, "bag",
 			"$postClause",
-			"$bag")
-		panic(message)
+			"$bag"))
 	}
 	clause = pro.PostClause(message, bag)
+	v.locateNode(clause, start)
 	return clause, token, true
 }
 
@@ -840,23 +813,19 @@ func (v *parser) parseProcedure() (abs.ProcedureLike, *Token, bool) {
 		procedure, token, ok = v.parseInlineStatements()
 	}
 	if !ok {
-		var message = v.formatError(token)
-		message += generateGrammar("statements",
+		panic(v.syntaxError(token, "statements",
 			"$procedure",
 			"$statements",
 			"$statement",
-		)
-		panic(message)
+		))
 	}
 	_, token, ok = v.parseDelimiter("}")
 	if !ok {
-		var message = v.formatError(token)
-		message += generateGrammar("}",
+		panic(v.syntaxError(token, "}",
 			"$procedure",
 			"$statements",
 			"$statement",
-		)
-		panic(message)
+		))
 	}
 	return procedure, token, true
 }
@@ -865,11 +834,29 @@ func (v *parser) parseProcedure() (abs.ProcedureLike, *Token, bool) {
 // state of the formatter.
 func (v *formatter) formatProcedure(procedure abs.ProcedureLike) {
 	v.AppendString("{")
-	switch procedure.GetSize() {
-	case 0:
+	var iterator = col.Iterator[abs.StatementLike](procedure)
+	var size = procedure.GetSize()
+	switch {
+	case size == 0:
 		v.AppendString(" ")
+	case v.appendInline(procedure, size, v.options.InlineStatements, func(inline *formatter) bool {
+		iterator.ToStart()
+		for iterator.HasNext() {
+			var statement = iterator.GetNext()
+			if statement == nil || statement.GetAnnotation() != nil || statement.GetNote() != nil {
+				// Blank lines, annotations and notes require multiple lines.
+				return false
+			}
+			inline.formatStatement(statement)
+			if iterator.HasNext() {
+				inline.AppendString("; ")
+			}
+		}
+		return true
+	}):
+		// The statements were formatted inline.
 	default:
-		var iterator = col.Iterator[abs.StatementLike](procedure)
+		iterator.ToStart()
 		v.depth++
 		for iterator.HasNext() {
 			var statement = iterator.GetNext()
@@ -894,6 +881,7 @@ func (v *parser) parsePublishClause() (abs.PublishClauseLike, *Token, bool) {
 	var token *Token
 	var event abs.Expression
 	var clause abs.PublishClauseLike
+	var start = v.nextLocation()
 	_, token, ok = v.parseKeyword("publish")
 	if !ok {
 		// This is not a publish clause.
@@ -901,13 +889,12 @@ func (v *parser) parsePublishClause() (abs.PublishClauseLike, *Token, bool) {
 	}
 	event, token, ok = v.parseExpression()
 	if !ok {
-		var message = v.formatError(token)
-		message += generateGrammar("event",
+		panic(v.syntaxError(token, "event",
 			"$publishClause",
-			"$event")
-		panic(message)
+			"$event"))
 	}
 	clause = pro.PublishClause(event)
+	v.locateNode(clause, start)
 	return clause, token, true
 }
 
@@ -952,6 +939,7 @@ func (v *parser) parseRejectClause() (abs.RejectClauseLike, *Token, bool) {
 	var token *Token
 	var message abs.Expression
 	var clause abs.RejectClauseLike
+	var start = v.nextLocation()
 	_, token, ok = v.parseKeyword("reject")
 	if !ok {
 		// This is not a reject clause.
@@ -959,13 +947,12 @@ func (v *parser) parseRejectClause() (abs.RejectClauseLike, *Token, bool) {
 	}
 	message, token, ok = v.parseExpression()
 	if !ok {
-		var message = v.formatError(token)
-		message += generateGrammar("message",
+		panic(v.syntaxError(token, "message",
 			"$rejectClause",
-			"$message")
-		panic(message)
+			"$message"))
 	}
 	clause = pro.RejectClause(message)
+	v.locateNode(clause, start)
 	return clause, token, true
 }
 
@@ -985,6 +972,7 @@ func (v *parser) parseRetrieveClause() (abs.RetrieveClauseLike, *Token, bool) {
 	var recipient abs.Recipient
 	var bag abs.Expression
 	var clause abs.RetrieveClauseLike
+	var start = v.nextLocation()
 	_, token, ok = v.parseKeyword("retrieve")
 	if !ok {
 		// This is not a retrieve clause.
@@ -992,28 +980,23 @@ func (v *parser) parseRetrieveClause() (abs.RetrieveClauseLike, *Token, bool) {
 	}
 	recipient, token, ok = v.parseRecipient()
 	if !ok {
-		var message = v.formatError(token)
-		message += generateGrammar("recipient",
+		panic(v.syntaxError(token, "recipient",
 			"$retrieveClause",
-			"$recipient")
-		panic(message)
+			"$recipient"))
 	}
 	_, token, ok = v.parseKeyword("from")
 	if !ok {
-		var message = v.formatError(token)
-		message += generateGrammar("from",
-			"$retrieveClause")
-		panic(message)
+		panic(v.syntaxError(token, "from",
+			"$retrieveClause"))
 	}
 	bag, token, ok = v.parseExpression()
 	if !ok {
-		var message = v.formatError(token)
-		message += generateGrammar("bag",
+		panic(v.syntaxError(token, "bag",
 			"$retrieveClause",
-			"$bag")
-		panic(message)
+			"$bag"))
 	}
 	clause = pro.RetrieveClause(recipient, bag)
+	v.locateNode(clause, start)
 	return clause, token, true
 }
 
@@ -1035,6 +1018,7 @@ func (v *parser) parseReturnClause() (abs.ReturnClauseLike, *Token, bool) {
 	var token *Token
 	var result abs.Expression
 	var clause abs.ReturnClauseLike
+	var start = v.nextLocation()
 	_, token, ok = v.parseKeyword("return")
 	if !ok {
 		// This is not a return clause.
@@ -1042,13 +1026,12 @@ func (v *parser) parseReturnClause() (abs.ReturnClauseLike, *Token, bool) {
 	}
 	result, token, ok = v.parseExpression()
 	if !ok {
-		var message = v.formatError(token)
-		message += generateGrammar("result",
+		panic(v.syntaxError(token, "result",
 			"$returnClause",
-			"$result")
-		panic(message)
+			"$result"))
 	}
 	clause = pro.ReturnClause(result)
+	v.locateNode(clause, start)
 	return clause, token, true
 }
 
@@ -1068,6 +1051,7 @@ func (v *parser) parseSaveClause() (abs.SaveClauseLike, *Token, bool) {
 	var document abs.Expression
 	var recipient abs.Recipient
 	var clause abs.SaveClauseLike
+	var start = v.nextLocation()
 	_, token, ok = v.parseKeyword("save")
 	if !ok {
 		// This is not a save clause.
@@ -1075,28 +1059,23 @@ func (v *parser) parseSaveClause() (abs.SaveClauseLike, *Token, bool) {
 	}
 	document, token, ok = v.parseExpression()
 	if !ok {
-		var message = v.formatError(token)
-		message += generateGrammar("document",
+		panic(v.syntaxError(token, "document",
 			"$saveClause",
-			"$document")
-		panic(message)
+			"$document"))
 	}
 	_, token, ok = v.parseKeyword("as")
 	if !ok {
-		var message = v.formatError(token)
-		message += generateGrammar("as",
-			"$saveClause")
-		panic(message)
+		panic(v.syntaxError(token, "as",
+			"$saveClause"))
 	}
 	recipient, token, ok = v.parseRecipient()
 	if !ok {
-		var message = v.formatError(token)
-		message += generateGrammar("recipient",
+		panic(v.syntaxError(token, "recipient",
 			"$saveClause",
-			"$recipient")
-		panic(message)
+			"$recipient"))
 	}
 	clause = pro.SaveClause(document, recipient)
+	v.locateNode(clause, start)
 	return clause, token, true
 }
 
@@ -1118,6 +1097,7 @@ func (v *parser) parseSelectClause() (abs.SelectClauseLike, *Token, bool) {
 	var token *Token
 	var target abs.Expression
 	var block abs.BlockLike
+	var start = v.nextLocation()
 	var blocks = col.List[abs.BlockLike]()
 	var clause abs.SelectClauseLike
 	_, token, ok = v.parseKeyword("select")
@@ -1127,11 +1107,9 @@ func (v *parser) parseSelectClause() (abs.SelectClauseLike, *Token, bool) {
 	}
 	target, token, ok = v.parseExpression()
 	if !ok {
-		var message = v.formatError(token)
-		message += generateGrammar("target",
+		panic(v.syntaxError(token, "target",
 			"$selectClause",
-			"$target")
-		panic(message)
+			"$target"))
 	}
 	for {
 		_, token, ok = v.parseKeyword("matching")
@@ -1140,23 +1118,20 @@ func (v *parser) parseSelectClause() (abs.SelectClauseLike, *Token, bool) {
 		}
 		block, token, ok = v.parseBlock()
 		if !ok {
-			var message = v.formatError(token)
-			message += generateGrammar("pattern",
+			panic(v.syntaxError(token, "pattern",
 				"$selectClause",
-				"$pattern")
-			panic(message)
+				"$pattern"))
 		}
 		blocks.AddValue(block)
 	}
 	// There must be at least one matching block expression.
 	if blocks.IsEmpty() {
-		var message = v.formatError(token)
-		message += generateGrammar("pattern",
+		panic(v.syntaxError(token, "pattern",
 			"$selectClause",
-			"$pattern")
-		panic(message)
+			"$pattern"))
 	}
 	clause = pro.SelectClause(target, blocks)
+	v.locateNode(clause, start)
 	return clause, token, true
 }
 
@@ -1185,14 +1160,13 @@ func (v *parser) parseStatement() (abs.StatementLike, *Token, bool) {
 	var onClause abs.OnClauseLike
 	var annotation abs.Annotation
 	var note abs.NoteLike
+	var start = v.nextLocation()
 	annotation, _, ok = v.parseAnnotation() // This is optional.
 	if ok {
 		_, token, ok = v.parseEOL()
 		if !ok {
-			var message = v.formatError(token)
-			message += generateGrammar("EOL",
-				"$statement")
-			panic(message)
+			panic(v.syntaxError(token, "EOL",
+				"$statement"))
 		}
 	}
 	mainClause, token, ok = v.parseMainClause()
@@ -1203,6 +1177,7 @@ func (v *parser) parseStatement() (abs.StatementLike, *Token, bool) {
 	onClause, _, _ = v.parseOnClause() // This is optional.
 	note, token, _ = v.parseNote()     // This is optional.
 	statement = pro.StatementWithHandler(mainClause, onClause)
+	v.locateNode(statement, start)
 	statement.SetAnnotation(annotation)
 	statement.SetNote(note)
 	return statement, token, true
@@ -1211,6 +1186,9 @@ func (v *parser) parseStatement() (abs.StatementLike, *Token, bool) {
 // This method adds the canonical format for the specified statement to the
 // state of the formatter.
 func (v *formatter) formatStatement(statement abs.StatementLike) {
+	if v.preserveNode(statement, "statement") {
+		return
+	}
 	var annotation = statement.GetAnnotation()
 	if annotation != nil {
 		v.formatAnnotation(annotation)
@@ -1235,6 +1213,7 @@ func (v *parser) parseThrowClause() (abs.ThrowClauseLike, *Token, bool) {
 	var token *Token
 	var exception abs.Expression
 	var clause abs.ThrowClauseLike
+	var start = v.nextLocation()
 	_, token, ok = v.parseKeyword("throw")
 	if !ok {
 		// This is not a throw clause.
@@ -1242,13 +1221,12 @@ func (v *parser) parseThrowClause() (abs.ThrowClauseLike, *Token, bool) {
 	}
 	exception, token, ok = v.parseExpression()
 	if !ok {
-		var message = v.formatError(token)
-		message += generateGrammar("exception",
+		panic(v.syntaxError(token, "exception",
 			"$throwClause",
-			"$exception")
-		panic(message)
+			"$exception"))
 	}
 	clause = pro.ThrowClause(exception)
+	v.locateNode(clause, start)
 	return clause, token, true
 }
 
@@ -1267,6 +1245,7 @@ func (v *parser) parseWhileClause() (abs.WhileClauseLike, *Token, bool) {
 	var token *Token
 	var block abs.BlockLike
 	var clause abs.WhileClauseLike
+	var start = v.nextLocation()
 	_, token, ok = v.parseKeyword("while")
 	if !ok {
 		// This is not a while clause.
@@ -1274,13 +1253,12 @@ func (v *parser) parseWhileClause() (abs.WhileClauseLike, *Token, bool) {
 	}
 	block, token, ok = v.parseBlock()
 	if !ok {
-		var message = v.formatError(token)
-		message += generateGrammar("condition",
+		panic(v.syntaxError(token, "condition",
 			"$whileClause",
-			"$condition")
-		panic(message)
+			"$condition"))
 	}
 	clause = pro.WhileClause(block)
+	v.locateNode(clause, start)
 	return clause, token, true
 }
 
@@ -1300,6 +1278,7 @@ func (v *parser) parseWithClause() (abs.WithClauseLike, *Token, bool) {
 	var item abs.SymbolLike
 	var block abs.BlockLike
 	var clause abs.WithClauseLike
+	var start = v.nextLocation()
 	_, token, ok = v.parseKeyword("with")
 	if !ok {
 		// This is not a with clause.
@@ -1307,35 +1286,28 @@ func (v *parser) parseWithClause() (abs.WithClauseLike, *Token, bool) {
 	}
 	_, token, ok = v.parseKeyword("each")
 	if !ok {
-		var message = v.formatError(token)
-		message += generateGrammar("each",
-			"$withClause")
-		panic(message)
+		panic(v.syntaxError(token, "each",
+			"$withClause"))
 	}
 	item, token, ok = v.parseSymbol()
 	if !ok {
-		var message = v.formatError(token)
-		message += generateGrammar("item",
+		panic(v.syntaxError(token, "item",
 			"$withClause",
-			"$item")
-		panic(message)
+			"$item"))
 	}
 	_, token, ok = v.parseKeyword("in")
 	if !ok {
-		var message = v.formatError(token)
-		message += generateGrammar("in",
-			"$withClause")
-		panic(message)
+		panic(v.syntaxError(token, "in",
+			"$withClause"))
 	}
 	block, token, ok = v.parseBlock()
 	if !ok {
-		var message = v.formatError(token)
-		message += generateGrammar("sequence",
+		panic(v.syntaxError(token, "sequence",
 			"$withClause",
-			"$sequence")
-		panic(message)
+			"$sequence"))
 	}
 	clause = pro.WithClause(item, block)
+	v.locateNode(clause, start)
 	return clause, token, true
 }
 
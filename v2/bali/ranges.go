@@ -443,20 +443,16 @@ func (v *parser) parseRange() (abs.Range, *Token, bool) {
 	}
 	last, token, ok = v.parseEndpoint()
 	if !ok {
-		var message = v.formatError(token)
-		message += generateGrammar("primitive",
+		panic(v.syntaxError(token, "primitive",
 			"$range",
-			"$primitive")
-		panic(message)
+			"$primitive"))
 	}
 	right, token, ok = v.parseDelimiter("]")
 	if !ok {
 		right, token, ok = v.parseDelimiter(")")
 		if !ok {
-			var message = v.formatError(token)
-			message += generateGrammar("bracket",
-				"$range")
-			panic(message)
+			panic(v.syntaxError(token, "bracket",
+				"$range"))
 		}
 	}
 	switch {
@@ -624,7 +620,7 @@ func (v *parser) parseFloat() (abs.FloatLike, *Token, bool) {
 // This method adds the canonical format for the specified real number to the
 // state of the formatter.
 func (v *formatter) formatFloat(real_ abs.FloatLike) {
-	v.AppendString(real_.AsString())
+	v.AppendString(v.spellSymbols(real_.AsString()))
 }
 
 // This method attempts to parse a character. It returns the character and whether or not
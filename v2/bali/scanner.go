@@ -12,6 +12,7 @@ package bali
 
 import (
 	byt "bytes"
+	con "context"
 	//fmt "fmt"
 	uti "github.com/bali-nebula/go-component-framework/v2/utilities"
 	reg "regexp"
@@ -31,6 +32,7 @@ const (
 	TokenBINARY      TokenType = "BINARY"
 	TokenBOOLEAN     TokenType = "BOOLEAN"
 	TokenBYTECODE    TokenType = "BYTECODE"
+	TokenCITATION    TokenType = "CITATION"
 	TokenCOMMENT     TokenType = "COMMENT"
 	TokenDELIMITER   TokenType = "DELIMITER"
 	TokenDURATION    TokenType = "DURATION"
@@ -60,11 +62,29 @@ const (
 // of bytes. The scanner will automatically generating tokens that match the
 // corresponding regular expressions.
 func ScanTokens(source []byte, tokens chan Token) *scanner {
-	var v = &scanner{source: source, line: 1, position: 1, tokens: tokens}
+	return ScanTokensWithContext(con.Background(), source, tokens)
+}
+
+// This function creates a new scanner initialized with the specified array
+// of bytes that generates its tokens in the background like the ScanTokens()
+// function. If the specified context is cancelled before all of the tokens
+// have been consumed the scanner stops scanning and closes the token channel
+// so that its go routine is not left blocked on the channel.
+func ScanTokensWithContext(ctx con.Context, source []byte, tokens chan Token) *scanner {
+	var v = &scanner{source: source, line: 1, position: 1, tokens: tokens, done: ctx.Done()}
 	go v.generateTokens() // Start scanning in the background.
 	return v
 }
 
+// This constructor creates a new pull-based scanner initialized with the
+// specified array of bytes. The scanner does not start a go routine, instead
+// each call to its NextToken() method synchronously scans the next token from
+// the source bytes.
+func Scanner(source []byte) *scanner {
+	var v = &scanner{source: source, line: 1, position: 1}
+	return v
+}
+
 // SCANNER IMPLEMENTATION
 
 // This private function converts an array of byte arrays into an array of
@@ -87,11 +107,33 @@ func bytesToStrings(bytes [][]byte) []string {
 
 type scanner struct {
 	source    []byte
-	firstByte int // The zero based index of the first possible byte in the next token.
-	nextByte  int // The zero based index of the next possible byte in the next token.
-	line      int // The line number in the source bytes of the next rune.
-	position  int // The position in the current line of the first rune in the next token.
-	tokens    chan Token
+	firstByte int             // The zero based index of the first possible byte in the next token.
+	nextByte  int             // The zero based index of the next possible byte in the next token.
+	line      int             // The line number in the source bytes of the next rune.
+	position  int             // The position in the current line of the first rune in the next token.
+	tokens    chan Token      // The channel of generated tokens for a background scanner.
+	done      <-chan struct{} // The channel that is closed when a background scanner is cancelled.
+	cancelled bool            // Whether or not a background scanner has been cancelled.
+	pending   []Token         // The scanned but unread tokens for a pull-based scanner.
+	last      Token           // The last token that was read from a pull-based scanner.
+	finished  bool            // Whether or not a pull-based scanner has reached the EOF or an error.
+}
+
+// This method returns the next token from the source bytes of a pull-based
+// scanner. Once the EOF token or an error token has been returned, that same
+// token is returned by every subsequent call.
+func (v *scanner) NextToken() Token {
+	if v.tokens != nil {
+		panic("The NextToken() method may only be called on a pull-based scanner.")
+	}
+	for len(v.pending) == 0 && !v.finished {
+		v.finished = !v.processToken()
+	}
+	if len(v.pending) > 0 {
+		v.last = v.pending[0]
+		v.pending = v.pending[1:]
+	}
+	return v.last
 }
 
 // This method determines whether or not the scanner is at the end of the source
@@ -99,8 +141,9 @@ type scanner struct {
 // channel if it is at the end.
 func (v *scanner) atEOF() bool {
 	if v.nextByte == len(v.source) {
-		// The last byte in a POSIX standard file must be an EOL character.
-		if byt.HasPrefix(v.source[v.nextByte-1:], []byte(EOL)) {
+		// The last byte in a POSIX standard file must be an EOL character, but
+		// an empty file has no last byte.
+		if len(v.source) == 0 || byt.HasSuffix(v.source, []byte(EOL)) {
 			v.emitToken("", TokenEOF)
 			return true
 		}
@@ -147,9 +190,9 @@ func (v *scanner) emitToken(tValue string, tType TokenType) {
 				tValue = "<VTAB>"
 			}
 		}
-		var token = Token{tType, tValue, v.line, v.position}
+		var token = Token{tType, tValue, v.line, v.position, v.firstByte}
 		//fmt.Println(token)
-		v.tokens <- token
+		v.sendToken(token)
 	}
 	v.nextByte += byteCount
 	v.firstByte = v.nextByte
@@ -162,13 +205,32 @@ func (v *scanner) emitToken(tValue string, tType TokenType) {
 }
 
 // This method continues scanning tokens from the source bytes until an error
-// occurs or the end of file is reached. It then closes the token channel.
+// occurs, the end of file is reached or the scanner is cancelled. It then
+// closes the token channel.
 func (v *scanner) generateTokens() {
-	for v.processToken() {
+	for !v.cancelled && v.processToken() {
 	}
 	close(v.tokens)
 }
 
+// This method delivers the specified token to the consumer of the scanner. A
+// pull-based scanner buffers the token until it is read, a background scanner
+// sends it to the token channel unless the scanner has been cancelled.
+func (v *scanner) sendToken(token Token) {
+	switch {
+	case v.tokens == nil:
+		v.pending = append(v.pending, token)
+	case v.cancelled:
+		// The consumer is no longer reading from the token channel.
+	default:
+		select {
+		case v.tokens <- token:
+		case <-v.done:
+			v.cancelled = true
+		}
+	}
+}
+
 // This method attempts to scan any token starting with the next rune in the
 // source bytes. It checks for each type of token as the cases for the switch
 // statement. If that token type is found, this method returns true and skips
@@ -189,6 +251,7 @@ func (v *scanner) processToken() bool {
 	// Element token types.
 	case v.scanANGLE():
 	case v.scanBOOLEAN():
+	case v.scanCITATION():
 	case v.scanDURATION():
 	case v.scanMOMENT():
 	case v.scanPATTERN():
@@ -266,6 +329,18 @@ func (v *scanner) scanBYTECODE() bool {
 	return false
 }
 
+// This method adds a new citation token with the current scanner information
+// to the token channel. It returns true if a new citation token was found.
+func (v *scanner) scanCITATION() bool {
+	var s = v.source[v.nextByte:]
+	var matches = bytesToStrings(uti.CitationMatcher.FindSubmatch(s))
+	if len(matches) > 0 {
+		v.emitToken(matches[0], TokenCITATION)
+		return true
+	}
+	return false
+}
+
 // This method adds a new comment token with the current scanner information
 // to the token channel. It returns true if a new comment token was found.
 func (v *scanner) scanCOMMENT() bool {
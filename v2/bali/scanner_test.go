@@ -0,0 +1,109 @@
+/*******************************************************************************
+ *   Copyright (c) 2009-2023 Crater Dog Technologies™.  All Rights Reserved.   *
+ *******************************************************************************
+ * DO NOT ALTER OR REMOVE COPYRIGHT NOTICES OR THIS FILE HEADER.               *
+ *                                                                             *
+ * This code is free software; you can redistribute it and/or modify it under  *
+ * the terms of The MIT License (MIT), as published by the Open Source         *
+ * Initiative. (See http://opensource.org/licenses/MIT)                        *
+ *******************************************************************************/
+
+package bali_test
+
+import (
+	con "context"
+	erx "errors"
+	bal "github.com/bali-nebula/go-component-framework/v2/bali"
+	ass "github.com/stretchr/testify/assert"
+	run "runtime"
+	sts "strings"
+	tes "testing"
+	tim "time"
+)
+
+func TestPullScanner(t *tes.T) {
+	var scanner = bal.Scanner([]byte("[1, $foo]\n"))
+	var types []bal.TokenType
+	for {
+		var token = scanner.NextToken()
+		types = append(types, token.Type)
+		if token.Type == bal.TokenEOF {
+			break
+		}
+	}
+	ass.Equal(t, []bal.TokenType{
+		bal.TokenDELIMITER,
+		bal.TokenNUMBER,
+		bal.TokenDELIMITER,
+		bal.TokenSYMBOL,
+		bal.TokenDELIMITER,
+		bal.TokenEOL,
+		bal.TokenEOF,
+	}, types)
+	ass.Equal(t, bal.TokenEOF, scanner.NextToken().Type)
+}
+
+func TestCancelledScanner(t *tes.T) {
+	var before = run.NumGoroutine()
+	var source = "[" + sts.Repeat("1, ", 10000) + "1]\n"
+	var ctx, cancel = con.WithCancel(con.Background())
+	var tokens = make(chan bal.Token, 4)
+	bal.ScanTokensWithContext(ctx, []byte(source), tokens)
+	<-tokens
+	cancel()
+	for range tokens {
+		// Drain any tokens that were sent before the cancellation.
+	}
+	ass.True(t, waitForGoroutines(before))
+}
+
+func TestCancelledParser(t *tes.T) {
+	var ctx, cancel = con.WithCancel(con.Background())
+	cancel()
+	var component, e = bal.TryParseDocumentWithContext(ctx, []byte("[1, 2, 3]\n"))
+	ass.Nil(t, component)
+	ass.True(t, erx.Is(e, con.Canceled))
+}
+
+func TestParserLeavesNoGoroutines(t *tes.T) {
+	var before = run.NumGoroutine()
+	var source = "[1 " + sts.Repeat("1, ", 1000) + "1]\n" // The error is near the start.
+	for index := 0; index < 10; index++ {
+		var _, e = bal.TryParseComponent(source)
+		ass.NotNil(t, e)
+	}
+	ass.True(t, waitForGoroutines(before))
+}
+
+func waitForGoroutines(count int) bool {
+	for attempt := 0; attempt < 100; attempt++ {
+		if run.NumGoroutine() <= count {
+			return true
+		}
+		tim.Sleep(10 * tim.Millisecond)
+	}
+	return false
+}
+
+func TestUnequalTokens(t *tes.T) {
+	for _, operator := range []string{"≠", "!="} {
+		var scanner = bal.Scanner([]byte("a " + operator + " b\n"))
+		scanner.NextToken()
+		var token = scanner.NextToken()
+		ass.Equal(t, bal.TokenDELIMITER, token.Type)
+		ass.Equal(t, operator, token.Value)
+	}
+}
+
+func TestInfinityTokens(t *tes.T) {
+	for _, source := range []string{"∞", "infinity"} {
+		var token = bal.Scanner([]byte(source + "\n")).NextToken()
+		ass.Equal(t, bal.TokenNUMBER, token.Type)
+		ass.Equal(t, source, token.Value)
+	}
+	var token = bal.Scanner([]byte("infinite\n")).NextToken()
+	ass.Equal(t, bal.TokenIDENTIFIER, token.Type)
+	token = bal.Scanner([]byte("5i\n")).NextToken()
+	ass.Equal(t, bal.TokenNUMBER, token.Type)
+	ass.Equal(t, "5i", token.Value)
+}
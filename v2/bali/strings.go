@@ -17,7 +17,6 @@ import (
 	uti "github.com/bali-nebula/go-component-framework/v2/utilities"
 	stc "strconv"
 	sts "strings"
-	uni "unicode"
 )
 
 // UNIVERSAL CONSTRUCTORS
@@ -214,20 +213,17 @@ func (v *parser) parseBinary() (abs.BinaryLike, *Token, bool) {
 		v.backupOne(token)
 		return binary, token, false
 	}
-	var matches = bytesToStrings(binaryScanner.FindSubmatch([]byte(token.Value)))
-	// Remove all whitespace and the "'>" and "<'" delimiters.
-	binary = str.BinaryFromString(sts.Map(func(r rune) rune {
-		if uni.IsSpace(r) {
-			return -1
-		}
-		return r
-	}, matches[1]))
+	binary = str.BinaryFromString(token.Value)
 	return binary, token, true
 }
 
 const lineLength = 60 // 60 base 64 characters encode 45 bytes per line.
 
+// This method adds the canonical format for the specified name to the state of
+// the formatter.
 func (v *formatter) formatName(name abs.NameLike) {
+	var s = name.AsString()
+	v.AppendString(s)
 }
 
 // This method adds the canonical format for the specified string to the state
@@ -235,7 +231,7 @@ func (v *formatter) formatName(name abs.NameLike) {
 func (v *formatter) formatBinary(binary abs.BinaryLike) {
 	v.AppendString("'>")
 	v.depth++
-	var s = binary.AsString()
+	var s = uti.Base64Encode(binary.AsArray())
 	var length = len(s)
 	if length > 0 {
 		for index := 0; index < length; {
@@ -263,14 +259,7 @@ func (v *parser) parseBytecode() (abs.BytecodeLike, *Token, bool) {
 		v.backupOne(token)
 		return bytecode, token, false
 	}
-	var matches = bytesToStrings(bytecodeScanner.FindSubmatch([]byte(token.Value)))
-	// Remove all whitespace and the "'" delimiters.
-	bytecode = str.BytecodeFromString(sts.Map(func(r rune) rune {
-		if uni.IsSpace(r) {
-			return -1
-		}
-		return r
-	}, matches[1]))
+	bytecode = str.BytecodeFromString(token.Value)
 	return bytecode, token, true
 }
 
@@ -278,7 +267,7 @@ func (v *parser) parseBytecode() (abs.BytecodeLike, *Token, bool) {
 // of the formatter.
 func (v *formatter) formatBytecode(bytecode abs.BytecodeLike) {
 	v.AppendString("'")
-	var s = bytecode.AsString()
+	var s = sts.Trim(bytecode.AsString(), "'") // Remove the "'" delimiters.
 	var length = len(s)
 	if length > 0 {
 		v.AppendString(s[0:4])
@@ -0,0 +1,181 @@
+/*******************************************************************************
+ *   Copyright (c) 2009-2023 Crater Dog Technologies™.  All Rights Reserved.   *
+ *******************************************************************************
+ * DO NOT ALTER OR REMOVE COPYRIGHT NOTICES OR THIS FILE HEADER.               *
+ *                                                                             *
+ * This code is free software; you can redistribute it and/or modify it under  *
+ * the terms of The MIT License (MIT), as published by the Open Source         *
+ * Initiative. (See http://opensource.org/licenses/MIT)                        *
+ *******************************************************************************/
+
+package bali
+
+import (
+	xml "encoding/xml"
+	fmt "fmt"
+	abs "github.com/bali-nebula/go-component-framework/v2/abstractions"
+	col "github.com/bali-nebula/go-component-framework/v2/collections"
+	com "github.com/bali-nebula/go-component-framework/v2/components"
+	sts "strings"
+)
+
+// XML INTERFACE
+
+// This function returns an XML document for the specified component. Each
+// component becomes a <component> element whose type attribute names the kind
+// of entity and whose note attribute holds its note, if it has one. Each
+// parameter in the context of the component becomes a <parameter> child
+// element. A catalog contains an <association> element with a <key> and a
+// <value> for each of its associations, a list contains a <component> element
+// for each of its components, and any other entity contains its canonical Bali
+// source form as text:
+//
+//	<component type="catalog">
+//	    <parameter name="$type">
+//	        <component type="citation">/acme/types/Order/v1</component>
+//	    </parameter>
+//	    <association>
+//	        <key>
+//	            <component type="symbol">$total</component>
+//	        </key>
+//	        <value>
+//	            <component type="number">5</component>
+//	        </value>
+//	    </association>
+//	</component>
+func FormatXML(component abs.ComponentLike) []byte {
+	var element = encodeXML(component)
+	var document, err = xml.MarshalIndent(element, "", "    ")
+	if err != nil {
+		var message = fmt.Sprintf("The XML encoder could not encode the component: %v", err)
+		panic(message)
+	}
+	return []byte(xml.Header + string(document) + EOL)
+}
+
+// This function parses the specified XML document, as produced by the
+// FormatXML() function, and returns the corresponding component.
+func ParseXML(document []byte) abs.ComponentLike {
+	var element xmlComponent
+	var err = xml.Unmarshal(document, &element)
+	if err != nil {
+		var message = fmt.Sprintf("The XML document is invalid: %v", err)
+		panic(message)
+	}
+	return decodeXML(&element)
+}
+
+// XML ELEMENT DEFINITIONS
+
+// This type defines the structure of the <component> element.
+type xmlComponent struct {
+	XMLName      xml.Name          `xml:"component"`
+	Type         string            `xml:"type,attr"`
+	Note         string            `xml:"note,attr,omitempty"`
+	Source       string            `xml:",chardata"`
+	Parameters   []*xmlParameter   `xml:"parameter"`
+	Associations []*xmlAssociation `xml:"association"`
+	Components   []*xmlComponent   `xml:"component"`
+}
+
+// This type defines the structure of the <parameter> element.
+type xmlParameter struct {
+	Name  string        `xml:"name,attr"`
+	Value *xmlComponent `xml:"component"`
+}
+
+// This type defines the structure of the <association> element.
+type xmlAssociation struct {
+	Key   *xmlComponent `xml:"key>component"`
+	Value *xmlComponent `xml:"value>component"`
+}
+
+// PRIVATE FUNCTIONS
+
+// This private function returns the <component> element for the specified
+// component.
+func encodeXML(component abs.ComponentLike) *xmlComponent {
+	var context = adjustContext(component)
+	var entity = component.GetEntity()
+	var element = &xmlComponent{Type: entityType(entity)}
+	if component.IsParameterized() {
+		var iterator = com.ParameterIterator(context)
+		for iterator.HasNext() {
+			var parameter = iterator.GetNext()
+			element.Parameters = append(element.Parameters, &xmlParameter{
+				Name:  FormatEntity(parameter.GetKey()),
+				Value: encodeXML(parameter.GetValue()),
+			})
+		}
+	}
+	if component.IsAnnotated() {
+		element.Note = "! " + string(component.GetNote().AsArray())
+	}
+	switch value := entity.(type) {
+	case abs.ValuesLike:
+		var iterator = com.ComponentIterator(value)
+		for iterator.HasNext() {
+			element.Components = append(element.Components, encodeXML(iterator.GetNext()))
+		}
+	case abs.AssociationsLike:
+		var iterator = col.AssociationIterator(value)
+		for iterator.HasNext() {
+			var association = iterator.GetNext()
+			element.Associations = append(element.Associations, &xmlAssociation{
+				Key:   encodeXML(com.Component(association.GetKey())),
+				Value: encodeXML(association.GetValue()),
+			})
+		}
+	default:
+		element.Source = FormatEntity(value)
+	}
+	return element
+}
+
+// This private function returns the component for the specified <component>
+// element.
+func decodeXML(element *xmlComponent) abs.ComponentLike {
+	var entity abs.Entity
+	switch element.Type {
+	case "list":
+		var list = col.List()
+		for _, item := range element.Components {
+			list.AddValue(decodeXML(item))
+		}
+		entity = list
+	case "catalog":
+		var catalog = col.Catalog()
+		for _, association := range element.Associations {
+			if association.Key == nil || association.Value == nil {
+				panic("An XML association requires both a key and a value.")
+			}
+			catalog.SetValue(decodeXML(association.Key).GetEntity(), decodeXML(association.Value))
+		}
+		entity = catalog
+	default:
+		// The canonical source form never begins or ends with whitespace so any
+		// surrounding whitespace is indentation from the parameter elements.
+		var source = sts.TrimSpace(element.Source)
+		entity = ParseEntity(source)
+		if entityType(entity) != element.Type {
+			var message = fmt.Sprintf("The XML component is not of type %v: %v", element.Type, source)
+			panic(message)
+		}
+	}
+	var component = com.Component(entity)
+	if len(element.Parameters) > 0 {
+		var context = com.Context()
+		for _, parameter := range element.Parameters {
+			if parameter.Value == nil {
+				var message = fmt.Sprintf("The XML parameter requires a value: %v", parameter.Name)
+				panic(message)
+			}
+			context.SetValue(Symbol(sts.TrimPrefix(parameter.Name, "$")), decodeXML(parameter.Value))
+		}
+		component.SetContext(context)
+	}
+	if len(element.Note) > 0 {
+		component.SetNote(com.Note(sts.TrimPrefix(element.Note, "! ")))
+	}
+	return component
+}
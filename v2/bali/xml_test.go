@@ -0,0 +1,85 @@
+/*******************************************************************************
+ *   Copyright (c) 2009-2023 Crater Dog Technologies™.  All Rights Reserved.   *
+ *******************************************************************************
+ * DO NOT ALTER OR REMOVE COPYRIGHT NOTICES OR THIS FILE HEADER.               *
+ *                                                                             *
+ * This code is free software; you can redistribute it and/or modify it under  *
+ * the terms of The MIT License (MIT), as published by the Open Source         *
+ * Initiative. (See http://opensource.org/licenses/MIT)                        *
+ *******************************************************************************/
+
+package bali_test
+
+import (
+	bal "github.com/bali-nebula/go-component-framework/v2/bali"
+	ass "github.com/stretchr/testify/assert"
+	osx "os"
+	sts "strings"
+	tes "testing"
+)
+
+func TestXMLRoundtrips(t *tes.T) {
+	var files, err = osx.ReadDir(testDirectory)
+	if err != nil {
+		panic("Could not find the ./test directory.")
+	}
+
+	for _, file := range files {
+		var filename = testDirectory + file.Name()
+		if sts.HasSuffix(filename, ".bali") {
+			var expected, _ = osx.ReadFile(filename)
+			var component = bal.ParseDocument(expected)
+			var document = bal.FormatXML(component)
+			component = bal.ParseXML(document)
+			ass.Equal(t, string(expected), string(bal.FormatDocument(component)), filename)
+		}
+	}
+}
+
+func TestXML(t *tes.T) {
+	var component = bal.ParseComponent(`[
+    $total: 5
+    $items: ["<apple>", ~π($units: $radians)]
+]($type: /acme/types/Order/v1)  ! A note.`)
+	var document = bal.FormatXML(component)
+	ass.Equal(t, `<?xml version="1.0" encoding="UTF-8"?>
+<component type="catalog" note="! A note.">
+    <parameter name="$type">
+        <component type="citation">/acme/types/Order/v1</component>
+    </parameter>
+    <association>
+        <key>
+            <component type="symbol">$total</component>
+        </key>
+        <value>
+            <component type="number">5</component>
+        </value>
+    </association>
+    <association>
+        <key>
+            <component type="symbol">$items</component>
+        </key>
+        <value>
+            <component type="list">
+                <component type="quote">&#34;&lt;apple&gt;&#34;</component>
+                <component type="angle">~π
+                    <parameter name="$units">
+                        <component type="symbol">$radians</component>
+                    </parameter>
+                </component>
+            </component>
+        </value>
+    </association>
+</component>
+`, string(document))
+	ass.Equal(t, bal.FormatComponent(component), bal.FormatComponent(bal.ParseXML(document)))
+
+	defer func() {
+		if e := recover(); e != nil {
+			ass.Equal(t, "The XML component is not of type moment: 5", e)
+		} else {
+			ass.Fail(t, "Test should result in recovered panic.")
+		}
+	}()
+	bal.ParseXML([]byte(`<component type="moment">5</component>`)) // This should panic.
+}
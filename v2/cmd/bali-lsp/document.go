@@ -0,0 +1,377 @@
+/*******************************************************************************
+ *   Copyright (c) 2009-2023 Crater Dog Technologies™.  All Rights Reserved.   *
+ *******************************************************************************
+ * DO NOT ALTER OR REMOVE COPYRIGHT NOTICES OR THIS FILE HEADER.               *
+ *                                                                             *
+ * This code is free software; you can redistribute it and/or modify it under  *
+ * the terms of The MIT License (MIT), as published by the Open Source         *
+ * Initiative. (See http://opensource.org/licenses/MIT)                        *
+ *******************************************************************************/
+
+package main
+
+import (
+	erx "errors"
+	fmt "fmt"
+	abs "github.com/bali-nebula/go-component-framework/v2/abstractions"
+	bal "github.com/bali-nebula/go-component-framework/v2/bali"
+	col "github.com/bali-nebula/go-component-framework/v2/collections"
+	com "github.com/bali-nebula/go-component-framework/v2/components"
+	sor "sort"
+	sts "strings"
+	u16 "unicode/utf16"
+	utf "unicode/utf8"
+)
+
+// DOCUMENT DEFINITIONS
+
+// This map defines the keywords that introduce the symbol of a recipient. The
+// "as" keyword only introduces a recipient in a save clause.
+var recipientKeywords = map[string]bool{
+	"let":      true,
+	"each":     true,
+	"checkout": true,
+	"retrieve": true,
+	"on":       true,
+	"as":       true,
+}
+
+// DOCUMENT IMPLEMENTATION
+
+// This constructor creates a new document for the specified source bytes. The
+// source bytes are parsed and scanned once when the document is created and
+// every later request is answered from the results.
+func Document(uri string, version int, source []byte) *document {
+	var component, err = bal.TryParseDocument(source)
+	var v = &document{
+		uri:       uri,
+		version:   version,
+		source:    source,
+		component: component,
+		err:       err,
+	}
+	v.lineStarts = append(v.lineStarts, 0)
+	for offset, character := range source {
+		if character == '\n' {
+			v.lineStarts = append(v.lineStarts, offset+1)
+		}
+	}
+	if len(source) == 0 {
+		// An empty document has no tokens.
+		return v
+	}
+	var scanner = bal.Scanner(source)
+	for {
+		var token = scanner.NextToken()
+		if token.Type == bal.TokenEOF || token.Type == bal.TokenERROR {
+			break
+		}
+		if token.Type != bal.TokenEOL {
+			v.tokens = append(v.tokens, token)
+		}
+	}
+	return v
+}
+
+// This type defines the structure and methods for a text document that has
+// been opened by the language client.
+type document struct {
+	uri        string
+	version    int
+	source     []byte
+	component  abs.ComponentLike // The parsed component or nil if it is invalid.
+	err        error             // The error that was returned by the parser.
+	tokens     []bal.Token       // The scanned tokens, not including EOL tokens.
+	lineStarts []int             // The byte offset of the start of each line.
+}
+
+// This method returns the version of this document.
+func (v *document) GetVersion() int {
+	return v.version
+}
+
+// This method returns the diagnostics for this document. A document that was
+// parsed successfully has no diagnostics.
+func (v *document) GetDiagnostics() []diagnostic {
+	var diagnostics = []diagnostic{}
+	if v.err == nil {
+		return diagnostics
+	}
+	var syntaxError *bal.SyntaxError
+	if !erx.As(v.err, &syntaxError) {
+		diagnostics = append(diagnostics, diagnostic{
+			Range:    v.rangeOf(0, 0),
+			Severity: severityError,
+			Source:   "bali",
+			Message:  v.err.Error(),
+		})
+		return diagnostics
+	}
+	var token = syntaxError.Token
+	var end = token.Offset + len(token.Value)
+	var message = fmt.Sprintf("An unexpected token was received by the parser: %q", token.Value)
+	switch {
+	case token.Type == bal.TokenERROR:
+		// The value of an error token may be a description of the character.
+		var _, size = utf.DecodeRune(v.source[min(token.Offset, len(v.source)):])
+		end = token.Offset + size
+		message = fmt.Sprintf("An invalid character was found by the scanner: %v", token.Value)
+	case token.Type == bal.TokenEOF:
+		end = token.Offset
+		message = "The end of the document was reached unexpectedly."
+	}
+	if len(syntaxError.Expected) > 0 {
+		message += fmt.Sprintf("\nWas expecting '%v' from:", syntaxError.Expected)
+		for _, rule := range syntaxError.Rules {
+			message += fmt.Sprintf("\n  %v: %v", rule, sts.TrimSpace(syntaxError.Grammar[rule]))
+		}
+	}
+	diagnostics = append(diagnostics, diagnostic{
+		Range:    v.rangeOf(token.Offset, end),
+		Severity: severityError,
+		Source:   "bali",
+		Message:  message,
+	})
+	return diagnostics
+}
+
+// This method returns the edits that rewrite this document in its canonical
+// form. It returns no edits if the document is invalid or already canonical.
+func (v *document) GetFormattingEdits() []textEdit {
+	var edits = []textEdit{}
+	if v.component == nil {
+		return edits
+	}
+	var formatted = string(bal.FormatDocument(v.component))
+	if formatted == string(v.source) {
+		return edits
+	}
+	edits = append(edits, textEdit{
+		Range:   v.rangeOf(0, len(v.source)),
+		NewText: formatted,
+	})
+	return edits
+}
+
+// This method returns the grammar rules defining the token at the specified
+// position in this document, or nil if there is no token at that position.
+func (v *document) GetHover(at position) *hover {
+	var index, ok = v.tokenAt(v.offsetOf(at))
+	if !ok {
+		return nil
+	}
+	var token = v.tokens[index]
+	var rules = bal.GetTokenRules(token)
+	if len(rules) == 0 {
+		return nil
+	}
+	var value = fmt.Sprintf("**%v** `%v`\n", token.Type, token.Value)
+	for _, rule := range rules {
+		var definition, _ = bal.GetGrammarRule(rule)
+		value += fmt.Sprintf("```\n%v: %v\n```\n", rule, sts.TrimSpace(definition))
+	}
+	var result = &hover{
+		Contents: markupContent{Kind: "markdown", Value: value},
+		Range:    v.rangeOf(token.Offset, token.Offset+len(token.Value)),
+	}
+	return result
+}
+
+// This method returns the location of the definition of the variable or
+// symbol at the specified position in this document, or nil if it has none.
+// A variable or symbol is defined by the symbol of the recipient in a let,
+// with each, checkout, retrieve, save or on clause. The closest definition
+// preceding the position is preferred over any that follow it.
+func (v *document) GetDefinition(at position) *location {
+	var index, ok = v.tokenAt(v.offsetOf(at))
+	if !ok {
+		return nil
+	}
+	var token = v.tokens[index]
+	var identifier string
+	switch token.Type {
+	case bal.TokenIDENTIFIER:
+		identifier = token.Value
+	case bal.TokenSYMBOL:
+		identifier = token.Value[1:]
+	default:
+		return nil
+	}
+	var definition = -1
+	var clause string // The keyword that began the current clause.
+	for current, candidate := range v.tokens {
+		if candidate.Type == bal.TokenKEYWORD && !recipientKeywords[candidate.Value] {
+			clause = candidate.Value
+		}
+		if candidate.Type != bal.TokenSYMBOL || candidate.Value[1:] != identifier {
+			continue
+		}
+		if current == 0 || !v.isRecipient(current, clause) {
+			continue
+		}
+		if definition < 0 || current <= index {
+			definition = current
+		}
+		if current >= index {
+			break
+		}
+	}
+	if definition < 0 {
+		return nil
+	}
+	var symbol = v.tokens[definition]
+	var result = &location{
+		URI:   v.uri,
+		Range: v.rangeOf(symbol.Offset, symbol.Offset+len(symbol.Value)),
+	}
+	return result
+}
+
+// This method returns the outline of the catalogs in this document. Each
+// association in a catalog is listed by its key and contains the associations
+// of any catalogs that are nested within its value.
+func (v *document) GetOutline() []documentSymbol {
+	var outline = []documentSymbol{}
+	if v.component == nil {
+		return outline
+	}
+	return append(outline, v.outlineEntity(v.component.GetEntity())...)
+}
+
+// PRIVATE METHODS
+
+// This private method determines whether or not the symbol token at the
+// specified index is the recipient of the clause that began with the specified
+// keyword.
+func (v *document) isRecipient(index int, clause string) bool {
+	var previous = v.tokens[index-1]
+	if previous.Type != bal.TokenKEYWORD || !recipientKeywords[previous.Value] {
+		return false
+	}
+	return previous.Value != "as" || clause == "save"
+}
+
+// This private method returns the outline of the associations in the
+// specified entity. The associations of catalogs within a list are included
+// directly.
+func (v *document) outlineEntity(entity abs.Entity) []documentSymbol {
+	var outline []documentSymbol
+	switch collection := entity.(type) {
+	case abs.AssociationsLike:
+		var iterator = col.AssociationIterator(collection)
+		for iterator.HasNext() {
+			var association = iterator.GetNext()
+			var symbol, ok = v.outlineAssociation(association)
+			if ok {
+				outline = append(outline, symbol)
+			}
+		}
+	case abs.ValuesLike:
+		var iterator = com.ComponentIterator(collection)
+		for iterator.HasNext() {
+			var value = iterator.GetNext()
+			outline = append(outline, v.outlineEntity(value.GetEntity())...)
+		}
+	}
+	return outline
+}
+
+// This private method returns the outline entry for the specified association
+// and whether or not its location in this document is known.
+func (v *document) outlineAssociation(association abs.AssociationLike) (documentSymbol, bool) {
+	var symbol documentSymbol
+	var value = association.GetValue()
+	var located, ok = value.(abs.Located)
+	if !ok || !located.GetSpan().IsValid() {
+		return symbol, false
+	}
+	var span = located.GetSpan()
+	symbol.Name = bal.FormatEntity(association.GetKey())
+	symbol.Range = v.rangeOf(span.Start.Offset, span.End.Offset)
+	symbol.SelectionRange = symbol.Range
+
+	// The key is the token preceding the ":" delimiter that precedes the value.
+	var index = sor.Search(len(v.tokens), func(i int) bool {
+		return v.tokens[i].Offset >= span.Start.Offset
+	})
+	if index >= 2 && v.tokens[index-1].Value == ":" {
+		var key = v.tokens[index-2]
+		symbol.Range = v.rangeOf(key.Offset, span.End.Offset)
+		symbol.SelectionRange = v.rangeOf(key.Offset, key.Offset+len(key.Value))
+	}
+
+	var entity = value.GetEntity()
+	switch entity.(type) {
+	case abs.AssociationsLike:
+		symbol.Kind = symbolObject
+		symbol.Detail = "catalog"
+	case abs.ValuesLike:
+		symbol.Kind = symbolArray
+		symbol.Detail = "list"
+	case abs.ProcedureLike:
+		symbol.Kind = symbolFunction
+		symbol.Detail = "procedure"
+	default:
+		symbol.Kind = symbolField
+		symbol.Detail = bal.FormatEntity(entity)
+		if len(symbol.Detail) > 60 || sts.Contains(symbol.Detail, bal.EOL) {
+			symbol.Detail = ""
+		}
+	}
+	symbol.Children = v.outlineEntity(entity)
+	return symbol, true
+}
+
+// This private method returns the index of the token containing the specified
+// byte offset and whether or not there is such a token. A token also contains
+// the offset just past its last byte so that the cursor may follow it.
+func (v *document) tokenAt(offset int) (int, bool) {
+	var index = sor.Search(len(v.tokens), func(i int) bool {
+		return v.tokens[i].Offset+len(v.tokens[i].Value) >= offset
+	})
+	if index == len(v.tokens) || v.tokens[index].Offset > offset {
+		return 0, false
+	}
+	return index, true
+}
+
+// This private method returns the range of text between the specified byte
+// offsets in this document.
+func (v *document) rangeOf(start, end int) textRange {
+	return textRange{Start: v.positionOf(start), End: v.positionOf(end)}
+}
+
+// This private method returns the position of the specified byte offset in
+// this document.
+func (v *document) positionOf(offset int) position {
+	offset = max(0, min(offset, len(v.source)))
+	var line = sor.SearchInts(v.lineStarts, offset+1) - 1
+	var text = v.source[v.lineStarts[line]:offset]
+	var character int
+	for len(text) > 0 {
+		var rune_, size = utf.DecodeRune(text)
+		character += len(u16.Encode([]rune{rune_}))
+		text = text[size:]
+	}
+	return position{Line: line, Character: character}
+}
+
+// This private method returns the byte offset of the specified position in
+// this document. A position beyond the end of its line is treated as the end
+// of the line.
+func (v *document) offsetOf(at position) int {
+	if at.Line < 0 {
+		return 0
+	}
+	if at.Line >= len(v.lineStarts) {
+		return len(v.source)
+	}
+	var offset = v.lineStarts[at.Line]
+	var character int
+	for offset < len(v.source) && v.source[offset] != '\n' && character < at.Character {
+		var rune_, size = utf.DecodeRune(v.source[offset:])
+		character += len(u16.Encode([]rune{rune_}))
+		offset += size
+	}
+	return offset
+}
@@ -0,0 +1,34 @@
+/*******************************************************************************
+ *   Copyright (c) 2009-2023 Crater Dog Technologies™.  All Rights Reserved.   *
+ *******************************************************************************
+ * DO NOT ALTER OR REMOVE COPYRIGHT NOTICES OR THIS FILE HEADER.               *
+ *                                                                             *
+ * This code is free software; you can redistribute it and/or modify it under  *
+ * the terms of The MIT License (MIT), as published by the Open Source         *
+ * Initiative. (See http://opensource.org/licenses/MIT)                        *
+ *******************************************************************************/
+
+// The bali-lsp command is a Language Server Protocol server for Bali Document
+// Notation™ (BDN) documents. It communicates with the editor over its standard
+// input and output and provides:
+//
+//   - diagnostics for the syntax errors found by the parser,
+//   - formatting of a document into its canonical form,
+//   - hover information showing the grammar rules for the token under the
+//     cursor,
+//   - go to definition for the variables and symbols in procedures, and
+//   - an outline of the associations in the catalogs of a document.
+//
+// The server requests that the editor send the full text of a document each
+// time it changes.
+package main
+
+import (
+	osx "os"
+)
+
+func main() {
+	var connection = Connection(osx.Stdin, osx.Stdout)
+	var server = Server(connection)
+	osx.Exit(server.Serve())
+}
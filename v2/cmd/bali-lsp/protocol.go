@@ -0,0 +1,226 @@
+/*******************************************************************************
+ *   Copyright (c) 2009-2023 Crater Dog Technologies™.  All Rights Reserved.   *
+ *******************************************************************************
+ * DO NOT ALTER OR REMOVE COPYRIGHT NOTICES OR THIS FILE HEADER.               *
+ *                                                                             *
+ * This code is free software; you can redistribute it and/or modify it under  *
+ * the terms of The MIT License (MIT), as published by the Open Source         *
+ * Initiative. (See http://opensource.org/licenses/MIT)                        *
+ *******************************************************************************/
+
+package main
+
+import (
+	buf "bufio"
+	jsn "encoding/json"
+	fmt "fmt"
+	ioo "io"
+	stc "strconv"
+	sts "strings"
+)
+
+// PROTOCOL DEFINITIONS
+
+// These constants define the error codes used in JSON-RPC error responses.
+const (
+	parseError     = -32700
+	methodNotFound = -32601
+	invalidParams  = -32602
+	internalError  = -32603
+)
+
+// These constants define the kinds of document symbols used in an outline.
+const (
+	symbolField    = 8
+	symbolFunction = 12
+	symbolArray    = 18
+	symbolObject   = 19
+)
+
+// These constants define the severities of diagnostics.
+const (
+	severityError = 1
+)
+
+// This type defines a JSON-RPC message which may be a request, a response or
+// a notification. A request has both an identifier and a method, a response
+// has only an identifier and a notification has only a method.
+type message struct {
+	JSONRPC string          `json:"jsonrpc"`
+	ID      *jsn.RawMessage `json:"id,omitempty"`
+	Method  string          `json:"method,omitempty"`
+	Params  jsn.RawMessage  `json:"params,omitempty"`
+	Result  jsn.RawMessage  `json:"result,omitempty"`
+	Error   *responseError  `json:"error,omitempty"`
+}
+
+// This type defines the error that is returned in a JSON-RPC response.
+type responseError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+// This method returns the message for this response error.
+func (v *responseError) Error() string {
+	return v.Message
+}
+
+// This type defines a zero-based position in a text document. The character
+// is counted in UTF-16 code units as required by the protocol.
+type position struct {
+	Line      int `json:"line"`
+	Character int `json:"character"`
+}
+
+// This type defines a range of text in a text document. The end position is
+// exclusive.
+type textRange struct {
+	Start position `json:"start"`
+	End   position `json:"end"`
+}
+
+// This type defines a range of text in a specific text document.
+type location struct {
+	URI   string    `json:"uri"`
+	Range textRange `json:"range"`
+}
+
+// This type defines a diagnostic, such as a syntax error, for a text document.
+type diagnostic struct {
+	Range    textRange `json:"range"`
+	Severity int       `json:"severity"`
+	Source   string    `json:"source"`
+	Message  string    `json:"message"`
+}
+
+// This type defines an edit that replaces a range of text in a text document.
+type textEdit struct {
+	Range   textRange `json:"range"`
+	NewText string    `json:"newText"`
+}
+
+// This type defines the markdown content shown when hovering over a token.
+type hover struct {
+	Contents markupContent `json:"contents"`
+	Range    textRange     `json:"range"`
+}
+
+// This type defines content that is rendered using the specified markup.
+type markupContent struct {
+	Kind  string `json:"kind"`
+	Value string `json:"value"`
+}
+
+// This type defines an entry in the hierarchical outline of a text document.
+type documentSymbol struct {
+	Name           string           `json:"name"`
+	Detail         string           `json:"detail,omitempty"`
+	Kind           int              `json:"kind"`
+	Range          textRange        `json:"range"`
+	SelectionRange textRange        `json:"selectionRange"`
+	Children       []documentSymbol `json:"children,omitempty"`
+}
+
+// These types define the parameters for each of the supported methods.
+type (
+	textDocumentIdentifier struct {
+		URI string `json:"uri"`
+	}
+	textDocumentItem struct {
+		URI     string `json:"uri"`
+		Version int    `json:"version"`
+		Text    string `json:"text"`
+	}
+	didOpenParams struct {
+		TextDocument textDocumentItem `json:"textDocument"`
+	}
+	didChangeParams struct {
+		TextDocument struct {
+			URI     string `json:"uri"`
+			Version int    `json:"version"`
+		} `json:"textDocument"`
+		ContentChanges []struct {
+			Text string `json:"text"`
+		} `json:"contentChanges"`
+	}
+	didCloseParams struct {
+		TextDocument textDocumentIdentifier `json:"textDocument"`
+	}
+	documentParams struct {
+		TextDocument textDocumentIdentifier `json:"textDocument"`
+	}
+	positionParams struct {
+		TextDocument textDocumentIdentifier `json:"textDocument"`
+		Position     position               `json:"position"`
+	}
+	publishDiagnosticsParams struct {
+		URI         string       `json:"uri"`
+		Version     int          `json:"version"`
+		Diagnostics []diagnostic `json:"diagnostics"`
+	}
+)
+
+// PROTOCOL IMPLEMENTATION
+
+// This constructor creates a new connection that reads JSON-RPC messages from
+// the specified reader and writes them to the specified writer using the
+// header framing defined by the Language Server Protocol.
+func Connection(reader ioo.Reader, writer ioo.Writer) *connection {
+	var v = &connection{reader: buf.NewReader(reader), writer: writer}
+	return v
+}
+
+// This type defines the structure and methods for a connection to a language
+// client.
+type connection struct {
+	reader *buf.Reader
+	writer ioo.Writer
+}
+
+// This method reads the next message from this connection. It returns ioo.EOF
+// when the client has closed the connection.
+func (v *connection) ReadMessage() (*message, error) {
+	var length = -1
+	for {
+		var line, err = v.reader.ReadString('\n')
+		if err != nil {
+			return nil, err
+		}
+		line = sts.TrimRight(line, "\r\n")
+		if len(line) == 0 {
+			break // The headers are followed by a blank line.
+		}
+		var name, value, ok = sts.Cut(line, ":")
+		if ok && sts.EqualFold(sts.TrimSpace(name), "Content-Length") {
+			length, err = stc.Atoi(sts.TrimSpace(value))
+			if err != nil {
+				return nil, fmt.Errorf("An invalid content length was received: %q", value)
+			}
+		}
+	}
+	if length < 0 {
+		return nil, fmt.Errorf("A message without a content length was received.")
+	}
+	var content = make([]byte, length)
+	var _, err = ioo.ReadFull(v.reader, content)
+	if err != nil {
+		return nil, err
+	}
+	var result = &message{}
+	err = jsn.Unmarshal(content, result)
+	if err != nil {
+		return nil, &responseError{parseError, err.Error()}
+	}
+	return result, nil
+}
+
+// This method writes the specified message to this connection.
+func (v *connection) WriteMessage(message *message) error {
+	message.JSONRPC = "2.0"
+	var content, err = jsn.Marshal(message)
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintf(v.writer, "Content-Length: %d\r\n\r\n%s", len(content), content)
+	return err
+}
@@ -0,0 +1,259 @@
+/*******************************************************************************
+ *   Copyright (c) 2009-2023 Crater Dog Technologies™.  All Rights Reserved.   *
+ *******************************************************************************
+ * DO NOT ALTER OR REMOVE COPYRIGHT NOTICES OR THIS FILE HEADER.               *
+ *                                                                             *
+ * This code is free software; you can redistribute it and/or modify it under  *
+ * the terms of The MIT License (MIT), as published by the Open Source         *
+ * Initiative. (See http://opensource.org/licenses/MIT)                        *
+ *******************************************************************************/
+
+package main
+
+import (
+	jsn "encoding/json"
+	erx "errors"
+	fmt "fmt"
+	ioo "io"
+)
+
+// SERVER IMPLEMENTATION
+
+// This constructor creates a new language server that communicates with its
+// client over the specified connection.
+func Server(connection *connection) *server {
+	var v = &server{
+		connection: connection,
+		documents:  make(map[string]*document),
+	}
+	return v
+}
+
+// This type defines the structure and methods for a language server that
+// supports Bali Document Notation™ documents.
+type server struct {
+	connection *connection
+	documents  map[string]*document // The open documents indexed by their URI.
+	shutdown   bool                 // Whether or not a shutdown was requested.
+}
+
+// This method serves the requests and notifications from the client until the
+// client closes the connection or sends an exit notification. It returns the
+// exit code for the process, which is zero only if a shutdown was requested
+// before the server stopped.
+func (v *server) Serve() int {
+	for {
+		var message, err = v.connection.ReadMessage()
+		if err != nil {
+			var responseError *responseError
+			if erx.As(err, &responseError) {
+				v.respond(nil, nil, responseError)
+				continue
+			}
+			if erx.Is(err, ioo.EOF) && v.shutdown {
+				return 0
+			}
+			return 1
+		}
+		if message.Method == "exit" {
+			if v.shutdown {
+				return 0
+			}
+			return 1
+		}
+		v.handleMessage(message)
+	}
+}
+
+// PRIVATE METHODS
+
+// This private method handles the specified request or notification. Any
+// panic while handling a request is returned to the client as an internal
+// error rather than stopping the server.
+func (v *server) handleMessage(message *message) {
+	defer func() {
+		if e := recover(); e != nil {
+			var responseError = &responseError{internalError, fmt.Sprintf("%v", e)}
+			if message.ID != nil {
+				v.respond(message.ID, nil, responseError)
+			}
+		}
+	}()
+	var result, responseError = v.dispatch(message.Method, message.Params)
+	if message.ID == nil {
+		return // Notifications do not receive a response.
+	}
+	v.respond(message.ID, result, responseError)
+}
+
+// This private method invokes the handler for the specified method with the
+// specified parameters and returns its result.
+func (v *server) dispatch(method string, params jsn.RawMessage) (any, *responseError) {
+	switch method {
+	case "initialize":
+		return v.initialize(), nil
+	case "initialized":
+		return nil, nil
+	case "shutdown":
+		v.shutdown = true
+		return nil, nil
+	case "textDocument/didOpen":
+		var arguments didOpenParams
+		if e := v.decode(params, &arguments); e != nil {
+			return nil, e
+		}
+		var item = arguments.TextDocument
+		v.openDocument(item.URI, item.Version, []byte(item.Text))
+		return nil, nil
+	case "textDocument/didChange":
+		var arguments didChangeParams
+		if e := v.decode(params, &arguments); e != nil {
+			return nil, e
+		}
+		var changes = arguments.ContentChanges
+		if len(changes) > 0 {
+			// The server requests full text synchronization.
+			var text = changes[len(changes)-1].Text
+			v.openDocument(arguments.TextDocument.URI, arguments.TextDocument.Version, []byte(text))
+		}
+		return nil, nil
+	case "textDocument/didClose":
+		var arguments didCloseParams
+		if e := v.decode(params, &arguments); e != nil {
+			return nil, e
+		}
+		delete(v.documents, arguments.TextDocument.URI)
+		v.notify("textDocument/publishDiagnostics", publishDiagnosticsParams{
+			URI:         arguments.TextDocument.URI,
+			Diagnostics: []diagnostic{},
+		})
+		return nil, nil
+	case "textDocument/formatting":
+		var arguments documentParams
+		var document, e = v.lookupDocument(params, &arguments, &arguments.TextDocument)
+		if e != nil {
+			return nil, e
+		}
+		return document.GetFormattingEdits(), nil
+	case "textDocument/hover":
+		var arguments positionParams
+		var document, e = v.lookupDocument(params, &arguments, &arguments.TextDocument)
+		if e != nil {
+			return nil, e
+		}
+		return document.GetHover(arguments.Position), nil
+	case "textDocument/definition":
+		var arguments positionParams
+		var document, e = v.lookupDocument(params, &arguments, &arguments.TextDocument)
+		if e != nil {
+			return nil, e
+		}
+		return document.GetDefinition(arguments.Position), nil
+	case "textDocument/documentSymbol":
+		var arguments documentParams
+		var document, e = v.lookupDocument(params, &arguments, &arguments.TextDocument)
+		if e != nil {
+			return nil, e
+		}
+		return document.GetOutline(), nil
+	default:
+		var message = fmt.Sprintf("The language server does not support the method: %v", method)
+		return nil, &responseError{methodNotFound, message}
+	}
+}
+
+// This private method returns the capabilities of this language server.
+func (v *server) initialize() any {
+	var result = map[string]any{
+		"capabilities": map[string]any{
+			"textDocumentSync":           1, // The full text is sent on each change.
+			"documentFormattingProvider": true,
+			"hoverProvider":              true,
+			"definitionProvider":         true,
+			"documentSymbolProvider":     true,
+		},
+		"serverInfo": map[string]any{
+			"name": "bali-lsp",
+		},
+	}
+	return result
+}
+
+// This private method records the specified version of an open document and
+// publishes its diagnostics to the client.
+func (v *server) openDocument(uri string, version int, source []byte) {
+	var document = Document(uri, version, source)
+	v.documents[uri] = document
+	v.notify("textDocument/publishDiagnostics", publishDiagnosticsParams{
+		URI:         uri,
+		Version:     version,
+		Diagnostics: document.GetDiagnostics(),
+	})
+}
+
+// This private method decodes the specified parameters into the specified
+// arguments and then returns the open document that they identify.
+func (v *server) lookupDocument(params jsn.RawMessage, arguments any, identifier *textDocumentIdentifier) (*document, *responseError) {
+	if e := v.decode(params, arguments); e != nil {
+		return nil, e
+	}
+	var document, ok = v.documents[identifier.URI]
+	if !ok {
+		var message = fmt.Sprintf("The document has not been opened: %v", identifier.URI)
+		return nil, &responseError{invalidParams, message}
+	}
+	return document, nil
+}
+
+// This private method decodes the specified parameters into the specified
+// arguments.
+func (v *server) decode(params jsn.RawMessage, arguments any) *responseError {
+	var err = jsn.Unmarshal(params, arguments)
+	if err != nil {
+		var message = fmt.Sprintf("The parameters are invalid: %v", err)
+		return &responseError{invalidParams, message}
+	}
+	return nil
+}
+
+// This private method sends a response with the specified identifier and
+// either the specified result or the specified error to the client.
+func (v *server) respond(id *jsn.RawMessage, result any, responseError *responseError) {
+	var response = &message{ID: id, Error: responseError}
+	if id == nil {
+		// The identifier of a message that could not be read must be null.
+		var null = jsn.RawMessage("null")
+		response.ID = &null
+	}
+	if responseError == nil {
+		response.Result = v.encode(result)
+	}
+	v.send(response)
+}
+
+// This private method sends a notification with the specified method and
+// parameters to the client.
+func (v *server) notify(method string, params any) {
+	var notification = &message{Method: method, Params: v.encode(params)}
+	v.send(notification)
+}
+
+// This private method encodes the specified value as JSON. A nil value is
+// encoded as null.
+func (v *server) encode(value any) jsn.RawMessage {
+	var bytes, err = jsn.Marshal(value)
+	if err != nil {
+		var message = fmt.Sprintf("The language server could not encode a value: %v", err)
+		panic(message)
+	}
+	return bytes
+}
+
+// This private method sends the specified message to the client.
+func (v *server) send(message *message) {
+	var err = v.connection.WriteMessage(message)
+	if err != nil {
+		var message = fmt.Sprintf("The language server could not write to the client: %v", err)
+		panic(message)
+	}
+}
@@ -0,0 +1,208 @@
+/*******************************************************************************
+ *   Copyright (c) 2009-2023 Crater Dog Technologies™.  All Rights Reserved.   *
+ *******************************************************************************
+ * DO NOT ALTER OR REMOVE COPYRIGHT NOTICES OR THIS FILE HEADER.               *
+ *                                                                             *
+ * This code is free software; you can redistribute it and/or modify it under  *
+ * the terms of The MIT License (MIT), as published by the Open Source         *
+ * Initiative. (See http://opensource.org/licenses/MIT)                        *
+ *******************************************************************************/
+
+package main
+
+import (
+	byt "bytes"
+	jsn "encoding/json"
+	ass "github.com/stretchr/testify/assert"
+	ioo "io"
+	tes "testing"
+)
+
+const uri = "file:///test.bali"
+
+const source = `[
+    $name: "Bali"
+    $customer: [
+        $address: [
+            $city: "Boulder"
+            $zip: 80301
+        ]
+        $rank: 1
+    ]
+    $method: {
+        with each $item in items do {
+            let $total := total + item
+        }
+        return total
+    }
+]
+`
+
+// This function sends the specified messages to a new server and returns the
+// messages that the server sent back along with its exit code.
+func serve(t *tes.T, messages ...string) ([]*message, int) {
+	var input byt.Buffer
+	var client = Connection(&byt.Buffer{}, &input)
+	for _, content := range messages {
+		var message = &message{}
+		ass.Nil(t, jsn.Unmarshal([]byte(content), message))
+		ass.Nil(t, client.WriteMessage(message))
+	}
+	var output byt.Buffer
+	var code = Server(Connection(&input, &output)).Serve()
+	var results []*message
+	var reader = Connection(&output, ioo.Discard)
+	for {
+		var message, err = reader.ReadMessage()
+		if err != nil {
+			break
+		}
+		results = append(results, message)
+	}
+	return results, code
+}
+
+// This function returns the source of a didOpen notification for the specified
+// text.
+func didOpen(text string) string {
+	var bytes, _ = jsn.Marshal(text)
+	return `{"method": "textDocument/didOpen", "params": {"textDocument": {"uri": "` +
+		uri + `", "version": 1, "text": ` + string(bytes) + `}}}`
+}
+
+func TestLifecycle(t *tes.T) {
+	var messages, code = serve(t,
+		`{"id": 1, "method": "initialize", "params": {}}`,
+		`{"method": "initialized", "params": {}}`,
+		`{"id": 2, "method": "unknown", "params": {}}`,
+		`{"id": 3, "method": "shutdown"}`,
+		`{"method": "exit"}`)
+	ass.Equal(t, 0, code)
+	ass.Equal(t, 3, len(messages))
+	ass.Contains(t, string(messages[0].Result), `"hoverProvider":true`)
+	ass.Equal(t, methodNotFound, messages[1].Error.Code)
+	ass.Equal(t, "null", string(messages[2].Result))
+
+	_, code = serve(t, `{"method": "exit"}`)
+	ass.Equal(t, 1, code)
+}
+
+func TestDiagnostics(t *tes.T) {
+	var messages, _ = serve(t, didOpen(source), didOpen("[\n    $name: \n]\n"))
+	ass.Equal(t, 2, len(messages))
+	ass.Equal(t, "textDocument/publishDiagnostics", messages[0].Method)
+
+	var params publishDiagnosticsParams
+	ass.Nil(t, jsn.Unmarshal(messages[0].Params, &params))
+	ass.Equal(t, 0, len(params.Diagnostics))
+
+	ass.Nil(t, jsn.Unmarshal(messages[1].Params, &params))
+	ass.Equal(t, 1, len(params.Diagnostics))
+	var diagnostic = params.Diagnostics[0]
+	ass.Equal(t, position{Line: 1, Character: 11}, diagnostic.Range.Start)
+	ass.Contains(t, diagnostic.Message, "Was expecting 'value'")
+}
+
+func TestEmptyDocument(t *tes.T) {
+	var messages, _ = serve(t, didOpen(""),
+		`{"id": 1, "method": "textDocument/formatting", "params": {"textDocument": {"uri": "`+uri+`"}}}`)
+	ass.Equal(t, 2, len(messages))
+
+	var params publishDiagnosticsParams
+	ass.Nil(t, jsn.Unmarshal(messages[0].Params, &params))
+	ass.Equal(t, 1, len(params.Diagnostics))
+	var diagnostic = params.Diagnostics[0]
+	ass.Equal(t, textRange{}, diagnostic.Range)
+	ass.Contains(t, diagnostic.Message, "The end of the document was reached unexpectedly.")
+
+	ass.Nil(t, messages[1].Error)
+	ass.Equal(t, "[]", string(messages[1].Result))
+}
+
+func TestFormatting(t *tes.T) {
+	var document = Document(uri, 1, []byte(source))
+	ass.Equal(t, 0, len(document.GetFormattingEdits()))
+
+	document = Document(uri, 1, []byte("[$x: 1,   $y: 2]\n"))
+	var edits = document.GetFormattingEdits()
+	ass.Equal(t, 1, len(edits))
+	ass.Equal(t, "[\n    $x: 1\n    $y: 2\n]\n", edits[0].NewText)
+	ass.Equal(t, textRange{End: position{Line: 1}}, edits[0].Range)
+
+	document = Document(uri, 1, []byte("[$x: \n"))
+	ass.Equal(t, 0, len(document.GetFormattingEdits()))
+}
+
+func TestHover(t *tes.T) {
+	var document = Document(uri, 1, []byte(source))
+	var hover = document.GetHover(position{Line: 13, Character: 9})
+	ass.NotNil(t, hover)
+	ass.Contains(t, hover.Contents.Value, "$returnClause: \"return\" result")
+	ass.Equal(t, textRange{
+		Start: position{Line: 13, Character: 8},
+		End:   position{Line: 13, Character: 14},
+	}, hover.Range)
+
+	hover = document.GetHover(position{Line: 1, Character: 6})
+	ass.Contains(t, hover.Contents.Value, "$SYMBOL: '$' IDENTIFIER")
+
+	ass.Nil(t, document.GetHover(position{Line: 1, Character: 1}))
+}
+
+func TestDefinition(t *tes.T) {
+	var document = Document(uri, 1, []byte(source))
+	var item = textRange{
+		Start: position{Line: 10, Character: 18},
+		End:   position{Line: 10, Character: 23},
+	}
+	var definition = document.GetDefinition(position{Line: 11, Character: 35})
+	ass.NotNil(t, definition)
+	ass.Equal(t, uri, definition.URI)
+	ass.Equal(t, item, definition.Range)
+
+	definition = document.GetDefinition(position{Line: 10, Character: 20})
+	ass.Equal(t, item, definition.Range)
+
+	definition = document.GetDefinition(position{Line: 13, Character: 16})
+	ass.Equal(t, textRange{
+		Start: position{Line: 11, Character: 16},
+		End:   position{Line: 11, Character: 22},
+	}, definition.Range)
+
+	ass.Nil(t, document.GetDefinition(position{Line: 10, Character: 28}))
+}
+
+func TestOutline(t *tes.T) {
+	var document = Document(uri, 1, []byte(source))
+	var outline = document.GetOutline()
+	ass.Equal(t, 3, len(outline))
+	ass.Equal(t, "$name", outline[0].Name)
+	ass.Equal(t, `"Bali"`, outline[0].Detail)
+	ass.Equal(t, symbolField, outline[0].Kind)
+	ass.Equal(t, textRange{
+		Start: position{Line: 1, Character: 4},
+		End:   position{Line: 1, Character: 17},
+	}, outline[0].Range)
+	ass.Equal(t, textRange{
+		Start: position{Line: 1, Character: 4},
+		End:   position{Line: 1, Character: 9},
+	}, outline[0].SelectionRange)
+
+	var customer = outline[1]
+	ass.Equal(t, "$customer", customer.Name)
+	ass.Equal(t, symbolObject, customer.Kind)
+	ass.Equal(t, "$address", customer.Children[0].Name)
+	ass.Equal(t, "$city", customer.Children[0].Children[0].Name)
+	ass.Equal(t, 8, customer.Range.End.Line)
+
+	ass.Equal(t, symbolFunction, outline[2].Kind)
+	ass.Equal(t, 0, len(outline[2].Children))
+}
+
+func TestUnicodePositions(t *tes.T) {
+	var document = Document(uri, 1, []byte("[$π: \"𝄞\", $x: 1]\n"))
+	var outline = document.GetOutline()
+	ass.Equal(t, 2, len(outline))
+	ass.Equal(t, position{Line: 0, Character: 11}, outline[1].SelectionRange.Start)
+	ass.Equal(t, 14, document.offsetOf(position{Line: 0, Character: 11}))
+}
@@ -0,0 +1,59 @@
+/*******************************************************************************
+ *   Copyright (c) 2009-2023 Crater Dog Technologies™.  All Rights Reserved.   *
+ *******************************************************************************
+ * DO NOT ALTER OR REMOVE COPYRIGHT NOTICES OR THIS FILE HEADER.               *
+ *                                                                             *
+ * This code is free software; you can redistribute it and/or modify it under  *
+ * the terms of The MIT License (MIT), as published by the Open Source         *
+ * Initiative. (See http://opensource.org/licenses/MIT)                        *
+ *******************************************************************************/
+
+package main
+
+import (
+	bal "github.com/bali-nebula/go-component-framework/v2/bali"
+)
+
+// CHECK DEFINITIONS
+
+// This constant defines the description of the check subcommand.
+const checkDescription = `Check validates the syntax of each Bali document and reports the syntax
+errors that it finds. With no paths, the standard input is checked. The exit
+code is 0 if every document is valid, 1 if any document is invalid and 2 if a
+document could not be read.`
+
+// CHECK IMPLEMENTATION
+
+// This private method runs the check subcommand with the specified arguments.
+func (v *command) runCheck(arguments []string) int {
+	var flags = v.flagSet("check", "[path ...]", checkDescription)
+	if flags.Parse(arguments) != nil {
+		return exitUsage
+	}
+	var paths = flags.Args()
+	if len(paths) == 0 {
+		paths = []string{""}
+	}
+	var code = exitSuccess
+	for _, path := range paths {
+		code = max(code, v.checkDocument(path))
+	}
+	return code
+}
+
+// This private method checks the syntax of the document with the specified
+// path, or the standard input if the path is empty, and returns the exit code.
+func (v *command) checkDocument(path string) int {
+	var name = documentName(path)
+	var source, failure = v.readSource(path)
+	if failure != nil {
+		v.reportError(name, failure)
+		return exitUsage
+	}
+	_, failure = bal.TryParseDocument(source)
+	if failure != nil {
+		v.reportError(name, failure)
+		return exitInvalid
+	}
+	return exitSuccess
+}
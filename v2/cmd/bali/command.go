@@ -0,0 +1,150 @@
+/*******************************************************************************
+ *   Copyright (c) 2009-2023 Crater Dog Technologies™.  All Rights Reserved.   *
+ *******************************************************************************
+ * DO NOT ALTER OR REMOVE COPYRIGHT NOTICES OR THIS FILE HEADER.               *
+ *                                                                             *
+ * This code is free software; you can redistribute it and/or modify it under  *
+ * the terms of The MIT License (MIT), as published by the Open Source         *
+ * Initiative. (See http://opensource.org/licenses/MIT)                        *
+ *******************************************************************************/
+
+package main
+
+import (
+	erx "errors"
+	fla "flag"
+	fmt "fmt"
+	bal "github.com/bali-nebula/go-component-framework/v2/bali"
+	ioo "io"
+	osx "os"
+)
+
+// COMMAND DEFINITIONS
+
+// These constants define the exit codes returned by each subcommand.
+const (
+	exitSuccess = 0 // The subcommand succeeded.
+	exitInvalid = 1 // A document was invalid or could not be processed.
+	exitUsage   = 2 // The command line or a file could not be used.
+)
+
+// This constant names the standard input in messages.
+const standardInput = "<standard input>"
+
+// This constant defines the usage message for the command.
+const usage = `Usage: bali <command> [arguments]
+
+The commands are:
+
+    fmt      rewrite documents in their canonical form
+    check    validate the syntax of documents
+    convert  translate a document between Bali and another format
+    eval     evaluate an expression or procedure
+
+Use "bali <command> -h" for more information about a command.
+`
+
+// COMMAND IMPLEMENTATION
+
+// This constructor creates a new command that reads from the specified
+// standard input and writes to the specified standard output and error.
+func Command(stdin ioo.Reader, stdout, stderr ioo.Writer) *command {
+	var v = &command{stdin: stdin, stdout: stdout, stderr: stderr}
+	return v
+}
+
+// This type defines the structure and methods for the bali command.
+type command struct {
+	stdin  ioo.Reader
+	stdout ioo.Writer
+	stderr ioo.Writer
+}
+
+// This method runs the subcommand named by the first of the specified
+// arguments and returns the exit code for the process.
+func (v *command) Run(arguments []string) int {
+	if len(arguments) == 0 {
+		fmt.Fprint(v.stderr, usage)
+		return exitUsage
+	}
+	var name, rest = arguments[0], arguments[1:]
+	switch name {
+	case "fmt":
+		return v.runFormat(rest)
+	case "check":
+		return v.runCheck(rest)
+	case "convert":
+		return v.runConvert(rest)
+	case "eval":
+		return v.runEval(rest)
+	case "help", "-h", "-help", "--help":
+		fmt.Fprint(v.stdout, usage)
+		return exitSuccess
+	default:
+		fmt.Fprintf(v.stderr, "bali: unknown command %q\n\n%v", name, usage)
+		return exitUsage
+	}
+}
+
+// PRIVATE METHODS
+
+// This private method returns a new flag set for the specified subcommand
+// whose usage message shows the specified synopsis and description.
+func (v *command) flagSet(name, synopsis, description string) *fla.FlagSet {
+	var flags = fla.NewFlagSet(name, fla.ContinueOnError)
+	flags.SetOutput(v.stderr)
+	flags.Usage = func() {
+		fmt.Fprintf(v.stderr, "Usage: bali %v %v\n\n%v\n", name, synopsis, description)
+		flags.PrintDefaults()
+	}
+	return flags
+}
+
+// This private method returns the bytes in the file with the specified path,
+// or in the standard input if the path is empty or "-".
+func (v *command) readSource(path string) ([]byte, error) {
+	if path == "" || path == "-" {
+		return ioo.ReadAll(v.stdin)
+	}
+	return osx.ReadFile(path)
+}
+
+// This private method reports the specified error for the document with the
+// specified name. A syntax error is reported with the line and position of the
+// offending token followed by the surrounding lines and expected grammar.
+func (v *command) reportError(name string, failure error) {
+	var syntaxError *bal.SyntaxError
+	if erx.As(failure, &syntaxError) {
+		fmt.Fprintf(v.stderr, "%v:%v:%v: %v", name, syntaxError.Line, syntaxError.Position, syntaxError.Render(false))
+		return
+	}
+	fmt.Fprintf(v.stderr, "%v: %v\n", name, failure)
+}
+
+// PRIVATE FUNCTIONS
+
+// This private function returns the name of the document with the specified
+// path as it is used in messages.
+func documentName(path string) string {
+	if path == "" || path == "-" {
+		return standardInput
+	}
+	return path
+}
+
+// This private function calls the specified function and returns any panic that
+// it raises as an error.
+func recoverPanic(function func()) (failure error) {
+	defer func() {
+		if e := recover(); e != nil {
+			switch actual := e.(type) {
+			case error:
+				failure = actual
+			default:
+				failure = fmt.Errorf("%v", actual)
+			}
+		}
+	}()
+	function()
+	return nil
+}
@@ -0,0 +1,262 @@
+/*******************************************************************************
+ *   Copyright (c) 2009-2023 Crater Dog Technologies™.  All Rights Reserved.   *
+ *******************************************************************************
+ * DO NOT ALTER OR REMOVE COPYRIGHT NOTICES OR THIS FILE HEADER.               *
+ *                                                                             *
+ * This code is free software; you can redistribute it and/or modify it under  *
+ * the terms of The MIT License (MIT), as published by the Open Source         *
+ * Initiative. (See http://opensource.org/licenses/MIT)                        *
+ *******************************************************************************/
+
+package main
+
+import (
+	byt "bytes"
+	fmt "fmt"
+	ass "github.com/stretchr/testify/assert"
+	osx "os"
+	pth "path/filepath"
+	sts "strings"
+	tes "testing"
+)
+
+const messy = "[$x: 1,   $y: 2]\n"
+
+const canonical = `[
+    $x: 1
+    $y: 2
+]
+`
+
+// This function runs the bali command with the specified standard input and
+// arguments and returns its exit code, standard output and standard error.
+func run(stdin string, arguments ...string) (int, string, string) {
+	var stdout, stderr byt.Buffer
+	var command = Command(sts.NewReader(stdin), &stdout, &stderr)
+	var code = command.Run(arguments)
+	return code, stdout.String(), stderr.String()
+}
+
+// This function writes the specified files into a new temporary directory and
+// returns the path of the directory.
+func writeFiles(t *tes.T, files map[string]string) string {
+	var directory = t.TempDir()
+	for name, content := range files {
+		var path = pth.Join(directory, name)
+		ass.Nil(t, osx.MkdirAll(pth.Dir(path), 0755))
+		ass.Nil(t, osx.WriteFile(path, []byte(content), 0644))
+	}
+	return directory
+}
+
+func readFile(t *tes.T, path string) string {
+	var bytes, err = osx.ReadFile(path)
+	ass.Nil(t, err)
+	return string(bytes)
+}
+
+func TestUsage(t *tes.T) {
+	var code, _, stderr = run("")
+	ass.Equal(t, exitUsage, code)
+	ass.Contains(t, stderr, "Usage: bali <command>")
+
+	code, _, stderr = run("", "unknown")
+	ass.Equal(t, exitUsage, code)
+	ass.Contains(t, stderr, `unknown command "unknown"`)
+
+	code, _, _ = run("", "fmt", "-x")
+	ass.Equal(t, exitUsage, code)
+}
+
+func TestFormatStandardInput(t *tes.T) {
+	var code, stdout, _ = run(messy, "fmt")
+	ass.Equal(t, exitSuccess, code)
+	ass.Equal(t, canonical, stdout)
+
+	code, stdout, _ = run(messy, "fmt", "-l")
+	ass.Equal(t, exitSuccess, code)
+	ass.Equal(t, "<standard input>\n", stdout)
+
+	code, _, stderr := run("[$x: ]\n", "fmt")
+	ass.Equal(t, exitInvalid, code)
+	ass.Contains(t, stderr, "<standard input>:1:6: ")
+}
+
+func TestFormatFiles(t *tes.T) {
+	var directory = writeFiles(t, map[string]string{
+		"messy.bali":      messy,
+		"nested/ok.bali":  canonical,
+		"nested/bad.bali": "[$x: ]\n",
+		"other.txt":       messy,
+	})
+	var messyPath = pth.Join(directory, "messy.bali")
+	var okPath = pth.Join(directory, "nested", "ok.bali")
+	var badPath = pth.Join(directory, "nested", "bad.bali")
+
+	var code, stdout, stderr = run("", "fmt", "-l", directory)
+	ass.Equal(t, exitInvalid, code)
+	ass.Equal(t, messyPath+"\n", stdout)
+	ass.Contains(t, stderr, badPath+":1:6: ")
+	ass.Equal(t, messy, readFile(t, messyPath))
+
+	code, stdout, _ = run("", "fmt", "-d", messyPath, okPath)
+	ass.Equal(t, exitSuccess, code)
+	ass.Equal(t, "diff "+messyPath+".orig "+messyPath+"\n"+
+		"--- "+messyPath+".orig\n"+
+		"+++ "+messyPath+"\n"+
+		"@@ -1 +1,4 @@\n"+
+		"-[$x: 1,   $y: 2]\n"+
+		"+[\n"+
+		"+    $x: 1\n"+
+		"+    $y: 2\n"+
+		"+]\n", stdout)
+	ass.Equal(t, messy, readFile(t, messyPath))
+
+	code, stdout, _ = run("", "fmt", messyPath, okPath)
+	ass.Equal(t, exitSuccess, code)
+	ass.Equal(t, "", stdout)
+	ass.Equal(t, canonical, readFile(t, messyPath))
+	ass.Equal(t, messy, readFile(t, pth.Join(directory, "other.txt")))
+
+	code, _, _ = run("", "fmt", pth.Join(directory, "missing.bali"))
+	ass.Equal(t, exitUsage, code)
+}
+
+func TestCheck(t *tes.T) {
+	var directory = writeFiles(t, map[string]string{
+		"good.bali": canonical,
+		"bad.bali":  "[\n    $x: \n]\n",
+	})
+	var code, _, stderr = run("", "check", pth.Join(directory, "good.bali"))
+	ass.Equal(t, exitSuccess, code)
+	ass.Equal(t, "", stderr)
+
+	code, _, stderr = run("", "check", pth.Join(directory, "good.bali"), pth.Join(directory, "bad.bali"))
+	ass.Equal(t, exitInvalid, code)
+	ass.Contains(t, stderr, pth.Join(directory, "bad.bali")+":2:9: An unexpected token")
+	ass.Contains(t, stderr, "Was expecting 'value' from:")
+
+	code, _, _ = run(messy, "check")
+	ass.Equal(t, exitSuccess, code)
+
+	code, _, _ = run("", "check", pth.Join(directory, "missing.bali"))
+	ass.Equal(t, exitUsage, code)
+}
+
+func TestConvert(t *tes.T) {
+	var code, json, _ = run(messy, "convert", "-to", "json")
+	ass.Equal(t, exitSuccess, code)
+	ass.Equal(t, "{\n    \"x\": 1,\n    \"y\": 2\n}\n", json)
+
+	code, stdout, _ := run(json, "convert", "-from", "json", "-to", "bali")
+	ass.Equal(t, exitSuccess, code)
+	ass.Equal(t, canonical, stdout)
+
+	var directory = t.TempDir()
+	var xmlPath = pth.Join(directory, "document.xml")
+	code, stdout, _ = run(messy, "convert", "-o", xmlPath)
+	ass.Equal(t, exitSuccess, code)
+	ass.Equal(t, "", stdout)
+	code, stdout, _ = run("", "convert", "-to", "bali", xmlPath)
+	ass.Equal(t, exitSuccess, code)
+	ass.Equal(t, canonical, stdout)
+
+	var binaryPath = pth.Join(directory, "document.bin")
+	code, _, _ = run(canonical, "convert", "-to", "binary", "-o", binaryPath)
+	ass.Equal(t, exitSuccess, code)
+	code, stdout, _ = run("", "convert", "-from", "binary", "-to", "bali", binaryPath)
+	ass.Equal(t, exitSuccess, code)
+	ass.Equal(t, canonical, stdout)
+
+	code, stdout, _ = run(canonical, "convert", "-to", "html")
+	ass.Equal(t, exitSuccess, code)
+	ass.True(t, sts.HasPrefix(stdout, `<pre class="bali">`))
+
+	code, _, stderr := run(canonical, "convert")
+	ass.Equal(t, exitUsage, code)
+	ass.Contains(t, stderr, "the output format must be specified")
+
+	code, _, stderr = run(canonical, "convert", "-from", "html", "-to", "bali")
+	ass.Equal(t, exitUsage, code)
+	ass.Contains(t, stderr, "the input format is not supported: html")
+
+	code, _, stderr = run("{\"x\": ", "convert", "-from", "json", "-to", "bali")
+	ass.Equal(t, exitInvalid, code)
+	ass.Contains(t, stderr, "<standard input>: ")
+}
+
+func TestEval(t *tes.T) {
+	var code, stdout, _ = run("3 * (4 + 5)\n", "eval")
+	ass.Equal(t, exitSuccess, code)
+	ass.Equal(t, "27\n", stdout)
+
+	var directory = writeFiles(t, map[string]string{
+		"procedure.bali": "{\n    let $x := 5\n    return x + 1\n}\n",
+	})
+	code, stdout, _ = run("", "eval", pth.Join(directory, "procedure.bali"))
+	ass.Equal(t, exitSuccess, code)
+	ass.Equal(t, "6\n", stdout)
+
+	code, stdout, _ = run(canonical, "eval")
+	ass.Equal(t, exitSuccess, code)
+	ass.Equal(t, canonical, stdout)
+
+	code, stdout, _ = run("{\n    let $x := 5\n}\n", "eval")
+	ass.Equal(t, exitSuccess, code)
+	ass.Equal(t, "", stdout)
+
+	code, _, stderr := run("{throw \"boom\"}\n", "eval")
+	ass.Equal(t, exitInvalid, code)
+	ass.Equal(t, "<standard input>: An unhandled exception was thrown: \"boom\"\n", stderr)
+
+	code, _, stderr = run("[\n    $x: \n]\n", "eval")
+	ass.Equal(t, exitInvalid, code)
+	ass.Contains(t, stderr, "<standard input>:2:9: ")
+
+	// An expression is parsed on its own so it cannot escape into a procedure.
+	code, stdout, stderr = run("1} {2\n", "eval")
+	ass.Equal(t, exitInvalid, code)
+	ass.Equal(t, "", stdout)
+	ass.Contains(t, stderr, "<standard input>:1:2: ")
+
+	code, _, stderr = run("3 * (4 + )\n", "eval")
+	ass.Equal(t, exitInvalid, code)
+	ass.Contains(t, stderr, "<standard input>:1:10: ")
+}
+
+func TestUnifiedDiff(t *tes.T) {
+	ass.Equal(t, "", unifiedDiff("a", "b", "same\n", "same\n"))
+
+	var old = "1\n2\n3\n4\n5\n6\n7\n8\n9\n10\n11\n12\n"
+	var new = "1\n2\nthree\n4\n5\n6\n7\n8\n9\n10\n11\n12\n13"
+	ass.Equal(t, `--- a
++++ b
+@@ -1,6 +1,6 @@
+ 1
+ 2
+-3
++three
+ 4
+ 5
+ 6
+@@ -10,3 +10,4 @@
+ 10
+ 11
+ 12
++13
+\ No newline at end of file
+`, unifiedDiff("a", "b", old, new))
+}
+
+func TestUnifiedDiffLargeDocuments(t *tes.T) {
+	// Two documents with no lines in common need memory in proportion to their
+	// size rather than to the square of it.
+	var old, new sts.Builder
+	for line := 0; line < 5000; line++ {
+		fmt.Fprintf(&old, "old %v\n", line)
+		fmt.Fprintf(&new, "new %v\n", line)
+	}
+	var diff = unifiedDiff("a", "b", old.String(), new.String())
+	ass.True(t, sts.HasPrefix(diff, "--- a\n+++ b\n@@ -1,5000 +1,5000 @@\n-old 0\n"))
+	ass.Equal(t, 10004, len(sts.Split(diff, "\n")))
+}
@@ -0,0 +1,154 @@
+/*******************************************************************************
+ *   Copyright (c) 2009-2023 Crater Dog Technologies™.  All Rights Reserved.   *
+ *******************************************************************************
+ * DO NOT ALTER OR REMOVE COPYRIGHT NOTICES OR THIS FILE HEADER.               *
+ *                                                                             *
+ * This code is free software; you can redistribute it and/or modify it under  *
+ * the terms of The MIT License (MIT), as published by the Open Source         *
+ * Initiative. (See http://opensource.org/licenses/MIT)                        *
+ *******************************************************************************/
+
+package main
+
+import (
+	fmt "fmt"
+	abs "github.com/bali-nebula/go-component-framework/v2/abstractions"
+	bal "github.com/bali-nebula/go-component-framework/v2/bali"
+	osx "os"
+	pth "path/filepath"
+	sor "sort"
+	sts "strings"
+)
+
+// CONVERT DEFINITIONS
+
+// This constant defines the description of the convert subcommand.
+const convertDescription = `Convert translates a document from one format into another. With no path,
+the standard input is converted. The formats are inferred from the extensions
+of the input and output files when they are not specified, and the input is
+assumed to be Bali if its format is unknown. The supported formats are:
+
+    bali           Bali Document Notation (.bali)
+    json           idiomatic JSON (.json)
+    lossless-json  JSON that preserves every type, context and note
+    xml            XML (.xml)
+    html           syntax highlighted Bali in HTML (.html, output only)
+    binary         the compact binary encoding`
+
+// This type defines the functions that parse and format a document in a
+// specific serialization format. A format that can only be produced has no
+// parse function.
+type serialization struct {
+	parse  func(document []byte) abs.ComponentLike
+	format func(component abs.ComponentLike) []byte
+}
+
+// This map defines the supported serialization formats indexed by their names.
+var serializations = map[string]serialization{
+	"bali":          {bal.ParseDocument, bal.FormatDocument},
+	"json":          {bal.ParseJSON, bal.FormatJSON},
+	"lossless-json": {bal.ParseLosslessJSON, bal.FormatLosslessJSON},
+	"xml":           {bal.ParseXML, bal.FormatXML},
+	"html":          {nil, bal.FormatHTML},
+	"binary":        {bal.DecodeComponent, bal.EncodeComponent},
+}
+
+// This map defines the serialization formats implied by each file extension.
+var extensions = map[string]string{
+	".bali": "bali",
+	".json": "json",
+	".xml":  "xml",
+	".html": "html",
+	".htm":  "html",
+}
+
+// CONVERT IMPLEMENTATION
+
+// This private method runs the convert subcommand with the specified arguments.
+func (v *command) runConvert(arguments []string) int {
+	var flags = v.flagSet("convert", "[-from format] [-to format] [-o file] [path]", convertDescription)
+	var from = flags.String("from", "", "the format of the input document")
+	var to = flags.String("to", "", "the format of the output document")
+	var output = flags.String("o", "", "the output file instead of the standard output")
+	if flags.Parse(arguments) != nil {
+		return exitUsage
+	}
+	if flags.NArg() > 1 {
+		flags.Usage()
+		return exitUsage
+	}
+	var path = flags.Arg(0)
+	var name = documentName(path)
+	if *from == "" {
+		*from = extensions[sts.ToLower(pth.Ext(path))]
+		if *from == "" {
+			*from = "bali"
+		}
+	}
+	if *to == "" {
+		*to = extensions[sts.ToLower(pth.Ext(*output))]
+		if *to == "" {
+			fmt.Fprintln(v.stderr, "bali convert: the output format must be specified using -to")
+			return exitUsage
+		}
+	}
+	var input, ok = serializations[*from]
+	if !ok || input.parse == nil {
+		fmt.Fprintf(v.stderr, "bali convert: the input format is not supported: %v (%v)\n", *from, formatNames(true))
+		return exitUsage
+	}
+	var target serialization
+	target, ok = serializations[*to]
+	if !ok {
+		fmt.Fprintf(v.stderr, "bali convert: the output format is not supported: %v (%v)\n", *to, formatNames(false))
+		return exitUsage
+	}
+
+	var source, failure = v.readSource(path)
+	if failure != nil {
+		v.reportError(name, failure)
+		return exitUsage
+	}
+	var component abs.ComponentLike
+	if *from == "bali" {
+		component, failure = bal.TryParseDocument(source)
+	} else {
+		failure = recoverPanic(func() { component = input.parse(source) })
+	}
+	if failure != nil {
+		v.reportError(name, failure)
+		return exitInvalid
+	}
+	var document []byte
+	failure = recoverPanic(func() { document = target.format(component) })
+	if failure != nil {
+		v.reportError(name, failure)
+		return exitInvalid
+	}
+
+	if *output == "" {
+		v.stdout.Write(document)
+		return exitSuccess
+	}
+	failure = osx.WriteFile(*output, document, 0644)
+	if failure != nil {
+		v.reportError(*output, failure)
+		return exitUsage
+	}
+	return exitSuccess
+}
+
+// PRIVATE FUNCTIONS
+
+// This private function returns the sorted names of the supported formats that
+// can be parsed, if parsed is true, or formatted otherwise.
+func formatNames(parsed bool) string {
+	var names []string
+	for name, serialization := range serializations {
+		if !parsed || serialization.parse != nil {
+			names = append(names, name)
+		}
+	}
+	sor.Strings(names)
+	return sts.Join(names, ", ")
+}
@@ -0,0 +1,256 @@
+/*******************************************************************************
+ *   Copyright (c) 2009-2023 Crater Dog Technologies™.  All Rights Reserved.   *
+ *******************************************************************************
+ * DO NOT ALTER OR REMOVE COPYRIGHT NOTICES OR THIS FILE HEADER.               *
+ *                                                                             *
+ * This code is free software; you can redistribute it and/or modify it under  *
+ * the terms of The MIT License (MIT), as published by the Open Source         *
+ * Initiative. (See http://opensource.org/licenses/MIT)                        *
+ *******************************************************************************/
+
+package main
+
+import (
+	fmt "fmt"
+	sor "sort"
+	sts "strings"
+)
+
+// DIFF DEFINITIONS
+
+// This constant defines the number of unchanged lines shown around each change
+// in a unified diff.
+const diffContext = 3
+
+// This type defines a single line in the edit script between two documents.
+// The operation is ' ' for an unchanged line, '-' for a deleted line and '+'
+// for an inserted line.
+type lineEdit struct {
+	operation byte
+	line      string
+}
+
+// PRIVATE FUNCTIONS
+
+// This private function returns the unified diff between the specified old
+// and new documents using the specified names in its header. It returns an
+// empty string if the documents are the same.
+func unifiedDiff(oldName, newName, oldText, newText string) string {
+	var edits = diffLines(splitLines(oldText), splitLines(newText))
+	var result sts.Builder
+	var oldLine, newLine int // The number of lines before the current edit.
+	for first := 0; first < len(edits); {
+		// Find the next change and the run of changes that are close to it.
+		var start = first
+		for start < len(edits) && edits[start].operation == ' ' {
+			start++
+		}
+		if start == len(edits) {
+			break
+		}
+		var end = start
+		for next := start; next < len(edits); next++ {
+			if edits[next].operation != ' ' {
+				end = next + 1
+			} else if next-end >= 2*diffContext {
+				break
+			}
+		}
+
+		// Include the unchanged lines surrounding the changes in the hunk.
+		var hunkStart = max(first, start-diffContext)
+		var hunkEnd = min(len(edits), end+diffContext)
+		oldLine += hunkStart - first // The skipped lines are all unchanged.
+		newLine += hunkStart - first
+		var oldCount, newCount int
+		var lines sts.Builder
+		for _, edit := range edits[hunkStart:hunkEnd] {
+			if edit.operation != '+' {
+				oldCount++
+			}
+			if edit.operation != '-' {
+				newCount++
+			}
+			lines.WriteByte(edit.operation)
+			lines.WriteString(edit.line)
+			if !sts.HasSuffix(edit.line, "\n") {
+				lines.WriteString("\n\\ No newline at end of file\n")
+			}
+		}
+		if result.Len() == 0 {
+			fmt.Fprintf(&result, "--- %v\n+++ %v\n", oldName, newName)
+		}
+		fmt.Fprintf(&result, "@@ -%v +%v @@\n", hunkRange(oldLine, oldCount), hunkRange(newLine, newCount))
+		result.WriteString(lines.String())
+		oldLine += oldCount
+		newLine += newCount
+		first = hunkEnd
+	}
+	return result.String()
+}
+
+// This private function returns the range of lines in a hunk header for a hunk
+// with the specified number of lines that follows the specified number of
+// lines.
+func hunkRange(before, count int) string {
+	switch count {
+	case 0:
+		return fmt.Sprintf("%v,0", before)
+	case 1:
+		return fmt.Sprintf("%v", before+1)
+	default:
+		return fmt.Sprintf("%v,%v", before+1, count)
+	}
+}
+
+// This private function splits the specified text into lines that each retain
+// their trailing EOL character. Only the last line may lack one.
+func splitLines(text string) []string {
+	var lines = sts.SplitAfter(text, "\n")
+	if lines[len(lines)-1] == "" {
+		lines = lines[:len(lines)-1]
+	}
+	return lines
+}
+
+// This private function returns the shortest edit script that transforms the
+// specified old lines into the specified new lines. It uses the linear space
+// refinement of the algorithm described by Eugene Myers in "An O(ND) Difference
+// Algorithm and Its Variations", which splits the lines at the middle of a
+// shortest edit script and then diffs each half, so the memory it uses is
+// proportional to the number of lines rather than to the number of lines times
+// the number of differences. The deleted lines in each run of changes precede
+// the inserted lines.
+func diffLines(old, new []string) []lineEdit {
+	var edits = appendEdits(make([]lineEdit, 0, len(old)+len(new)), old, new)
+	for first := 0; first < len(edits); first++ {
+		if edits[first].operation == ' ' {
+			continue
+		}
+		var last = first
+		for last < len(edits) && edits[last].operation != ' ' {
+			last++
+		}
+		sor.SliceStable(edits[first:last], func(i, j int) bool {
+			return edits[first+i].operation == '-' && edits[first+j].operation == '+'
+		})
+		first = last
+	}
+	return edits
+}
+
+// This private function appends to the specified edits the shortest edit script
+// that transforms the specified old lines into the specified new lines.
+func appendEdits(edits []lineEdit, old, new []string) []lineEdit {
+	// The lines that the old and new lines start and end with are unchanged.
+	var prefix = 0
+	for prefix < len(old) && prefix < len(new) && old[prefix] == new[prefix] {
+		prefix++
+	}
+	var suffix = 0
+	for suffix < len(old)-prefix && suffix < len(new)-prefix && old[len(old)-suffix-1] == new[len(new)-suffix-1] {
+		suffix++
+	}
+	for _, line := range old[:prefix] {
+		edits = append(edits, lineEdit{' ', line})
+	}
+	var unchanged = old[len(old)-suffix:]
+	old, new = old[prefix:len(old)-suffix], new[prefix:len(new)-suffix]
+
+	// The remaining lines are split where a shortest edit script crosses its
+	// middle and each half is diffed separately.
+	if x, y, ok := bisectLines(old, new); ok && x+y > 0 && x+y < len(old)+len(new) {
+		edits = appendEdits(edits, old[:x], new[:y])
+		edits = appendEdits(edits, old[x:], new[y:])
+	} else {
+		for _, line := range old {
+			edits = append(edits, lineEdit{'-', line})
+		}
+		for _, line := range new {
+			edits = append(edits, lineEdit{'+', line})
+		}
+	}
+	for _, line := range unchanged {
+		edits = append(edits, lineEdit{' ', line})
+	}
+	return edits
+}
+
+// This private function returns the point at which a shortest edit script that
+// transforms the specified old lines into the specified new lines crosses its
+// middle. The forward and reverse searches for the furthest reaching paths
+// proceed together, one edit at a time, until they overlap. It returns false if
+// the old and new lines have no lines in common, in which case every old line
+// is deleted and every new line is inserted. The old and new lines must differ
+// in their first and last lines.
+func bisectLines(old, new []string) (x, y int, ok bool) {
+	var n, m = len(old), len(new)
+	if n == 0 || m == 0 {
+		return 0, 0, false
+	}
+	var limit = (n + m + 1) / 2
+	var offset = limit + 1
+	var forward = make([]int, 2*offset+1) // The furthest x reached on each diagonal.
+	var reverse = make([]int, 2*offset+1) // The same from the ends of the lines.
+	for index := range forward {
+		forward[index] = -1
+		reverse[index] = -1
+	}
+	forward[offset+1] = 0
+	reverse[offset+1] = 0
+	var delta = n - m
+	var odd = delta%2 != 0
+	var forwardStart, forwardEnd, reverseStart, reverseEnd int // The diagonals that left the edit graph.
+	for d := 0; d < limit; d++ {
+		for k := -d + forwardStart; k <= d-forwardEnd; k += 2 {
+			var x int
+			if k == -d || (k != d && forward[offset+k-1] < forward[offset+k+1]) {
+				x = forward[offset+k+1] // Move down from diagonal k+1.
+			} else {
+				x = forward[offset+k-1] + 1 // Move right from diagonal k-1.
+			}
+			var y = x - k
+			for x < n && y < m && old[x] == new[y] {
+				x, y = x+1, y+1
+			}
+			forward[offset+k] = x
+			switch {
+			case x > n:
+				forwardEnd += 2
+			case y > m:
+				forwardStart += 2
+			case odd:
+				var opposite = offset + delta - k
+				if opposite >= 0 && opposite < len(reverse) && reverse[opposite] >= 0 && x+reverse[opposite] >= n {
+					return x, y, true
+				}
+			}
+		}
+		for k := -d + reverseStart; k <= d-reverseEnd; k += 2 {
+			var x int
+			if k == -d || (k != d && reverse[offset+k-1] < reverse[offset+k+1]) {
+				x = reverse[offset+k+1]
+			} else {
+				x = reverse[offset+k-1] + 1
+			}
+			var y = x - k
+			for x < n && y < m && old[n-x-1] == new[m-y-1] {
+				x, y = x+1, y+1
+			}
+			reverse[offset+k] = x
+			switch {
+			case x > n:
+				reverseEnd += 2
+			case y > m:
+				reverseStart += 2
+			case !odd:
+				var opposite = offset + delta - k
+				if opposite >= 0 && opposite < len(forward) && forward[opposite] >= 0 && forward[opposite]+x >= n {
+					var x = forward[opposite]
+					return x, x - (delta - k), true
+				}
+			}
+		}
+	}
+	return 0, 0, false
+}
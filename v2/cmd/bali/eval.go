@@ -0,0 +1,128 @@
+/*******************************************************************************
+ *   Copyright (c) 2009-2023 Crater Dog Technologies™.  All Rights Reserved.   *
+ *******************************************************************************
+ * DO NOT ALTER OR REMOVE COPYRIGHT NOTICES OR THIS FILE HEADER.               *
+ *                                                                             *
+ * This code is free software; you can redistribute it and/or modify it under  *
+ * the terms of The MIT License (MIT), as published by the Open Source         *
+ * Initiative. (See http://opensource.org/licenses/MIT)                        *
+ *******************************************************************************/
+
+package main
+
+import (
+	erx "errors"
+	fmt "fmt"
+	abs "github.com/bali-nebula/go-component-framework/v2/abstractions"
+	age "github.com/bali-nebula/go-component-framework/v2/agents"
+	bal "github.com/bali-nebula/go-component-framework/v2/bali"
+	com "github.com/bali-nebula/go-component-framework/v2/components"
+	pro "github.com/bali-nebula/go-component-framework/v2/procedures"
+	col "github.com/craterdog/go-collection-framework/v2"
+	sts "strings"
+)
+
+// EVAL DEFINITIONS
+
+// This constant defines the description of the eval subcommand.
+const evalDescription = `Eval evaluates the expression or procedure in a file, or in the standard
+input if no path is given, and writes the resulting component in its canonical
+form. A procedure is executed and the component that it returns is written. A
+document containing any other component is its own result. Any other source is
+evaluated as an expression, for example:
+
+    echo '3 * (4 + 5)' | bali eval`
+
+// EVAL IMPLEMENTATION
+
+// This private method runs the eval subcommand with the specified arguments.
+func (v *command) runEval(arguments []string) int {
+	var flags = v.flagSet("eval", "[path]", evalDescription)
+	if flags.Parse(arguments) != nil {
+		return exitUsage
+	}
+	if flags.NArg() > 1 {
+		flags.Usage()
+		return exitUsage
+	}
+	var path = flags.Arg(0)
+	var name = documentName(path)
+	var source, failure = v.readSource(path)
+	if failure != nil {
+		v.reportError(name, failure)
+		return exitUsage
+	}
+	var procedure abs.ProcedureLike
+	procedure, failure = parseProcedure(source)
+	if failure != nil {
+		v.reportError(name, failure)
+		return exitInvalid
+	}
+	var result, thrown = executeProcedure(procedure)
+	switch actual := thrown.(type) {
+	case nil:
+		// The procedure completed normally.
+	case abs.ComponentLike:
+		var exception = bal.FormatDocument(actual)
+		fmt.Fprintf(v.stderr, "%v: An unhandled exception was thrown: %s", name, exception)
+		return exitInvalid
+	default:
+		fmt.Fprintf(v.stderr, "%v: The evaluation failed: %v\n", name, actual)
+		return exitInvalid
+	}
+	if result != nil {
+		v.stdout.Write(bal.FormatDocument(result))
+	}
+	return exitSuccess
+}
+
+// PRIVATE FUNCTIONS
+
+// This private function returns the procedure that evaluates the specified
+// source bytes. A document containing a procedure is that procedure, and a
+// document containing any other component or an expression is parsed as an
+// expression which the procedure returns. If neither form is valid, the syntax
+// error that was found furthest into the source is returned.
+func parseProcedure(source []byte) (abs.ProcedureLike, error) {
+	var component, failure = bal.TryParseDocument(source)
+	if failure == nil {
+		if procedure, ok := component.GetEntity().(abs.ProcedureLike); ok && !component.IsParameterized() {
+			return procedure, nil
+		}
+	}
+	var document = []byte(sts.TrimRight(string(source), bal.EOL) + bal.EOL)
+	var expression, expressionFailure = bal.TryParseExpression(document)
+	if expressionFailure != nil {
+		if failure == nil || isFurther(expressionFailure, failure) {
+			failure = expressionFailure
+		}
+		return nil, failure
+	}
+	var statements = col.List[abs.StatementLike]()
+	statements.AddValue(pro.Statement(pro.ReturnClause(expression)))
+	return statements, nil
+}
+
+// This private function determines whether or not the first failure is a
+// syntax error that was found further into the source than the second one.
+func isFurther(first, second error) bool {
+	var firstError, secondError *bal.SyntaxError
+	if !erx.As(first, &firstError) || !erx.As(second, &secondError) {
+		return false
+	}
+	if firstError.Line != secondError.Line {
+		return firstError.Line > secondError.Line
+	}
+	return firstError.Position > secondError.Position
+}
+
+// This private function executes the specified procedure in an empty
+// environment and returns its result. It also returns the value of any panic,
+// such as an unhandled exception, that stopped the execution.
+func executeProcedure(procedure abs.ProcedureLike) (result abs.ComponentLike, thrown any) {
+	defer func() {
+		thrown = recover()
+	}()
+	var interpreter = age.Interpreter(com.Context())
+	return interpreter.ExecuteProcedure(procedure), nil
+}
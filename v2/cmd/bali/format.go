@@ -0,0 +1,115 @@
+/*******************************************************************************
+ *   Copyright (c) 2009-2023 Crater Dog Technologies™.  All Rights Reserved.   *
+ *******************************************************************************
+ * DO NOT ALTER OR REMOVE COPYRIGHT NOTICES OR THIS FILE HEADER.               *
+ *                                                                             *
+ * This code is free software; you can redistribute it and/or modify it under  *
+ * the terms of The MIT License (MIT), as published by the Open Source         *
+ * Initiative. (See http://opensource.org/licenses/MIT)                        *
+ *******************************************************************************/
+
+package main
+
+import (
+	byt "bytes"
+	fmt "fmt"
+	abs "github.com/bali-nebula/go-component-framework/v2/abstractions"
+	bal "github.com/bali-nebula/go-component-framework/v2/bali"
+	fil "io/fs"
+	osx "os"
+	pth "path/filepath"
+)
+
+// FORMAT DEFINITIONS
+
+// This constant defines the description of the fmt subcommand.
+const formatDescription = `Fmt rewrites each Bali document in its canonical form. A directory is
+searched recursively for files ending in ".bali". With no paths, the standard
+input is formatted to the standard output. The -l and -d flags report the
+documents whose formatting differs instead of rewriting them.`
+
+// FORMAT IMPLEMENTATION
+
+// This private method runs the fmt subcommand with the specified arguments.
+func (v *command) runFormat(arguments []string) int {
+	var flags = v.flagSet("fmt", "[-l] [-d] [path ...]", formatDescription)
+	var list = flags.Bool("l", false, "list the documents whose formatting differs")
+	var diff = flags.Bool("d", false, "display diffs instead of rewriting documents")
+	if flags.Parse(arguments) != nil {
+		return exitUsage
+	}
+	var paths = flags.Args()
+	if len(paths) == 0 {
+		return v.formatDocument("", *list, *diff)
+	}
+	var code = exitSuccess
+	for _, path := range paths {
+		var walk = func(path string, entry fil.DirEntry, failure error) error {
+			if failure != nil {
+				v.reportError(path, failure)
+				code = max(code, exitUsage)
+				return nil
+			}
+			if entry.IsDir() || pth.Ext(path) != ".bali" {
+				return nil
+			}
+			code = max(code, v.formatDocument(path, *list, *diff))
+			return nil
+		}
+		var info, failure = osx.Stat(path)
+		switch {
+		case failure != nil:
+			v.reportError(path, failure)
+			code = max(code, exitUsage)
+		case info.IsDir():
+			pth.WalkDir(path, walk)
+		default:
+			// A file that is named explicitly is formatted whatever its extension.
+			code = max(code, v.formatDocument(path, *list, *diff))
+		}
+	}
+	return code
+}
+
+// This private method formats the document with the specified path, or the
+// standard input if the path is empty, and returns the exit code.
+func (v *command) formatDocument(path string, list, diff bool) int {
+	var name = documentName(path)
+	var source, failure = v.readSource(path)
+	if failure != nil {
+		v.reportError(name, failure)
+		return exitUsage
+	}
+	var component abs.ComponentLike
+	component, failure = bal.TryParseDocument(source)
+	if failure != nil {
+		v.reportError(name, failure)
+		return exitInvalid
+	}
+	var formatted = bal.FormatDocument(component)
+	var changed = !byt.Equal(source, formatted)
+	if list && changed {
+		fmt.Fprintln(v.stdout, name)
+	}
+	if diff && changed {
+		fmt.Fprintf(v.stdout, "diff %v.orig %v\n", name, name)
+		fmt.Fprint(v.stdout, unifiedDiff(name+".orig", name, string(source), string(formatted)))
+	}
+	switch {
+	case list || diff:
+		// The document is only reported.
+	case path == "":
+		v.stdout.Write(formatted)
+	case changed:
+		var mode fil.FileMode = 0644
+		if info, failure := osx.Stat(path); failure == nil {
+			mode = info.Mode().Perm()
+		}
+		failure = osx.WriteFile(path, formatted, mode)
+		if failure != nil {
+			v.reportError(name, failure)
+			return exitUsage
+		}
+	}
+	return exitSuccess
+}
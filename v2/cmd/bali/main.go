@@ -0,0 +1,34 @@
+/*******************************************************************************
+ *   Copyright (c) 2009-2023 Crater Dog Technologies™.  All Rights Reserved.   *
+ *******************************************************************************
+ * DO NOT ALTER OR REMOVE COPYRIGHT NOTICES OR THIS FILE HEADER.               *
+ *                                                                             *
+ * This code is free software; you can redistribute it and/or modify it under  *
+ * the terms of The MIT License (MIT), as published by the Open Source         *
+ * Initiative. (See http://opensource.org/licenses/MIT)                        *
+ *******************************************************************************/
+
+// The bali command processes Bali Document Notation™ (BDN) documents. It
+// provides the following subcommands:
+//
+//	bali fmt [-l] [-d] [path ...]
+//	bali check [path ...]
+//	bali convert [-from format] [-to format] [-o file] [path]
+//	bali eval [path]
+//
+// The fmt subcommand rewrites documents in their canonical form, the check
+// subcommand validates the syntax of documents, the convert subcommand
+// translates a document between Bali and another serialization format, and the
+// eval subcommand evaluates an expression or procedure. Each subcommand reads
+// the standard input when no path is given. The exit code is 1 if a document
+// is invalid and 2 if the command line or a file could not be used.
+package main
+
+import (
+	osx "os"
+)
+
+func main() {
+	var command = Command(osx.Stdin, osx.Stdout, osx.Stderr)
+	osx.Exit(command.Run(osx.Args[1:]))
+}
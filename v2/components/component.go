@@ -39,6 +39,7 @@ type component struct {
 	entity  abs.Entity
 	context abs.ContextLike
 	note    abs.NoteLike
+	span    abs.Span
 }
 
 // ENCAPSULATED INTERFACE
@@ -71,6 +72,11 @@ func (v *component) ExtractBoolean() abs.BooleanLike {
 	return v.entity.(abs.BooleanLike)
 }
 
+// This method returns the entity for this component as a bytecode.
+func (v *component) ExtractBytecode() abs.BytecodeLike {
+	return v.entity.(abs.BytecodeLike)
+}
+
 // This method returns the entity for this component as a catalog.
 func (v *component) ExtractCatalog() abs.CatalogLike {
 	return v.entity.(abs.CatalogLike)
@@ -216,6 +222,20 @@ func (v *component) SetNote(note abs.NoteLike) {
 	v.note = note
 }
 
+// LOCATED INTERFACE
+
+// This method returns the span of source bytes from which this component was
+// parsed.
+func (v *component) GetSpan() abs.Span {
+	return v.span
+}
+
+// This method sets the span of source bytes from which this component was
+// parsed.
+func (v *component) SetSpan(span abs.Span) {
+	v.span = span
+}
+
 // COMPONENT ITERATOR IMPLEMENTATION
 
 // This constructor creates a new instance of a components iterator that can be
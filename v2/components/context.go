@@ -66,7 +66,7 @@ type parameters struct {
 // This constructor creates a new empty context.
 func Context() abs.ContextLike {
 	var v = col.Catalog[abs.SymbolLike, abs.ComponentLike]()
-	return &context{v}
+	return &context{parameters: v}
 }
 
 // This constructor creates a new context from the specified sequence of
@@ -84,13 +84,14 @@ func ContextFromSequence(sequence abs.Sequential[abs.ParameterLike]) abs.Context
 		var value = parameter.GetValue()
 		v.SetValue(key, value)
 	}
-	return &context{v}
+	return &context{parameters: v}
 }
 
 // This type defines the structure and methods associated with a context of
 // key-value pair parameters.
 type context struct {
 	parameters col.CatalogLike[abs.SymbolLike, abs.ComponentLike]
+	span       abs.Span
 }
 
 // SEQUENTIAL INTERFACE
@@ -162,3 +163,16 @@ func (v *context) RemoveValues(keys abs.Sequential[abs.SymbolLike]) abs.Sequenti
 func (v *context) RemoveAll() {
 	v.parameters.RemoveAll()
 }
+
+// LOCATED INTERFACE
+
+// This method returns the span of source bytes from which this context was
+// parsed.
+func (v *context) GetSpan() abs.Span {
+	return v.span
+}
+
+// This method sets the span of source bytes from which this context was parsed.
+func (v *context) SetSpan(span abs.Span) {
+	v.span = span
+}
@@ -314,7 +314,7 @@ func complexFromMatches(matches []string) complex128 {
 			complex_ = complex(0, -1)
 		case "+pi", "pi", "-pi", "+phi", "phi", "-phi":
 			// We must handle the constants that end in "i" separately.
-			complex_ = complex(floatFromString(matches[1]), 0)
+			complex_ = complex(floatFromString(matches[0]), 0)
 		default:
 			if sts.HasSuffix(matches[0], "i") {
 				// This is a pure imaginary number.
@@ -0,0 +1,355 @@
+/*******************************************************************************
+ *   Copyright (c) 2009-2023 Crater Dog Technologies™.  All Rights Reserved.   *
+ *******************************************************************************
+ * DO NOT ALTER OR REMOVE COPYRIGHT NOTICES OR THIS FILE HEADER.               *
+ *                                                                             *
+ * This code is free software; you can redistribute it and/or modify it under  *
+ * the terms of The MIT License (MIT), as published by the Open Source         *
+ * Initiative. (See http://opensource.org/licenses/MIT)                        *
+ *******************************************************************************/
+
+package events
+
+import (
+	fmt "fmt"
+	abs "github.com/bali-nebula/go-component-framework/v2/abstractions"
+	bal "github.com/bali-nebula/go-component-framework/v2/bali"
+	str "github.com/bali-nebula/go-component-framework/v2/strings"
+	uti "github.com/bali-nebula/go-component-framework/v2/utilities"
+	osx "os"
+	sor "sort"
+	stc "strconv"
+	sts "strings"
+	syn "sync"
+)
+
+// CONSTANT DEFINITIONS
+
+const (
+	eventSuffix = ".bali" // The suffix of a file holding a logged event.
+)
+
+// EVENT BUS IMPLEMENTATION
+
+// This constructor creates a new in-process event bus. A subscriber registers
+// with the bus either for a specific event type or for all events that match a
+// pattern. The type of an event is the value of its $type attribute if the
+// event is a catalog, or else the value of the $type parameter in its context,
+// in its Bali source form (e.g. "/acme/events/OrderPlaced/v1"). A pattern is
+// matched against the Bali source form of the entire event.
+//
+// Each published event is delivered asynchronously to every subscriber whose
+// subscription matches it at the time the event is delivered. The events from
+// each publisher are delivered one at a time in the order they were published,
+// while the events from different publishers are delivered concurrently. A
+// failure within a subscriber does not prevent delivery to the remaining
+// subscribers. Instead, the failure is passed to the failure handler of the
+// bus which, unless it has been replaced, reports it on the standard error.
+func EventBus() abs.EventBusLike {
+	return &eventBus{
+		handler:       reportFailure,
+		subscriptions: make(map[int]*subscription),
+		publishers:    make(map[string]*outbox),
+		waiting:       syn.NewCond(&syn.Mutex{}),
+	}
+}
+
+// This constructor creates a new event bus like the EventBus() constructor
+// does but also appends each published event to a persistent log in the
+// specified directory so that the events can be replayed later, even by a new
+// event bus that uses the same directory.
+func EventBusWithLog(directory string) abs.EventBusLike {
+	if len(directory) == 0 {
+		panic("An event log requires a directory.")
+	}
+	if !sts.HasSuffix(directory, "/") {
+		directory += "/"
+	}
+	var err = osx.MkdirAll(directory, 0700)
+	if err != nil {
+		var message = fmt.Sprintf("Could not create the event log directory: %v.", err)
+		panic(message)
+	}
+	var v = EventBus().(*eventBus)
+	v.directory = directory
+	for _, sequence := range v.listEvents() {
+		v.sequence = sequence
+	}
+	return v
+}
+
+// This type defines the structure and methods associated with an event bus.
+type eventBus struct {
+	mutex         syn.Mutex
+	directory     string
+	sequence      int
+	counter       int
+	handler       abs.FailureHandler
+	subscriptions map[int]*subscription
+	publishers    map[string]*outbox
+	waiting       *syn.Cond
+	pending       int
+}
+
+// This type defines the structure of a subscription to an event bus.
+type subscription struct {
+	identifier int
+	type_      string
+	pattern    abs.PatternLike
+	subscriber abs.Subscriber
+}
+
+// This type defines the structure of the queue of events from a publisher that
+// are waiting to be delivered.
+type outbox struct {
+	events []abs.ComponentLike
+}
+
+// PUBLISHABLE INTERFACE
+
+// This method publishes the specified event on behalf of the specified
+// publisher. The event is logged, if this bus has a log, before this method
+// returns.
+func (v *eventBus) PublishEvent(publisher string, event abs.ComponentLike) {
+	if event == nil {
+		panic("An event bus cannot publish a nil event.")
+	}
+	v.mutex.Lock()
+	defer v.mutex.Unlock()
+	v.logEvent(event)
+	v.waiting.L.Lock()
+	v.pending++
+	v.waiting.L.Unlock()
+	var queue, ok = v.publishers[publisher]
+	if ok {
+		queue.events = append(queue.events, event)
+		return
+	}
+	queue = &outbox{events: []abs.ComponentLike{event}}
+	v.publishers[publisher] = queue
+	go v.deliverEvents(publisher, queue)
+}
+
+// This method waits until every event that has been published so far has been
+// delivered to its subscribers.
+func (v *eventBus) AwaitDelivery() {
+	v.waiting.L.Lock()
+	defer v.waiting.L.Unlock()
+	for v.pending > 0 {
+		v.waiting.Wait()
+	}
+}
+
+// SUBSCRIBABLE INTERFACE
+
+// This method registers the specified subscriber for all events of the
+// specified type and returns the identifier of the new subscription.
+func (v *eventBus) SubscribeToType(type_ string, subscriber abs.Subscriber) int {
+	if len(type_) == 0 || subscriber == nil {
+		panic("An event subscription requires an event type and a subscriber.")
+	}
+	return v.addSubscription(&subscription{type_: type_, subscriber: subscriber})
+}
+
+// This method registers the specified subscriber for all events that match the
+// specified pattern and returns the identifier of the new subscription.
+func (v *eventBus) SubscribeToPattern(pattern abs.PatternLike, subscriber abs.Subscriber) int {
+	if pattern == nil || subscriber == nil {
+		panic("An event subscription requires a pattern and a subscriber.")
+	}
+	return v.addSubscription(&subscription{pattern: pattern, subscriber: subscriber})
+}
+
+// This method cancels the specified subscription. Any events that have not yet
+// been delivered to the subscriber will not be.
+func (v *eventBus) Unsubscribe(subscription int) {
+	v.mutex.Lock()
+	defer v.mutex.Unlock()
+	delete(v.subscriptions, subscription)
+}
+
+// This method sets the handler that is passed the event and the failure each
+// time a subscriber fails while the event is being delivered to it.
+func (v *eventBus) SetFailureHandler(handler abs.FailureHandler) {
+	if handler == nil {
+		panic("An event bus requires a failure handler.")
+	}
+	v.mutex.Lock()
+	defer v.mutex.Unlock()
+	v.handler = handler
+}
+
+// REPLAYABLE INTERFACE
+
+// This method determines whether or not this event bus has a persistent log.
+func (v *eventBus) IsLogged() bool {
+	return len(v.directory) > 0
+}
+
+// This method delivers each event in the persistent log of this event bus to
+// the specified subscriber, one at a time in the order they were published.
+func (v *eventBus) ReplayEvents(subscriber abs.Subscriber) {
+	if !v.IsLogged() {
+		panic("The event bus does not have a persistent log to replay.")
+	}
+	v.mutex.Lock()
+	var sequences = v.listEvents()
+	v.mutex.Unlock()
+	for _, sequence := range sequences {
+		var document, err = osx.ReadFile(v.getFilename(sequence))
+		if err != nil {
+			var message = fmt.Sprintf("Could not read the event file: %v.", err)
+			panic(message)
+		}
+		subscriber(bal.ParseDocument(document))
+	}
+}
+
+// PRIVATE METHODS
+
+// This private method adds the specified subscription to this event bus and
+// returns its identifier.
+func (v *eventBus) addSubscription(subscription *subscription) int {
+	v.mutex.Lock()
+	defer v.mutex.Unlock()
+	v.counter++
+	subscription.identifier = v.counter
+	v.subscriptions[v.counter] = subscription
+	return v.counter
+}
+
+// This private method delivers the events waiting in the specified queue until
+// the queue is empty.
+func (v *eventBus) deliverEvents(name string, queue *outbox) {
+	for {
+		v.mutex.Lock()
+		if len(queue.events) == 0 {
+			delete(v.publishers, name)
+			v.mutex.Unlock()
+			return
+		}
+		var event = queue.events[0]
+		queue.events[0] = nil
+		queue.events = queue.events[1:]
+		var subscribers = v.matchSubscribers(event)
+		var handler = v.handler
+		v.mutex.Unlock()
+		for _, subscriber := range subscribers {
+			v.notifySubscriber(subscriber, event, handler)
+		}
+		v.waiting.L.Lock()
+		v.pending--
+		if v.pending == 0 {
+			v.waiting.Broadcast()
+		}
+		v.waiting.L.Unlock()
+	}
+}
+
+// This private method returns the subscribers whose subscriptions match the
+// specified event, in the order they subscribed.
+func (v *eventBus) matchSubscribers(event abs.ComponentLike) []abs.Subscriber {
+	var type_ = v.getType(event)
+	var source string
+	var matches []*subscription
+	for _, subscription := range v.subscriptions {
+		switch {
+		case subscription.pattern != nil:
+			if len(source) == 0 {
+				source = bal.FormatComponent(event)
+			}
+			if subscription.pattern.MatchesText(source) {
+				matches = append(matches, subscription)
+			}
+		case subscription.type_ == type_:
+			matches = append(matches, subscription)
+		}
+	}
+	sor.Slice(matches, func(i, j int) bool {
+		return matches[i].identifier < matches[j].identifier
+	})
+	var subscribers = make([]abs.Subscriber, len(matches))
+	for index, subscription := range matches {
+		subscribers[index] = subscription.subscriber
+	}
+	return subscribers
+}
+
+// This private method delivers the specified event to the specified
+// subscriber. A failure within the subscriber is passed to the specified
+// failure handler.
+func (v *eventBus) notifySubscriber(subscriber abs.Subscriber, event abs.ComponentLike, handler abs.FailureHandler) {
+	defer func() {
+		if e := recover(); e != nil {
+			handler(event, e)
+		}
+	}()
+	subscriber(event)
+}
+
+// This private method returns the type of the specified event in its Bali
+// source form, or an empty string if the event does not have a type.
+func (v *eventBus) getType(event abs.ComponentLike) string {
+	var symbol = str.SymbolFromString("type")
+	var type_ abs.ComponentLike
+	if catalog, ok := event.GetEntity().(abs.CatalogLike); ok {
+		type_ = catalog.GetValue(symbol)
+	}
+	if type_ == nil && event.IsParameterized() {
+		type_ = event.GetContext().GetValue(symbol)
+	}
+	if type_ == nil {
+		return ""
+	}
+	return bal.FormatComponent(type_)
+}
+
+// This private method returns the name of the file that holds the logged event
+// with the specified sequence number.
+func (v *eventBus) getFilename(sequence int) string {
+	return fmt.Sprintf("%v%012d%v", v.directory, sequence, eventSuffix)
+}
+
+// This private method returns the sequence numbers of the logged events in the
+// order they were published.
+func (v *eventBus) listEvents() []int {
+	var entries, err = osx.ReadDir(v.directory)
+	if err != nil {
+		var message = fmt.Sprintf("Could not read the event log directory: %v.", err)
+		panic(message)
+	}
+	var sequences []int
+	for _, entry := range entries {
+		var name = entry.Name()
+		var sequence, err = stc.Atoi(sts.TrimSuffix(name, eventSuffix))
+		if entry.IsDir() || !sts.HasSuffix(name, eventSuffix) || err != nil {
+			continue
+		}
+		sequences = append(sequences, sequence)
+	}
+	sor.Ints(sequences)
+	return sequences
+}
+
+// This private method appends the specified event to the persistent log of
+// this event bus, if it has one.
+func (v *eventBus) logEvent(event abs.ComponentLike) {
+	if !v.IsLogged() {
+		return
+	}
+	v.sequence++
+	var document = bal.FormatDocument(event)
+	var err = uti.WriteFile(v.getFilename(v.sequence), document, 0600)
+	if err != nil {
+		var message = fmt.Sprintf("Could not create the event file: %v.", err)
+		panic(message)
+	}
+}
+
+// PRIVATE FUNCTIONS
+
+// This private function reports on the standard error that a subscriber failed
+// while the specified event was being delivered to it.
+func reportFailure(event abs.ComponentLike, failure any) {
+	fmt.Fprintf(osx.Stderr, "An event subscriber failed: %v\n", failure)
+}
@@ -0,0 +1,96 @@
+/*******************************************************************************
+ *   Copyright (c) 2009-2023 Crater Dog Technologies™.  All Rights Reserved.   *
+ *******************************************************************************
+ * DO NOT ALTER OR REMOVE COPYRIGHT NOTICES OR THIS FILE HEADER.               *
+ *                                                                             *
+ * This code is free software; you can redistribute it and/or modify it under  *
+ * the terms of The MIT License (MIT), as published by the Open Source         *
+ * Initiative. (See http://opensource.org/licenses/MIT)                        *
+ *******************************************************************************/
+
+package events_test
+
+import (
+	fmt "fmt"
+	abs "github.com/bali-nebula/go-component-framework/v2/abstractions"
+	bal "github.com/bali-nebula/go-component-framework/v2/bali"
+	ele "github.com/bali-nebula/go-component-framework/v2/elements"
+	evt "github.com/bali-nebula/go-component-framework/v2/events"
+	ass "github.com/stretchr/testify/assert"
+	syn "sync"
+	tes "testing"
+)
+
+func event(type_ string, order int) abs.ComponentLike {
+	return bal.ParseComponent(fmt.Sprintf("[$type: %v, $order: %v]", type_, order))
+}
+
+func TestEventSubscriptions(t *tes.T) {
+	var bus = evt.EventBus()
+	var mutex syn.Mutex
+	var placed, shipped, matched []int
+	var failures int
+	bus.SetFailureHandler(func(event abs.ComponentLike, failure any) {
+		mutex.Lock()
+		defer mutex.Unlock()
+		ass.Equal(t, "This failure should not prevent delivery to other subscribers.", failure)
+		failures++
+	})
+	var record = func(orders *[]int) abs.Subscriber {
+		return func(event abs.ComponentLike) {
+			mutex.Lock()
+			defer mutex.Unlock()
+			var order = event.ExtractCatalog().GetValue(bal.Symbol("order"))
+			*orders = append(*orders, int(order.ExtractNumber().GetReal()))
+		}
+	}
+	var subscription = bus.SubscribeToType("/acme/events/OrderPlaced/v1", record(&placed))
+	bus.SubscribeToType("/acme/events/OrderShipped/v1", record(&shipped))
+	bus.SubscribeToPattern(ele.Pattern().FromString(`"\$order: 7\s"?`), record(&matched))
+	bus.SubscribeToType("/acme/events/OrderShipped/v1", func(event abs.ComponentLike) {
+		panic("This failure should not prevent delivery to other subscribers.")
+	})
+
+	var expected []int
+	for order := 1; order <= 100; order++ {
+		bus.PublishEvent("orders", event("/acme/events/OrderPlaced/v1", order))
+		bus.PublishEvent("shipping", event("/acme/events/OrderShipped/v1", order))
+		expected = append(expected, order)
+	}
+	bus.AwaitDelivery()
+	ass.Equal(t, expected, placed)
+	ass.Equal(t, expected, shipped)
+	ass.Equal(t, []int{7, 7}, matched)
+	ass.Equal(t, 100, failures)
+
+	bus.Unsubscribe(subscription)
+	bus.PublishEvent("orders", event("/acme/events/OrderPlaced/v1", 101))
+	bus.AwaitDelivery()
+	ass.Equal(t, 100, len(placed))
+}
+
+func TestEventLog(t *tes.T) {
+	var directory = t.TempDir()
+	var bus = evt.EventBusWithLog(directory)
+	ass.True(t, bus.IsLogged())
+	bus.PublishEvent("orders", event("/acme/events/OrderPlaced/v1", 1))
+	bus.PublishEvent("orders", event("/acme/events/OrderPlaced/v1", 2))
+
+	bus = evt.EventBusWithLog(directory)
+	bus.PublishEvent("orders", event("/acme/events/OrderPlaced/v1", 3))
+	var orders []int
+	bus.ReplayEvents(func(event abs.ComponentLike) {
+		var order = event.ExtractCatalog().GetValue(bal.Symbol("order"))
+		orders = append(orders, int(order.ExtractNumber().GetReal()))
+	})
+	ass.Equal(t, []int{1, 2, 3}, orders)
+
+	defer func() {
+		if e := recover(); e != nil {
+			ass.Equal(t, "The event bus does not have a persistent log to replay.", e)
+		} else {
+			ass.Fail(t, "Test should result in recovered panic.")
+		}
+	}()
+	evt.EventBus().ReplayEvents(func(event abs.ComponentLike) {}) // This should panic.
+}
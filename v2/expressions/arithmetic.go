@@ -33,6 +33,7 @@ type arithmeticExpression struct {
 	first    abs.Expression
 	operator abs.Operator
 	second   abs.Expression
+	span     abs.Span
 }
 
 // This method returns the first expression in this arithmetic expression.
@@ -76,3 +77,15 @@ func (v *arithmeticExpression) SetSecond(second abs.Expression) {
 	}
 	v.second = second
 }
+
+// This method returns the span of source bytes from which this arithmetic
+// expression was parsed.
+func (v *arithmeticExpression) GetSpan() abs.Span {
+	return v.span
+}
+
+// This method sets the span of source bytes from which this arithmetic
+// expression was parsed.
+func (v *arithmeticExpression) SetSpan(span abs.Span) {
+	v.span = span
+}
@@ -32,6 +32,7 @@ type chainingExpression struct {
 	first    abs.Expression
 	operator abs.Operator
 	second   abs.Expression
+	span     abs.Span
 }
 
 // This method returns the first expression in this chaining expression.
@@ -75,3 +76,15 @@ func (v *chainingExpression) SetSecond(second abs.Expression) {
 	}
 	v.second = second
 }
+
+// This method returns the span of source bytes from which this chaining
+// expression was parsed.
+func (v *chainingExpression) GetSpan() abs.Span {
+	return v.span
+}
+
+// This method sets the span of source bytes from which this chaining expression
+// was parsed.
+func (v *chainingExpression) SetSpan(span abs.Span) {
+	v.span = span
+}
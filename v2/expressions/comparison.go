@@ -32,6 +32,7 @@ type comparisonExpression struct {
 	first    abs.Expression
 	operator abs.Operator
 	second   abs.Expression
+	span     abs.Span
 }
 
 // This method returns the first expression in this comparison expression.
@@ -75,3 +76,15 @@ func (v *comparisonExpression) SetSecond(second abs.Expression) {
 	}
 	v.second = second
 }
+
+// This method returns the span of source bytes from which this comparison
+// expression was parsed.
+func (v *comparisonExpression) GetSpan() abs.Span {
+	return v.span
+}
+
+// This method sets the span of source bytes from which this comparison
+// expression was parsed.
+func (v *comparisonExpression) SetSpan(span abs.Span) {
+	v.span = span
+}
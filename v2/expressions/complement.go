@@ -30,6 +30,7 @@ func Complement(operator abs.Operator, expression abs.Expression) abs.UnaryOpera
 type complementExpression struct {
 	operator   abs.Operator
 	expression abs.Expression
+	span       abs.Span
 }
 
 // This method returns the complement operator in this complement expression.
@@ -60,3 +61,15 @@ func (v *complementExpression) SetExpression(expression abs.Expression) {
 	}
 	v.expression = expression
 }
+
+// This method returns the span of source bytes from which this complement
+// expression was parsed.
+func (v *complementExpression) GetSpan() abs.Span {
+	return v.span
+}
+
+// This method sets the span of source bytes from which this complement
+// expression was parsed.
+func (v *complementExpression) SetSpan(span abs.Span) {
+	v.span = span
+}
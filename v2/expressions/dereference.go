@@ -30,6 +30,7 @@ func Dereference(operator abs.Operator, expression abs.Expression) abs.UnaryOper
 type dereferenceExpression struct {
 	operator   abs.Operator
 	expression abs.Expression
+	span       abs.Span
 }
 
 // This method returns the dereference operator in this dereference expression.
@@ -60,3 +61,15 @@ func (v *dereferenceExpression) SetExpression(expression abs.Expression) {
 	}
 	v.expression = expression
 }
+
+// This method returns the span of source bytes from which this dereference
+// expression was parsed.
+func (v *dereferenceExpression) GetSpan() abs.Span {
+	return v.span
+}
+
+// This method sets the span of source bytes from which this dereference
+// expression was parsed.
+func (v *dereferenceExpression) SetSpan(span abs.Span) {
+	v.span = span
+}
@@ -32,6 +32,7 @@ type exponentialExpression struct {
 	base     abs.Expression
 	operator abs.Operator
 	exponent abs.Expression
+	span     abs.Span
 }
 
 // This method returns the first expression in this exponential expression.
@@ -75,3 +76,15 @@ func (v *exponentialExpression) SetSecond(exponent abs.Expression) {
 	}
 	v.exponent = exponent
 }
+
+// This method returns the span of source bytes from which this exponential
+// expression was parsed.
+func (v *exponentialExpression) GetSpan() abs.Span {
+	return v.span
+}
+
+// This method sets the span of source bytes from which this exponential
+// expression was parsed.
+func (v *exponentialExpression) SetSpan(span abs.Span) {
+	v.span = span
+}
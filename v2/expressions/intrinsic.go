@@ -30,6 +30,7 @@ func Intrinsic(function string, arguments abs.Sequential[abs.Expression]) abs.In
 type intrinsicExpression struct {
 	function  string
 	arguments abs.Sequential[abs.Expression]
+	span      abs.Span
 }
 
 // This method returns the function name for this intrinsic expression.
@@ -57,3 +58,15 @@ func (v *intrinsicExpression) SetArguments(arguments abs.Sequential[abs.Expressi
 	}
 	v.arguments = arguments
 }
+
+// This method returns the span of source bytes from which this intrinsic
+// expression was parsed.
+func (v *intrinsicExpression) GetSpan() abs.Span {
+	return v.span
+}
+
+// This method sets the span of source bytes from which this intrinsic
+// expression was parsed.
+func (v *intrinsicExpression) SetSpan(span abs.Span) {
+	v.span = span
+}
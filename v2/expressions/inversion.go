@@ -30,6 +30,7 @@ func Inversion(operator abs.Operator, expression abs.Expression) abs.UnaryOperat
 type inversionExpression struct {
 	operator   abs.Operator
 	expression abs.Expression
+	span       abs.Span
 }
 
 // This method returns the inversion operator in this inversion expression.
@@ -60,3 +61,15 @@ func (v *inversionExpression) SetExpression(expression abs.Expression) {
 	}
 	v.expression = expression
 }
+
+// This method returns the span of source bytes from which this inversion
+// expression was parsed.
+func (v *inversionExpression) GetSpan() abs.Span {
+	return v.span
+}
+
+// This method sets the span of source bytes from which this inversion
+// expression was parsed.
+func (v *inversionExpression) SetSpan(span abs.Span) {
+	v.span = span
+}
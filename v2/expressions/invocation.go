@@ -39,12 +39,13 @@ type invocationExpression struct {
 	operator  abs.Operator
 	method    string
 	arguments abs.Sequential[abs.Expression]
+	span      abs.Span
 }
 
 // This method determines whether or not this invocation expression is
 // synchronous.
 func (v *invocationExpression) IsSynchronous() bool {
-	return v.operator == abs.ARROW
+	return v.operator == abs.DOT
 }
 
 // This method returns the target expression for this invocation expression.
@@ -98,3 +99,15 @@ func (v *invocationExpression) SetArguments(arguments abs.Sequential[abs.Express
 	}
 	v.arguments = arguments
 }
+
+// This method returns the span of source bytes from which this invocation
+// expression was parsed.
+func (v *invocationExpression) GetSpan() abs.Span {
+	return v.span
+}
+
+// This method sets the span of source bytes from which this invocation
+// expression was parsed.
+func (v *invocationExpression) SetSpan(span abs.Span) {
+	v.span = span
+}
@@ -33,6 +33,7 @@ type logicalExpression struct {
 	first    abs.Expression
 	operator abs.Operator
 	second   abs.Expression
+	span     abs.Span
 }
 
 // This method returns the first expression in this logical expression.
@@ -76,3 +77,15 @@ func (v *logicalExpression) SetSecond(second abs.Expression) {
 	}
 	v.second = second
 }
+
+// This method returns the span of source bytes from which this logical
+// expression was parsed.
+func (v *logicalExpression) GetSpan() abs.Span {
+	return v.span
+}
+
+// This method sets the span of source bytes from which this logical expression
+// was parsed.
+func (v *logicalExpression) SetSpan(span abs.Span) {
+	v.span = span
+}
@@ -28,6 +28,7 @@ func Magnitude(expression abs.Expression) abs.UnaryOperationLike {
 // expression.
 type magnitudeExpression struct {
 	expression abs.Expression
+	span       abs.Span
 }
 
 // This method returns the magnitude operator in this magnitude expression.
@@ -54,3 +55,15 @@ func (v *magnitudeExpression) SetExpression(expression abs.Expression) {
 	}
 	v.expression = expression
 }
+
+// This method returns the span of source bytes from which this magnitude
+// expression was parsed.
+func (v *magnitudeExpression) GetSpan() abs.Span {
+	return v.span
+}
+
+// This method sets the span of source bytes from which this magnitude
+// expression was parsed.
+func (v *magnitudeExpression) SetSpan(span abs.Span) {
+	v.span = span
+}
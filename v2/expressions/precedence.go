@@ -28,6 +28,7 @@ func Precedence(expression abs.Expression) abs.UnaryOperationLike {
 // expression.
 type precedenceExpression struct {
 	expression abs.Expression
+	span       abs.Span
 }
 
 // This method returns the precedence operator in this precedence expression.
@@ -53,3 +54,15 @@ func (v *precedenceExpression) SetExpression(expression abs.Expression) {
 	}
 	v.expression = expression
 }
+
+// This method returns the span of source bytes from which this precedence
+// expression was parsed.
+func (v *precedenceExpression) GetSpan() abs.Span {
+	return v.span
+}
+
+// This method sets the span of source bytes from which this precedence
+// expression was parsed.
+func (v *precedenceExpression) SetSpan(span abs.Span) {
+	v.span = span
+}
@@ -30,6 +30,7 @@ func Subcomponent(composite abs.Expression, indices abs.Sequential[abs.Expressio
 type subcomponentExpression struct {
 	composite abs.Expression
 	indices   abs.Sequential[abs.Expression]
+	span      abs.Span
 }
 
 // This method returns the composite for this subcomponent expression.
@@ -57,3 +58,15 @@ func (v *subcomponentExpression) SetIndices(indices abs.Sequential[abs.Expressio
 	}
 	v.indices = indices
 }
+
+// This method returns the span of source bytes from which this subcomponent
+// expression was parsed.
+func (v *subcomponentExpression) GetSpan() abs.Span {
+	return v.span
+}
+
+// This method sets the span of source bytes from which this subcomponent
+// expression was parsed.
+func (v *subcomponentExpression) SetSpan(span abs.Span) {
+	v.span = span
+}
@@ -28,6 +28,7 @@ func Value(component abs.ComponentLike) abs.ValueLike {
 // expression.
 type valueExpression struct {
 	component abs.ComponentLike
+	span      abs.Span
 }
 
 // This method returns the component for this value expression.
@@ -42,3 +43,15 @@ func (v *valueExpression) SetComponent(component abs.ComponentLike) {
 	}
 	v.component = component
 }
+
+// This method returns the span of source bytes from which this value expression
+// was parsed.
+func (v *valueExpression) GetSpan() abs.Span {
+	return v.span
+}
+
+// This method sets the span of source bytes from which this value expression
+// was parsed.
+func (v *valueExpression) SetSpan(span abs.Span) {
+	v.span = span
+}
@@ -28,6 +28,7 @@ func Variable(identifier string) abs.VariableLike {
 // expression.
 type variableExpression struct {
 	identifier string
+	span       abs.Span
 }
 
 // This method returns the identifier for this variable expression.
@@ -42,3 +43,15 @@ func (v *variableExpression) SetIdentifier(identifier string) {
 	}
 	v.identifier = identifier
 }
+
+// This method returns the span of source bytes from which this variable
+// expression was parsed.
+func (v *variableExpression) GetSpan() abs.Span {
+	return v.span
+}
+
+// This method sets the span of source bytes from which this variable expression
+// was parsed.
+func (v *variableExpression) SetSpan(span abs.Span) {
+	v.span = span
+}
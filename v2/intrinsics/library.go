@@ -0,0 +1,265 @@
+/*******************************************************************************
+ *   Copyright (c) 2009-2023 Crater Dog Technologies™.  All Rights Reserved.   *
+ *******************************************************************************
+ * DO NOT ALTER OR REMOVE COPYRIGHT NOTICES OR THIS FILE HEADER.               *
+ *                                                                             *
+ * This code is free software; you can redistribute it and/or modify it under  *
+ * the terms of The MIT License (MIT), as published by the Open Source         *
+ * Initiative. (See http://opensource.org/licenses/MIT)                        *
+ *******************************************************************************/
+
+package intrinsics
+
+import (
+	abs "github.com/bali-nebula/go-component-framework/v2/abstractions"
+	col "github.com/bali-nebula/go-component-framework/v2/collections"
+	com "github.com/bali-nebula/go-component-framework/v2/components"
+	ele "github.com/bali-nebula/go-component-framework/v2/elements"
+	str "github.com/bali-nebula/go-component-framework/v2/strings"
+	uti "github.com/bali-nebula/go-component-framework/v2/utilities"
+	cox "github.com/craterdog/go-collection-framework/v2"
+	sts "strings"
+)
+
+// STANDARD LIBRARY
+
+// This type defines the structure of the definition of an intrinsic function
+// in the standard library.
+type definition struct {
+	name      string
+	signature abs.SignatureLike
+	intrinsic abs.Intrinsic
+}
+
+// This list defines the intrinsic functions in the standard library.
+var library = []definition{
+	// Math Functions
+	{"sine", Signature([]string{"Angle"}, "Number"), sine},
+	{"cosine", Signature([]string{"Angle"}, "Number"), cosine},
+	{"tangent", Signature([]string{"Angle"}, "Number"), tangent},
+	{"arcsine", Signature([]string{"Number"}, "Angle"), arcsine},
+	{"arccosine", Signature([]string{"Number"}, "Angle"), arccosine},
+	{"arctangent", Signature([]string{"Number", "Number"}, "Angle"), arctangent},
+	{"logarithm", Signature([]string{"Number", "Number"}, "Number"), logarithm},
+	{"minimum", Signature([]string{"Any", "Any"}, "Any"), minimum},
+	{"maximum", Signature([]string{"Any", "Any"}, "Any"), maximum},
+
+	// String Functions
+	{"lowercase", Signature([]string{"Quote"}, "Quote"), lowercase},
+	{"uppercase", Signature([]string{"Quote"}, "Quote"), uppercase},
+	{"split", Signature([]string{"Quote", "Quote"}, "List"), split},
+	{"join", Signature([]string{"List", "Quote"}, "Quote"), join},
+
+	// Collection Functions
+	{"size", Signature([]string{"Sequence"}, "Number"), size},
+	{"first", Signature([]string{"List"}, "Any"), first},
+	{"last", Signature([]string{"List"}, "Any"), last},
+	{"reverse", Signature([]string{"List"}, "List"), reverse},
+	{"sort", Signature([]string{"List"}, "List"), sort},
+	{"keys", Signature([]string{"Catalog"}, "List"), keys},
+	{"values", Signature([]string{"Catalog"}, "List"), values},
+
+	// Time Functions
+	{"now", Signature([]string{}, "Moment"), now},
+	{"milliseconds", Signature([]string{"Duration"}, "Number"), milliseconds},
+	{"duration", Signature([]string{"Number"}, "Duration"), duration},
+
+	// Random Functions
+	{"randomBoolean", Signature([]string{}, "Boolean"), randomBoolean},
+	{"randomProbability", Signature([]string{}, "Probability"), randomProbability},
+	{"randomNumber", Signature([]string{"Number", "Number"}, "Number"), randomNumber},
+}
+
+// PRIVATE FUNCTIONS
+
+// This function returns a new component containing the specified real number.
+func realNumber(real float64) abs.ComponentLike {
+	return com.Component(ele.Number().FromComplex(complex(real, 0)))
+}
+
+// This function returns a new component containing a list of the specified
+// components.
+func listOf(components []abs.ComponentLike) abs.ComponentLike {
+	var list = col.List()
+	for _, component := range components {
+		list.AddValue(component)
+	}
+	return com.Component(list)
+}
+
+// This function returns the sine of the angle argument.
+func sine(arguments []abs.ComponentLike) abs.ComponentLike {
+	return realNumber(ele.Angle().Sine(arguments[0].ExtractAngle()))
+}
+
+// This function returns the cosine of the angle argument.
+func cosine(arguments []abs.ComponentLike) abs.ComponentLike {
+	return realNumber(ele.Angle().Cosine(arguments[0].ExtractAngle()))
+}
+
+// This function returns the tangent of the angle argument.
+func tangent(arguments []abs.ComponentLike) abs.ComponentLike {
+	return realNumber(ele.Angle().Tangent(arguments[0].ExtractAngle()))
+}
+
+// This function returns the angle whose sine is the number argument.
+func arcsine(arguments []abs.ComponentLike) abs.ComponentLike {
+	return com.Component(ele.Angle().ArcSine(arguments[0].ExtractNumber().GetReal()))
+}
+
+// This function returns the angle whose cosine is the number argument.
+func arccosine(arguments []abs.ComponentLike) abs.ComponentLike {
+	return com.Component(ele.Angle().ArcCosine(arguments[0].ExtractNumber().GetReal()))
+}
+
+// This function returns the angle of the point whose x and y coordinates are
+// the number arguments.
+func arctangent(arguments []abs.ComponentLike) abs.ComponentLike {
+	var x = arguments[0].ExtractNumber().GetReal()
+	var y = arguments[1].ExtractNumber().GetReal()
+	return com.Component(ele.Angle().ArcTangent(x, y))
+}
+
+// This function returns the logarithm of the second number argument using the
+// first number argument as the base.
+func logarithm(arguments []abs.ComponentLike) abs.ComponentLike {
+	var base = arguments[0].ExtractNumber()
+	var number = arguments[1].ExtractNumber()
+	return com.Component(ele.Number().Logarithm(base, number))
+}
+
+// This function returns the lesser of its two arguments.
+func minimum(arguments []abs.ComponentLike) abs.ComponentLike {
+	if cox.RankValues(arguments[1].GetEntity(), arguments[0].GetEntity()) < 0 {
+		return arguments[1]
+	}
+	return arguments[0]
+}
+
+// This function returns the greater of its two arguments.
+func maximum(arguments []abs.ComponentLike) abs.ComponentLike {
+	if cox.RankValues(arguments[1].GetEntity(), arguments[0].GetEntity()) > 0 {
+		return arguments[1]
+	}
+	return arguments[0]
+}
+
+// This function returns the quote argument with all letters in lowercase.
+func lowercase(arguments []abs.ComponentLike) abs.ComponentLike {
+	var quote = arguments[0].ExtractQuote().AsString()
+	return com.Component(str.QuoteFromString(sts.ToLower(quote)))
+}
+
+// This function returns the quote argument with all letters in uppercase.
+func uppercase(arguments []abs.ComponentLike) abs.ComponentLike {
+	var quote = arguments[0].ExtractQuote().AsString()
+	return com.Component(str.QuoteFromString(sts.ToUpper(quote)))
+}
+
+// This function returns a list of the quotes that result from splitting the
+// first quote argument at each occurrence of the second quote argument.
+func split(arguments []abs.ComponentLike) abs.ComponentLike {
+	var quote = arguments[0].ExtractQuote().AsString()
+	var separator = arguments[1].ExtractQuote().AsString()
+	var parts []abs.ComponentLike
+	for _, part := range sts.Split(quote, separator) {
+		parts = append(parts, com.Component(str.QuoteFromString(part)))
+	}
+	return listOf(parts)
+}
+
+// This function returns the quote that results from joining the quotes in the
+// list argument using the quote argument as the separator.
+func join(arguments []abs.ComponentLike) abs.ComponentLike {
+	var parts []string
+	for _, part := range arguments[0].ExtractList().AsArray() {
+		parts = append(parts, part.ExtractQuote().AsString())
+	}
+	var separator = arguments[1].ExtractQuote().AsString()
+	return com.Component(str.QuoteFromString(sts.Join(parts, separator)))
+}
+
+// This function returns the number of items in the sequence argument.
+func size(arguments []abs.ComponentLike) abs.ComponentLike {
+	var sequence = arguments[0].GetEntity().(interface{ GetSize() int })
+	return realNumber(float64(sequence.GetSize()))
+}
+
+// This function returns the first item in the list argument.
+func first(arguments []abs.ComponentLike) abs.ComponentLike {
+	return arguments[0].ExtractList().GetValue(1)
+}
+
+// This function returns the last item in the list argument.
+func last(arguments []abs.ComponentLike) abs.ComponentLike {
+	return arguments[0].ExtractList().GetValue(-1)
+}
+
+// This function returns a new list containing the items in the list argument
+// in reverse order.
+func reverse(arguments []abs.ComponentLike) abs.ComponentLike {
+	var list = col.ListFromSequence(arguments[0].ExtractList())
+	list.ReverseValues()
+	return com.Component(list)
+}
+
+// This function returns a new list containing the items in the list argument
+// in their natural order.
+func sort(arguments []abs.ComponentLike) abs.ComponentLike {
+	var list = col.ListFromSequence(arguments[0].ExtractList())
+	list.SortValues()
+	return com.Component(list)
+}
+
+// This function returns a list of the keys in the catalog argument.
+func keys(arguments []abs.ComponentLike) abs.ComponentLike {
+	var items []abs.ComponentLike
+	for _, key := range arguments[0].ExtractCatalog().GetKeys().AsArray() {
+		items = append(items, com.Component(key))
+	}
+	return listOf(items)
+}
+
+// This function returns a list of the values in the catalog argument.
+func values(arguments []abs.ComponentLike) abs.ComponentLike {
+	var items []abs.ComponentLike
+	for _, association := range arguments[0].ExtractCatalog().AsArray() {
+		items = append(items, association.GetValue())
+	}
+	return listOf(items)
+}
+
+// This function returns the current moment in time.
+func now(arguments []abs.ComponentLike) abs.ComponentLike {
+	return com.Component(ele.Moment().Now())
+}
+
+// This function returns the number of milliseconds in the duration argument.
+func milliseconds(arguments []abs.ComponentLike) abs.ComponentLike {
+	return realNumber(float64(arguments[0].ExtractDuration().AsInteger()))
+}
+
+// This function returns the duration that is the number argument of
+// milliseconds long.
+func duration(arguments []abs.ComponentLike) abs.ComponentLike {
+	var count = int(arguments[0].ExtractNumber().GetReal())
+	return com.Component(ele.Duration().FromMilliseconds(count))
+}
+
+// This function returns a random boolean.
+func randomBoolean(arguments []abs.ComponentLike) abs.ComponentLike {
+	return com.Component(ele.Boolean().FromBoolean(uti.RandomProbability() < 0.5))
+}
+
+// This function returns a random probability.
+func randomProbability(arguments []abs.ComponentLike) abs.ComponentLike {
+	return com.Component(ele.Probability().FromFloat(uti.RandomProbability()))
+}
+
+// This function returns a random number that is at least the first number
+// argument and less than the second number argument.
+func randomNumber(arguments []abs.ComponentLike) abs.ComponentLike {
+	var low = arguments[0].ExtractNumber().GetReal()
+	var high = arguments[1].ExtractNumber().GetReal()
+	return realNumber(low + uti.RandomProbability()*(high-low))
+}
@@ -0,0 +1,123 @@
+/*******************************************************************************
+ *   Copyright (c) 2009-2023 Crater Dog Technologies™.  All Rights Reserved.   *
+ *******************************************************************************
+ * DO NOT ALTER OR REMOVE COPYRIGHT NOTICES OR THIS FILE HEADER.               *
+ *                                                                             *
+ * This code is free software; you can redistribute it and/or modify it under  *
+ * the terms of The MIT License (MIT), as published by the Open Source         *
+ * Initiative. (See http://opensource.org/licenses/MIT)                        *
+ *******************************************************************************/
+
+package intrinsics
+
+import (
+	fmt "fmt"
+	abs "github.com/bali-nebula/go-component-framework/v2/abstractions"
+	sor "sort"
+	syn "sync"
+)
+
+// REGISTRY IMPLEMENTATION
+
+// This constructor creates a new empty registry of intrinsic functions.
+func Registry() abs.RegistryLike {
+	var v = &registry{entries: make(map[string]*entry)}
+	return v
+}
+
+// This constructor creates a new registry containing the standard library of
+// intrinsic functions. Additional intrinsic functions may be registered with
+// the new registry without affecting any other registry.
+func StandardLibrary() abs.RegistryLike {
+	var v = Registry()
+	for _, definition := range library {
+		v.RegisterIntrinsic(definition.name, definition.signature, definition.intrinsic)
+	}
+	return v
+}
+
+// This type defines the structure and methods associated with a registry of
+// intrinsic functions. A registry may be shared by processors that run on
+// different goroutines so its entries are guarded by a mutex.
+type registry struct {
+	mutex   syn.RWMutex
+	entries map[string]*entry
+}
+
+// This type defines the structure of each entry in a registry.
+type entry struct {
+	signature abs.SignatureLike
+	intrinsic abs.Intrinsic
+}
+
+// REGISTERED INTERFACE
+
+// This method registers the specified intrinsic function with the specified
+// name and signature, replacing any existing function with the same name.
+func (v *registry) RegisterIntrinsic(name string, signature abs.SignatureLike, intrinsic abs.Intrinsic) {
+	if signature == nil || intrinsic == nil {
+		var message = fmt.Sprintf("The intrinsic function $%v requires a signature and an implementation.", name)
+		panic(message)
+	}
+	v.mutex.Lock()
+	defer v.mutex.Unlock()
+	v.entries[name] = &entry{signature, intrinsic}
+}
+
+// This method determines whether or not an intrinsic function with the
+// specified name has been registered.
+func (v *registry) IsRegistered(name string) bool {
+	v.mutex.RLock()
+	defer v.mutex.RUnlock()
+	var _, ok = v.entries[name]
+	return ok
+}
+
+// This method returns the names of all intrinsic functions in this registry in
+// alphabetical order.
+func (v *registry) GetNames() []string {
+	v.mutex.RLock()
+	defer v.mutex.RUnlock()
+	var names = make([]string, 0, len(v.entries))
+	for name := range v.entries {
+		names = append(names, name)
+	}
+	sor.Strings(names)
+	return names
+}
+
+// This method returns the signature of the intrinsic function with the
+// specified name, or nil if no such function has been registered.
+func (v *registry) GetSignature(name string) abs.SignatureLike {
+	var entry, ok = v.getEntry(name)
+	if !ok {
+		return nil
+	}
+	return entry.signature
+}
+
+// This method invokes the intrinsic function with the specified name on the
+// specified arguments after checking them against its signature.
+func (v *registry) InvokeIntrinsic(name string, arguments []abs.ComponentLike) abs.ComponentLike {
+	var entry, ok = v.getEntry(name)
+	if !ok {
+		var message = fmt.Sprintf("The intrinsic function $%v is not defined.", name)
+		panic(message)
+	}
+	if !entry.signature.AcceptsArguments(arguments) {
+		var message = fmt.Sprintf("The intrinsic function $%v requires arguments of the form %v.", name, entry.signature.AsString())
+		panic(message)
+	}
+	return entry.intrinsic(arguments)
+}
+
+// PRIVATE METHODS
+
+// This private method returns the entry for the intrinsic function with the
+// specified name and whether or not it has been registered.
+func (v *registry) getEntry(name string) (*entry, bool) {
+	v.mutex.RLock()
+	defer v.mutex.RUnlock()
+	var entry, ok = v.entries[name]
+	return entry, ok
+}
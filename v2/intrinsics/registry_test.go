@@ -0,0 +1,149 @@
+/*******************************************************************************
+ *   Copyright (c) 2009-2023 Crater Dog Technologies™.  All Rights Reserved.   *
+ *******************************************************************************
+ * DO NOT ALTER OR REMOVE COPYRIGHT NOTICES OR THIS FILE HEADER.               *
+ *                                                                             *
+ * This code is free software; you can redistribute it and/or modify it under  *
+ * the terms of The MIT License (MIT), as published by the Open Source         *
+ * Initiative. (See http://opensource.org/licenses/MIT)                        *
+ *******************************************************************************/
+
+package intrinsics_test
+
+import (
+	abs "github.com/bali-nebula/go-component-framework/v2/abstractions"
+	col "github.com/bali-nebula/go-component-framework/v2/collections"
+	com "github.com/bali-nebula/go-component-framework/v2/components"
+	ele "github.com/bali-nebula/go-component-framework/v2/elements"
+	ins "github.com/bali-nebula/go-component-framework/v2/intrinsics"
+	str "github.com/bali-nebula/go-component-framework/v2/strings"
+	ass "github.com/stretchr/testify/assert"
+	tes "testing"
+)
+
+func number(value float64) abs.ComponentLike {
+	return com.Component(ele.Number().FromComplex(complex(value, 0)))
+}
+
+func quote(value string) abs.ComponentLike {
+	return com.Component(str.QuoteFromString(value))
+}
+
+func TestSignatures(t *tes.T) {
+	var signature = ins.Signature([]string{"Number", "Quote"}, "List")
+	ass.Equal(t, "(Number, Quote) List", signature.AsString())
+	ass.Equal(t, 2, signature.GetArity())
+	ass.True(t, signature.AcceptsArguments([]abs.ComponentLike{number(1), quote("a")}))
+	ass.False(t, signature.AcceptsArguments([]abs.ComponentLike{quote("a"), number(1)}))
+	ass.False(t, signature.AcceptsArguments([]abs.ComponentLike{number(1)}))
+
+	var duration = com.Component(ele.Duration().FromMilliseconds(5))
+	var moment = com.Component(ele.Moment().Now())
+	ass.True(t, ins.Signature([]string{"Duration"}, "Any").AcceptsArguments([]abs.ComponentLike{duration}))
+	ass.False(t, ins.Signature([]string{"Moment"}, "Any").AcceptsArguments([]abs.ComponentLike{duration}))
+	ass.True(t, ins.Signature([]string{"Moment"}, "Any").AcceptsArguments([]abs.ComponentLike{moment}))
+	ass.True(t, ins.Signature([]string{"Sequence"}, "Any").AcceptsArguments([]abs.ComponentLike{quote("abc")}))
+	ass.False(t, ins.Signature([]string{"Sequence"}, "Any").AcceptsArguments([]abs.ComponentLike{number(1)}))
+
+	defer func() {
+		if e := recover(); e != nil {
+			ass.Equal(t, "A signature was passed an unknown type: Integer", e)
+		} else {
+			ass.Fail(t, "Test should result in recovered panic.")
+		}
+	}()
+	ins.Signature([]string{"Integer"}, "Any") // This should panic.
+}
+
+func TestRegistry(t *tes.T) {
+	var registry = ins.Registry()
+	ass.False(t, registry.IsRegistered("double"))
+	registry.RegisterIntrinsic("double", ins.Signature([]string{"Number"}, "Number"),
+		func(arguments []abs.ComponentLike) abs.ComponentLike {
+			return number(2 * arguments[0].ExtractNumber().GetReal())
+		})
+	ass.True(t, registry.IsRegistered("double"))
+	ass.Equal(t, []string{"double"}, registry.GetNames())
+	ass.Equal(t, "(Number) Number", registry.GetSignature("double").AsString())
+	ass.Nil(t, registry.GetSignature("triple"))
+	var result = registry.InvokeIntrinsic("double", []abs.ComponentLike{number(21)})
+	ass.Equal(t, 42.0, result.ExtractNumber().GetReal())
+
+	defer func() {
+		if e := recover(); e != nil {
+			ass.Equal(t, "The intrinsic function $double requires arguments of the form (Number) Number.", e)
+		} else {
+			ass.Fail(t, "Test should result in recovered panic.")
+		}
+	}()
+	registry.InvokeIntrinsic("double", []abs.ComponentLike{quote("21")}) // This should panic.
+}
+
+func TestUnknownIntrinsic(t *tes.T) {
+	defer func() {
+		if e := recover(); e != nil {
+			ass.Equal(t, "The intrinsic function $triple is not defined.", e)
+		} else {
+			ass.Fail(t, "Test should result in recovered panic.")
+		}
+	}()
+	ins.Registry().InvokeIntrinsic("triple", nil) // This should panic.
+}
+
+func TestStandardLibrary(t *tes.T) {
+	var library = ins.StandardLibrary()
+	var invoke = func(name string, arguments ...abs.ComponentLike) abs.ComponentLike {
+		return library.InvokeIntrinsic(name, arguments)
+	}
+
+	// Math functions.
+	var angle = invoke("arctangent", number(1), number(1))
+	ass.InDelta(t, 0.7071, invoke("sine", angle).ExtractNumber().GetReal(), 0.0001)
+	ass.InDelta(t, 0.7071, invoke("cosine", angle).ExtractNumber().GetReal(), 0.0001)
+	ass.InDelta(t, 3.0, invoke("logarithm", number(2), number(8)).ExtractNumber().GetReal(), 0.0001)
+	ass.Equal(t, 3.0, invoke("minimum", number(3), number(5)).ExtractNumber().GetReal())
+	ass.Equal(t, 5.0, invoke("maximum", number(3), number(5)).ExtractNumber().GetReal())
+
+	// String functions.
+	ass.Equal(t, "BALI", invoke("uppercase", quote("Bali")).ExtractQuote().AsString())
+	ass.Equal(t, "bali", invoke("lowercase", quote("Bali")).ExtractQuote().AsString())
+	var parts = invoke("split", quote("a,b,c"), quote(","))
+	ass.Equal(t, 3, parts.ExtractList().GetSize())
+	ass.Equal(t, "a-b-c", invoke("join", parts, quote("-")).ExtractQuote().AsString())
+
+	// Collection functions.
+	var list = col.List()
+	list.AddValue(number(3))
+	list.AddValue(number(1))
+	list.AddValue(number(2))
+	var component = com.Component(list)
+	ass.Equal(t, 3.0, invoke("size", component).ExtractNumber().GetReal())
+	ass.Equal(t, 4.0, invoke("size", quote("bali")).ExtractNumber().GetReal())
+	ass.Equal(t, 3.0, invoke("first", component).ExtractNumber().GetReal())
+	ass.Equal(t, 2.0, invoke("last", component).ExtractNumber().GetReal())
+	var sorted = invoke("sort", component).ExtractList()
+	ass.Equal(t, 1.0, sorted.GetValue(1).ExtractNumber().GetReal())
+	ass.Equal(t, 3.0, sorted.GetValue(3).ExtractNumber().GetReal())
+	ass.Equal(t, 3.0, list.GetValue(1).ExtractNumber().GetReal())
+	var reversed = invoke("reverse", component).ExtractList()
+	ass.Equal(t, 2.0, reversed.GetValue(1).ExtractNumber().GetReal())
+	var catalog = col.Catalog()
+	catalog.SetValue(str.SymbolFromString("alpha"), number(1))
+	catalog.SetValue(str.SymbolFromString("beta"), number(2))
+	var keys = invoke("keys", com.Component(catalog)).ExtractList()
+	ass.Equal(t, "beta", keys.GetValue(2).ExtractSymbol().AsString())
+	var values = invoke("values", com.Component(catalog)).ExtractList()
+	ass.Equal(t, 2.0, values.GetValue(2).ExtractNumber().GetReal())
+
+	// Time functions.
+	ass.NotNil(t, invoke("now").ExtractMoment())
+	var duration = invoke("duration", number(1500))
+	ass.Equal(t, 1500.0, invoke("milliseconds", duration).ExtractNumber().GetReal())
+
+	// Random functions.
+	var probability = invoke("randomProbability").ExtractProbability().AsFloat()
+	ass.True(t, probability >= 0.0 && probability <= 1.0)
+	var random = invoke("randomNumber", number(5), number(10)).ExtractNumber().GetReal()
+	ass.True(t, random >= 5.0 && random <= 10.0)
+	invoke("randomBoolean").ExtractBoolean()
+}
@@ -0,0 +1,139 @@
+/*******************************************************************************
+ *   Copyright (c) 2009-2023 Crater Dog Technologies™.  All Rights Reserved.   *
+ *******************************************************************************
+ * DO NOT ALTER OR REMOVE COPYRIGHT NOTICES OR THIS FILE HEADER.               *
+ *                                                                             *
+ * This code is free software; you can redistribute it and/or modify it under  *
+ * the terms of The MIT License (MIT), as published by the Open Source         *
+ * Initiative. (See http://opensource.org/licenses/MIT)                        *
+ *******************************************************************************/
+
+package intrinsics
+
+import (
+	fmt "fmt"
+	abs "github.com/bali-nebula/go-component-framework/v2/abstractions"
+	sts "strings"
+)
+
+// SIGNATURE IMPLEMENTATION
+
+// This constructor creates a new signature for an intrinsic function that
+// takes arguments of the specified parameter types and returns a result of the
+// specified type. Each type is named by one of the following:
+//
+//	Any          any component
+//	Sequence     any component whose entity has a size (strings and collections)
+//	Angle  Binary  Boolean  Catalog  Duration  List  Moment  Name  Narrative
+//	Number  Pattern  Percentage  Probability  Procedure  Quote  Resource
+//	Symbol  Tag  Version
+func Signature(parameters []string, result string) abs.SignatureLike {
+	for _, name := range append([]string{result}, parameters...) {
+		if _, ok := types[name]; !ok {
+			var message = fmt.Sprintf("A signature was passed an unknown type: %v", name)
+			panic(message)
+		}
+	}
+	var v = &signature{parameters: parameters, result: result}
+	return v
+}
+
+// This type defines the structure and methods associated with the signature
+// of an intrinsic function.
+type signature struct {
+	parameters []string
+	result     string
+}
+
+// This map defines the test for each type that may be named in a signature.
+// NOTE: A duration is also a moment and percentages and probabilities are
+// also booleans, so the tests for the more general types exclude the more
+// specific ones.
+var types = map[string]func(entity abs.Entity) bool{
+	"Any": func(entity abs.Entity) bool { return true },
+	"Sequence": func(entity abs.Entity) bool {
+		var _, ok = entity.(interface{ GetSize() int })
+		return ok
+	},
+	"Angle": func(entity abs.Entity) bool {
+		var _, isNumber = entity.(abs.NumberLike)
+		var _, ok = entity.(abs.AngleLike)
+		return ok && !isNumber
+	},
+	"Binary": isType[abs.BinaryLike],
+	"Boolean": func(entity abs.Entity) bool {
+		var _, isPercentage = entity.(abs.PercentageLike)
+		var _, isProbability = entity.(abs.ProbabilityLike)
+		var _, ok = entity.(abs.BooleanLike)
+		return ok && !isPercentage && !isProbability
+	},
+	"Catalog":  isType[abs.CatalogLike],
+	"Duration": isType[abs.DurationLike],
+	"List":     isType[abs.ListLike],
+	"Moment": func(entity abs.Entity) bool {
+		var _, isDuration = entity.(abs.DurationLike)
+		var _, ok = entity.(abs.MomentLike)
+		return ok && !isDuration
+	},
+	"Name":       isType[abs.NameLike],
+	"Narrative":  isType[abs.NarrativeLike],
+	"Number":     isType[abs.NumberLike],
+	"Pattern":    isType[abs.PatternLike],
+	"Percentage": isType[abs.PercentageLike],
+	"Probability": func(entity abs.Entity) bool {
+		var _, isPercentage = entity.(abs.PercentageLike)
+		var _, ok = entity.(abs.ProbabilityLike)
+		return ok && !isPercentage
+	},
+	"Procedure": isType[abs.ProcedureLike],
+	"Quote":     isType[abs.QuoteLike],
+	"Resource":  isType[abs.ResourceLike],
+	"Symbol":    isType[abs.SymbolLike],
+	"Tag":       isType[abs.TagLike],
+	"Version":   isType[abs.VersionLike],
+}
+
+// This function determines whether or not the specified entity has the
+// specified type.
+func isType[T any](entity abs.Entity) bool {
+	var _, ok = entity.(T)
+	return ok
+}
+
+// LEXICAL INTERFACE
+
+// This method returns a string value for this signature.
+func (v *signature) AsString() string {
+	return fmt.Sprintf("(%v) %v", sts.Join(v.parameters, ", "), v.result)
+}
+
+// TYPED INTERFACE
+
+// This method returns the number of arguments that this signature requires.
+func (v *signature) GetArity() int {
+	return len(v.parameters)
+}
+
+// This method returns the type of each parameter in this signature.
+func (v *signature) GetParameters() []string {
+	return v.parameters
+}
+
+// This method returns the type of the result of this signature.
+func (v *signature) GetResult() string {
+	return v.result
+}
+
+// This method determines whether or not the specified arguments have the
+// number and types of the parameters in this signature.
+func (v *signature) AcceptsArguments(arguments []abs.ComponentLike) bool {
+	if len(arguments) != len(v.parameters) {
+		return false
+	}
+	for index, argument := range arguments {
+		if !types[v.parameters[index]](argument.GetEntity()) {
+			return false
+		}
+	}
+	return true
+}
@@ -28,6 +28,7 @@ func AcceptClause(message abs.Expression) abs.AcceptClauseLike {
 // clause.
 type acceptClause struct {
 	message abs.Expression
+	span    abs.Span
 }
 
 // This method returns the message expression for this accept clause.
@@ -42,3 +43,15 @@ func (v *acceptClause) SetMessage(message abs.Expression) {
 	}
 	v.message = message
 }
+
+// This method returns the span of source bytes from which this accept clause
+// was parsed.
+func (v *acceptClause) GetSpan() abs.Span {
+	return v.span
+}
+
+// This method sets the span of source bytes from which this accept clause was
+// parsed.
+func (v *acceptClause) SetSpan(span abs.Span) {
+	v.span = span
+}
@@ -29,6 +29,7 @@ func Attribute(variable string, indices abs.Sequential[abs.Expression]) abs.Attr
 type attribute struct {
 	variable string
 	indices  abs.Sequential[abs.Expression]
+	span     abs.Span
 }
 
 // This method returns the variable for this attribute.
@@ -56,3 +57,15 @@ func (v *attribute) SetIndices(indices abs.Sequential[abs.Expression]) {
 	}
 	v.indices = indices
 }
+
+// This method returns the span of source bytes from which this attribute was
+// parsed.
+func (v *attribute) GetSpan() abs.Span {
+	return v.span
+}
+
+// This method sets the span of source bytes from which this attribute was
+// parsed.
+func (v *attribute) SetSpan(span abs.Span) {
+	v.span = span
+}
@@ -29,6 +29,7 @@ func Block(expression abs.Expression, procedure abs.ProcedureLike) abs.BlockLike
 type block struct {
 	expression abs.Expression
 	procedure  abs.ProcedureLike
+	span       abs.Span
 }
 
 // This method returns the expression for this block.
@@ -56,3 +57,14 @@ func (v *block) SetProcedure(procedure abs.ProcedureLike) {
 	}
 	v.procedure = procedure
 }
+
+// This method returns the span of source bytes from which this block was
+// parsed.
+func (v *block) GetSpan() abs.Span {
+	return v.span
+}
+
+// This method sets the span of source bytes from which this block was parsed.
+func (v *block) SetSpan(span abs.Span) {
+	v.span = span
+}
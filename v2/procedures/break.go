@@ -24,4 +24,17 @@ func BreakClause() abs.BreakClauseLike {
 
 // This type defines the structure and methods associated with a break clause.
 type breakClause struct {
+	span abs.Span
+}
+
+// This method returns the span of source bytes from which this break clause was
+// parsed.
+func (v *breakClause) GetSpan() abs.Span {
+	return v.span
+}
+
+// This method sets the span of source bytes from which this break clause was
+// parsed.
+func (v *breakClause) SetSpan(span abs.Span) {
+	v.span = span
 }
@@ -32,6 +32,7 @@ type checkoutClause struct {
 	recipient abs.Recipient
 	level     abs.Expression // The version level to be incremented (optional).
 	name      abs.Expression // A name to the citation for the document to be checked out.
+	span      abs.Span
 }
 
 // This method returns the recipient for this checkout clause.
@@ -69,3 +70,15 @@ func (v *checkoutClause) SetName(name abs.Expression) {
 	}
 	v.name = name
 }
+
+// This method returns the span of source bytes from which this checkout clause
+// was parsed.
+func (v *checkoutClause) GetSpan() abs.Span {
+	return v.span
+}
+
+// This method sets the span of source bytes from which this checkout clause was
+// parsed.
+func (v *checkoutClause) SetSpan(span abs.Span) {
+	v.span = span
+}
@@ -24,4 +24,17 @@ func ContinueClause() abs.ContinueClauseLike {
 
 // This type defines the structure and methods associated with a continue clause.
 type continueClause struct {
+	span abs.Span
+}
+
+// This method returns the span of source bytes from which this continue clause
+// was parsed.
+func (v *continueClause) GetSpan() abs.Span {
+	return v.span
+}
+
+// This method sets the span of source bytes from which this continue clause was
+// parsed.
+func (v *continueClause) SetSpan(span abs.Span) {
+	v.span = span
 }
@@ -28,6 +28,7 @@ func DiscardClause(document abs.Expression) abs.DiscardClauseLike {
 // clause.
 type discardClause struct {
 	document abs.Expression
+	span     abs.Span
 }
 
 // This method returns the document expression for this discard clause.
@@ -42,3 +43,15 @@ func (v *discardClause) SetDocument(document abs.Expression) {
 	}
 	v.document = document
 }
+
+// This method returns the span of source bytes from which this discard clause
+// was parsed.
+func (v *discardClause) GetSpan() abs.Span {
+	return v.span
+}
+
+// This method sets the span of source bytes from which this discard clause was
+// parsed.
+func (v *discardClause) SetSpan(span abs.Span) {
+	v.span = span
+}
@@ -27,6 +27,7 @@ func IfClause(block abs.BlockLike) abs.IfClauseLike {
 // This type defines the structure and methods associated with an if clause.
 type ifClause struct {
 	block abs.BlockLike
+	span  abs.Span
 }
 
 // This method returns the block for this if clause.
@@ -41,3 +42,15 @@ func (v *ifClause) SetBlock(block abs.BlockLike) {
 	}
 	v.block = block
 }
+
+// This method returns the span of source bytes from which this if clause was
+// parsed.
+func (v *ifClause) GetSpan() abs.Span {
+	return v.span
+}
+
+// This method sets the span of source bytes from which this if clause was
+// parsed.
+func (v *ifClause) SetSpan(span abs.Span) {
+	v.span = span
+}
@@ -38,6 +38,7 @@ type letClause struct {
 	recipient  abs.Recipient
 	operator   abs.Operator
 	expression abs.Expression
+	span       abs.Span
 }
 
 // This method determines whether or not this clause has a recipient.
@@ -73,3 +74,15 @@ func (v *letClause) SetExpression(expression abs.Expression) {
 	}
 	v.expression = expression
 }
+
+// This method returns the span of source bytes from which this let clause was
+// parsed.
+func (v *letClause) GetSpan() abs.Span {
+	return v.span
+}
+
+// This method sets the span of source bytes from which this let clause was
+// parsed.
+func (v *letClause) SetSpan(span abs.Span) {
+	v.span = span
+}
@@ -30,6 +30,7 @@ func NotarizeClause(document, name abs.Expression) abs.NotarizeClauseLike {
 type notarizeClause struct {
 	document abs.Expression
 	name     abs.Expression
+	span     abs.Span
 }
 
 // This method returns the document expression for this notarize clause.
@@ -57,3 +58,15 @@ func (v *notarizeClause) SetName(name abs.Expression) {
 	}
 	v.name = name
 }
+
+// This method returns the span of source bytes from which this notarize clause
+// was parsed.
+func (v *notarizeClause) GetSpan() abs.Span {
+	return v.span
+}
+
+// This method sets the span of source bytes from which this notarize clause was
+// parsed.
+func (v *notarizeClause) SetSpan(span abs.Span) {
+	v.span = span
+}
@@ -29,6 +29,7 @@ func OnClause(failure abs.SymbolLike, blocks abs.Sequential[abs.BlockLike]) abs.
 type onClause struct {
 	failure abs.SymbolLike
 	blocks  abs.Sequential[abs.BlockLike]
+	span    abs.Span
 }
 
 // This method returns the symbol for the failure for this on clause.
@@ -56,3 +57,15 @@ func (v *onClause) SetBlocks(blocks abs.Sequential[abs.BlockLike]) {
 	}
 	v.blocks = blocks
 }
+
+// This method returns the span of source bytes from which this on clause was
+// parsed.
+func (v *onClause) GetSpan() abs.Span {
+	return v.span
+}
+
+// This method sets the span of source bytes from which this on clause was
+// parsed.
+func (v *onClause) SetSpan(span abs.Span) {
+	v.span = span
+}
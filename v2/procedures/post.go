@@ -30,6 +30,7 @@ func PostClause(message, bag abs.Expression) abs.PostClauseLike {
 type postClause struct {
 	message abs.Expression
 	bag     abs.Expression
+	span    abs.Span
 }
 
 // This method returns the message expression for this post clause.
@@ -57,3 +58,15 @@ func (v *postClause) SetBag(bag abs.Expression) {
 	}
 	v.bag = bag
 }
+
+// This method returns the span of source bytes from which this post clause was
+// parsed.
+func (v *postClause) GetSpan() abs.Span {
+	return v.span
+}
+
+// This method sets the span of source bytes from which this post clause was
+// parsed.
+func (v *postClause) SetSpan(span abs.Span) {
+	v.span = span
+}
@@ -28,6 +28,7 @@ func PublishClause(event abs.Expression) abs.PublishClauseLike {
 // clause.
 type publishClause struct {
 	event abs.Expression
+	span  abs.Span
 }
 
 // This method returns the event expression for this publish clause.
@@ -42,3 +43,15 @@ func (v *publishClause) SetEvent(event abs.Expression) {
 	}
 	v.event = event
 }
+
+// This method returns the span of source bytes from which this publish clause
+// was parsed.
+func (v *publishClause) GetSpan() abs.Span {
+	return v.span
+}
+
+// This method sets the span of source bytes from which this publish clause was
+// parsed.
+func (v *publishClause) SetSpan(span abs.Span) {
+	v.span = span
+}
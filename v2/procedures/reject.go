@@ -28,6 +28,7 @@ func RejectClause(message abs.Expression) abs.RejectClauseLike {
 // clause.
 type rejectClause struct {
 	message abs.Expression
+	span    abs.Span
 }
 
 // This method returns the message expression for this reject clause.
@@ -42,3 +43,15 @@ func (v *rejectClause) SetMessage(message abs.Expression) {
 	}
 	v.message = message
 }
+
+// This method returns the span of source bytes from which this reject clause
+// was parsed.
+func (v *rejectClause) GetSpan() abs.Span {
+	return v.span
+}
+
+// This method sets the span of source bytes from which this reject clause was
+// parsed.
+func (v *rejectClause) SetSpan(span abs.Span) {
+	v.span = span
+}
@@ -30,6 +30,7 @@ func RetrieveClause(recipient abs.Recipient, bag abs.Expression) abs.RetrieveCla
 type retrieveClause struct {
 	recipient abs.Recipient
 	bag       abs.Expression
+	span      abs.Span
 }
 
 // This method returns the recipient expression for this retrieve clause.
@@ -57,3 +58,15 @@ func (v *retrieveClause) SetBag(bag abs.Expression) {
 	}
 	v.bag = bag
 }
+
+// This method returns the span of source bytes from which this retrieve clause
+// was parsed.
+func (v *retrieveClause) GetSpan() abs.Span {
+	return v.span
+}
+
+// This method sets the span of source bytes from which this retrieve clause was
+// parsed.
+func (v *retrieveClause) SetSpan(span abs.Span) {
+	v.span = span
+}
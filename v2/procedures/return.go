@@ -28,6 +28,7 @@ func ReturnClause(result abs.Expression) abs.ReturnClauseLike {
 // clause.
 type returnClause struct {
 	result abs.Expression
+	span   abs.Span
 }
 
 // This method returns the result expression for this return clause.
@@ -42,3 +43,15 @@ func (v *returnClause) SetResult(result abs.Expression) {
 	}
 	v.result = result
 }
+
+// This method returns the span of source bytes from which this return clause
+// was parsed.
+func (v *returnClause) GetSpan() abs.Span {
+	return v.span
+}
+
+// This method sets the span of source bytes from which this return clause was
+// parsed.
+func (v *returnClause) SetSpan(span abs.Span) {
+	v.span = span
+}
@@ -30,6 +30,7 @@ func SaveClause(document abs.Expression, recipient abs.Recipient) abs.SaveClause
 type saveClause struct {
 	document  abs.Expression
 	recipient abs.Recipient
+	span      abs.Span
 }
 
 // This method returns the document expression for this save clause.
@@ -57,3 +58,15 @@ func (v *saveClause) SetRecipient(recipient abs.Recipient) {
 	}
 	v.recipient = recipient
 }
+
+// This method returns the span of source bytes from which this save clause was
+// parsed.
+func (v *saveClause) GetSpan() abs.Span {
+	return v.span
+}
+
+// This method sets the span of source bytes from which this save clause was
+// parsed.
+func (v *saveClause) SetSpan(span abs.Span) {
+	v.span = span
+}
@@ -29,6 +29,7 @@ func SelectClause(target abs.Expression, blocks abs.Sequential[abs.BlockLike]) a
 type selectClause struct {
 	target abs.Expression
 	blocks abs.Sequential[abs.BlockLike]
+	span   abs.Span
 }
 
 // This method returns the target expression for this select clause.
@@ -56,3 +57,15 @@ func (v *selectClause) SetBlocks(blocks abs.Sequential[abs.BlockLike]) {
 	}
 	v.blocks = blocks
 }
+
+// This method returns the span of source bytes from which this select clause
+// was parsed.
+func (v *selectClause) GetSpan() abs.Span {
+	return v.span
+}
+
+// This method sets the span of source bytes from which this select clause was
+// parsed.
+func (v *selectClause) SetSpan(span abs.Span) {
+	v.span = span
+}
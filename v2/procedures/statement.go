@@ -39,6 +39,7 @@ type statement struct {
 	mainClause abs.Clause
 	onClause   abs.OnClauseLike
 	note       abs.NoteLike
+	span       abs.Span
 }
 
 // This method returns the annotation for this statement.
@@ -80,3 +81,15 @@ func (v *statement) GetOnClause() abs.OnClauseLike {
 func (v *statement) SetOnClause(onClause abs.OnClauseLike) {
 	v.onClause = onClause
 }
+
+// This method returns the span of source bytes from which this statement was
+// parsed.
+func (v *statement) GetSpan() abs.Span {
+	return v.span
+}
+
+// This method sets the span of source bytes from which this statement was
+// parsed.
+func (v *statement) SetSpan(span abs.Span) {
+	v.span = span
+}
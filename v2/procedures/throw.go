@@ -28,6 +28,7 @@ func ThrowClause(exception abs.Expression) abs.ThrowClauseLike {
 // clause.
 type throwClause struct {
 	exception abs.Expression
+	span      abs.Span
 }
 
 // This method returns the exception expression for this throw clause.
@@ -42,3 +43,15 @@ func (v *throwClause) SetException(exception abs.Expression) {
 	}
 	v.exception = exception
 }
+
+// This method returns the span of source bytes from which this throw clause was
+// parsed.
+func (v *throwClause) GetSpan() abs.Span {
+	return v.span
+}
+
+// This method sets the span of source bytes from which this throw clause was
+// parsed.
+func (v *throwClause) SetSpan(span abs.Span) {
+	v.span = span
+}
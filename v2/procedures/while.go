@@ -27,6 +27,7 @@ func WhileClause(block abs.BlockLike) abs.WhileClauseLike {
 // This type defines the structure and methods associated with a while clause.
 type whileClause struct {
 	block abs.BlockLike
+	span  abs.Span
 }
 
 // This method returns the block for this while clause.
@@ -41,3 +42,15 @@ func (v *whileClause) SetBlock(block abs.BlockLike) {
 	}
 	v.block = block
 }
+
+// This method returns the span of source bytes from which this while clause was
+// parsed.
+func (v *whileClause) GetSpan() abs.Span {
+	return v.span
+}
+
+// This method sets the span of source bytes from which this while clause was
+// parsed.
+func (v *whileClause) SetSpan(span abs.Span) {
+	v.span = span
+}
@@ -29,6 +29,7 @@ func WithClause(item abs.SymbolLike, block abs.BlockLike) abs.WithClauseLike {
 type withClause struct {
 	item  abs.SymbolLike
 	block abs.BlockLike
+	span  abs.Span
 }
 
 // This method returns the symbol for the item for this with clause.
@@ -56,3 +57,15 @@ func (v *withClause) SetBlock(block abs.BlockLike) {
 	}
 	v.block = block
 }
+
+// This method returns the span of source bytes from which this with clause was
+// parsed.
+func (v *withClause) GetSpan() abs.Span {
+	return v.span
+}
+
+// This method sets the span of source bytes from which this with clause was
+// parsed.
+func (v *withClause) SetSpan(span abs.Span) {
+	v.span = span
+}
@@ -0,0 +1,165 @@
+/*******************************************************************************
+ *   Copyright (c) 2009-2023 Crater Dog Technologies™.  All Rights Reserved.   *
+ *******************************************************************************
+ * DO NOT ALTER OR REMOVE COPYRIGHT NOTICES OR THIS FILE HEADER.               *
+ *                                                                             *
+ * This code is free software; you can redistribute it and/or modify it under  *
+ * the terms of The MIT License (MIT), as published by the Open Source         *
+ * Initiative. (See http://opensource.org/licenses/MIT)                        *
+ *******************************************************************************/
+
+package programs
+
+import (
+	fmt "fmt"
+	abs "github.com/bali-nebula/go-component-framework/v2/abstractions"
+	col "github.com/bali-nebula/go-component-framework/v2/collections"
+	com "github.com/bali-nebula/go-component-framework/v2/components"
+	str "github.com/bali-nebula/go-component-framework/v2/strings"
+	cox "github.com/craterdog/go-collection-framework/v2"
+)
+
+// PROGRAM IMPLEMENTATION
+
+// These symbols name the attributes of a program when it is represented as a
+// catalog.
+var (
+	bytecodeSymbol = str.SymbolFromString("bytecode")
+	literalsSymbol = str.SymbolFromString("literals")
+	symbolsSymbol  = str.SymbolFromString("symbols")
+)
+
+// This constructor creates a new program from the specified bytecode and the
+// literal and symbol tables that its instructions refer to. The indices used
+// by the instructions are ordinal based so the first literal and symbol in
+// each table have an index of 1.
+func Program(
+	bytecode abs.BytecodeLike,
+	literals abs.Sequential[abs.ComponentLike],
+	symbols abs.Sequential[abs.SymbolLike],
+) abs.ProgramLike {
+	return ProgramWithSource(bytecode, literals, symbols, nil)
+}
+
+// This constructor creates a new program like the Program() constructor does
+// but also records the source line that each instruction was compiled from.
+// The source lines are debugging information only, they are not part of the
+// catalog form of the program.
+func ProgramWithSource(
+	bytecode abs.BytecodeLike,
+	literals abs.Sequential[abs.ComponentLike],
+	symbols abs.Sequential[abs.SymbolLike],
+	source []string,
+) abs.ProgramLike {
+	if bytecode == nil {
+		panic("A program requires bytecode.")
+	}
+	if literals == nil || symbols == nil {
+		panic("A program requires a literal table and a symbol table.")
+	}
+	if source != nil && len(source) != bytecode.GetSize() {
+		var message = fmt.Sprintf("A program requires a source line for each of its %v instructions.", bytecode.GetSize())
+		panic(message)
+	}
+	var v = &program{
+		bytecode: bytecode,
+		literals: cox.ListFromSequence[abs.ComponentLike](literals),
+		symbols:  cox.ListFromSequence[abs.SymbolLike](symbols),
+		source:   source,
+	}
+	return v
+}
+
+// This constructor creates a new program from the specified catalog which must
+// have the following attributes:
+//
+//	[
+//	    $bytecode: '...'
+//	    $literals: [...]
+//	    $symbols: [$symbol1, $symbol2, ...]
+//	]
+//
+// This is the form in which a compiled procedure is shipped in a document.
+func ProgramFromCatalog(catalog abs.CatalogLike) abs.ProgramLike {
+	var bytecode = extractAttribute(catalog, bytecodeSymbol).ExtractBytecode()
+	var literals = extractAttribute(catalog, literalsSymbol).ExtractList()
+	var symbols = cox.List[abs.SymbolLike]()
+	for _, symbol := range extractAttribute(catalog, symbolsSymbol).ExtractList().AsArray() {
+		symbols.AddValue(symbol.ExtractSymbol())
+	}
+	return Program(bytecode, literals, symbols)
+}
+
+// This type defines the structure and methods associated with a compiled
+// program.
+type program struct {
+	bytecode abs.BytecodeLike
+	literals cox.ListLike[abs.ComponentLike]
+	symbols  cox.ListLike[abs.SymbolLike]
+	source   []string
+}
+
+// PUBLIC INTERFACE
+
+// This method returns the bytecode for this program.
+func (v *program) GetBytecode() abs.BytecodeLike {
+	return v.bytecode
+}
+
+// This method returns the literal table for this program.
+func (v *program) GetLiterals() abs.Sequential[abs.ComponentLike] {
+	return v.literals
+}
+
+// This method returns the literal at the specified index in the literal table
+// for this program.
+func (v *program) GetLiteral(index int) abs.ComponentLike {
+	return v.literals.GetValue(index)
+}
+
+// This method returns the symbol table for this program.
+func (v *program) GetSymbols() abs.Sequential[abs.SymbolLike] {
+	return v.symbols
+}
+
+// This method returns the symbol at the specified index in the symbol table
+// for this program.
+func (v *program) GetSymbol(index int) abs.SymbolLike {
+	return v.symbols.GetValue(index)
+}
+
+// This method returns the source line that the instruction at the specified
+// address in this program was compiled from. An empty string is returned if
+// the source line is not known.
+func (v *program) GetSource(address int) string {
+	if address < 1 || address > len(v.source) {
+		return ""
+	}
+	return v.source[address-1]
+}
+
+// This method returns a catalog containing the attributes of this program.
+func (v *program) AsCatalog() abs.CatalogLike {
+	var symbols = col.List()
+	for _, symbol := range v.symbols.AsArray() {
+		symbols.AddValue(com.Component(symbol))
+	}
+	var catalog = col.Catalog()
+	catalog.SetValue(bytecodeSymbol, com.Component(v.bytecode))
+	catalog.SetValue(literalsSymbol, com.Component(col.ListFromSequence(v.literals)))
+	catalog.SetValue(symbolsSymbol, com.Component(symbols))
+	return catalog
+}
+
+// PRIVATE FUNCTIONS
+
+// This function returns the value of the specified attribute in the specified
+// catalog.
+func extractAttribute(catalog abs.CatalogLike, symbol abs.SymbolLike) abs.ComponentLike {
+	var value = catalog.GetValue(symbol)
+	if value == nil {
+		var message = fmt.Sprintf("A program catalog requires a value for: $%v", symbol.AsString())
+		panic(message)
+	}
+	return value
+}
@@ -0,0 +1,77 @@
+/*******************************************************************************
+ *   Copyright (c) 2009-2023 Crater Dog Technologies™.  All Rights Reserved.   *
+ *******************************************************************************
+ * DO NOT ALTER OR REMOVE COPYRIGHT NOTICES OR THIS FILE HEADER.               *
+ *                                                                             *
+ * This code is free software; you can redistribute it and/or modify it under  *
+ * the terms of The MIT License (MIT), as published by the Open Source         *
+ * Initiative. (See http://opensource.org/licenses/MIT)                        *
+ *******************************************************************************/
+
+package programs_test
+
+import (
+	abs "github.com/bali-nebula/go-component-framework/v2/abstractions"
+	col "github.com/bali-nebula/go-component-framework/v2/collections"
+	com "github.com/bali-nebula/go-component-framework/v2/components"
+	ele "github.com/bali-nebula/go-component-framework/v2/elements"
+	prg "github.com/bali-nebula/go-component-framework/v2/programs"
+	str "github.com/bali-nebula/go-component-framework/v2/strings"
+	cox "github.com/craterdog/go-collection-framework/v2"
+	ass "github.com/stretchr/testify/assert"
+	tes "testing"
+)
+
+func TestProgramCatalogs(t *tes.T) {
+	var bytecode = str.BytecodeFromArray([]abs.Instruction{
+		abs.InstructionFromOperation(abs.PUSH, abs.LITERAL, 1),
+		abs.InstructionFromOperation(abs.PULL, abs.RESULT, 0),
+	})
+	var literals = col.List()
+	literals.AddValue(com.Component(ele.Number().FromComplex(42)))
+	var symbols = cox.List[abs.SymbolLike]()
+	symbols.AddValue(str.SymbolFromString("answer"))
+	var program = prg.Program(bytecode, literals, symbols)
+	ass.Equal(t, "'28015000'", program.GetBytecode().AsString())
+	ass.Equal(t, 42.0, program.GetLiteral(1).ExtractNumber().GetReal())
+	ass.Equal(t, "answer", program.GetSymbol(-1).AsString())
+
+	var copy = prg.ProgramFromCatalog(program.AsCatalog())
+	ass.Equal(t, program.GetBytecode().AsArray(), copy.GetBytecode().AsArray())
+	ass.Equal(t, program.GetLiterals().AsArray(), copy.GetLiterals().AsArray())
+	ass.Equal(t, program.GetSymbols().AsArray(), copy.GetSymbols().AsArray())
+}
+
+func TestProgramWithoutBytecode(t *tes.T) {
+	defer func() {
+		if e := recover(); e != nil {
+			ass.Equal(t, "A program requires bytecode.", e)
+		} else {
+			ass.Fail(t, "Test should result in recovered panic.")
+		}
+	}()
+	prg.Program(nil, col.List(), cox.List[abs.SymbolLike]()) // This should panic.
+}
+
+func TestProgramWithSource(t *tes.T) {
+	var bytecode = str.BytecodeFromArray([]abs.Instruction{
+		abs.InstructionFromOperation(abs.PUSH, abs.LITERAL, 1),
+		abs.InstructionFromOperation(abs.PULL, abs.RESULT, 0),
+	})
+	var literals = col.List()
+	literals.AddValue(com.Component(ele.Number().FromComplex(42)))
+	var symbols = cox.List[abs.SymbolLike]()
+	var program = prg.ProgramWithSource(bytecode, literals, symbols, []string{"return 42", "return 42"})
+	ass.Equal(t, "return 42", program.GetSource(2))
+	ass.Equal(t, "", program.GetSource(3))
+	ass.Equal(t, "", prg.Program(bytecode, literals, symbols).GetSource(1))
+
+	defer func() {
+		if e := recover(); e != nil {
+			ass.Equal(t, "A program requires a source line for each of its 2 instructions.", e)
+		} else {
+			ass.Fail(t, "Test should result in recovered panic.")
+		}
+	}()
+	prg.ProgramWithSource(bytecode, literals, symbols, []string{"return 42"}) // This should panic.
+}
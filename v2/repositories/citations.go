@@ -0,0 +1,122 @@
+/*******************************************************************************
+ *   Copyright (c) 2009-2023 Crater Dog Technologies™.  All Rights Reserved.   *
+ *******************************************************************************
+ * DO NOT ALTER OR REMOVE COPYRIGHT NOTICES OR THIS FILE HEADER.               *
+ *                                                                             *
+ * This code is free software; you can redistribute it and/or modify it under  *
+ * the terms of The MIT License (MIT), as published by the Open Source         *
+ * Initiative. (See http://opensource.org/licenses/MIT)                        *
+ *******************************************************************************/
+
+package repositories
+
+import (
+	sha "crypto/sha512"
+	fmt "fmt"
+	abs "github.com/bali-nebula/go-component-framework/v2/abstractions"
+	bal "github.com/bali-nebula/go-component-framework/v2/bali"
+	com "github.com/bali-nebula/go-component-framework/v2/components"
+	str "github.com/bali-nebula/go-component-framework/v2/strings"
+	sts "strings"
+)
+
+// CITATION FUNCTIONS
+
+// This function returns the SHA-512 digest of the canonical Bali source form
+// of the specified document.
+func DigestDocument(document abs.ComponentLike) abs.BinaryLike {
+	if document == nil {
+		panic("Attempted to digest a nil document.")
+	}
+	return digestSource(bal.FormatDocument(document))
+}
+
+// This function returns a content-addressed citation to the specified document.
+// The citation is a component whose entity is the specified citation and whose
+// context contains the digest of the document as its $digest parameter:
+//
+//	/acme/orders/Order/v1.2($digest: '>
+//	    ...
+//	<')
+func CiteDocument(citation abs.CitationLike, document abs.ComponentLike) abs.ComponentLike {
+	if citation == nil {
+		panic("Attempted to cite a document without a citation.")
+	}
+	return citeSource(citation, bal.FormatDocument(document))
+}
+
+// This function determines whether or not the specified document matches the
+// digest in the specified content-addressed citation. A citation without a
+// digest matches any document.
+func VerifyCitation(citation abs.ComponentLike, document abs.ComponentLike) bool {
+	var digest = GetDigest(citation)
+	if digest == nil {
+		return true
+	}
+	if document == nil {
+		return false
+	}
+	return DigestDocument(document).AsString() == digest.AsString()
+}
+
+// This function returns the digest in the specified content-addressed citation,
+// or nil if the citation does not have a digest.
+func GetDigest(citation abs.ComponentLike) abs.BinaryLike {
+	if citation == nil || !citation.IsParameterized() {
+		return nil
+	}
+	var digest = citation.GetContext().GetValue(str.SymbolFromString("digest"))
+	if digest == nil {
+		return nil
+	}
+	var binary, ok = digest.GetEntity().(abs.BinaryLike)
+	if !ok {
+		var message = fmt.Sprintf("The digest of a citation must be a binary string: %v", bal.FormatComponent(digest))
+		panic(message)
+	}
+	return binary
+}
+
+// This function retrieves the committed document that the specified citation
+// refers to from the specified repository. If the citation has a digest, the
+// digest of the retrieved document must match it, which guarantees that the
+// document has not changed since the citation was created.
+func ResolveCitation(repository abs.RepositoryLike, citation abs.ComponentLike) abs.ComponentLike {
+	if repository == nil {
+		panic("Attempted to resolve a citation without a repository.")
+	}
+	var cited, ok = citation.GetEntity().(abs.CitationLike)
+	if !ok {
+		var message = fmt.Sprintf("Attempted to resolve a citation that is not a citation: %v", bal.FormatComponent(citation))
+		panic(message)
+	}
+	var name = str.NameFromString(cited.GetName())
+	var version = str.VersionFromString(sts.TrimPrefix(cited.GetVersion(), "v"))
+	var document = repository.RetrieveDocument(name, version)
+	if document == nil {
+		var message = fmt.Sprintf("The repository does not contain the cited document: %v", cited.AsString())
+		panic(message)
+	}
+	if !VerifyCitation(citation, document) {
+		var message = fmt.Sprintf("The cited document does not match the digest in its citation: %v", cited.AsString())
+		panic(message)
+	}
+	return document
+}
+
+// PRIVATE FUNCTIONS
+
+// This private function returns the SHA-512 digest of the specified canonical
+// Bali source form of a document.
+func digestSource(source []byte) abs.BinaryLike {
+	var digest = sha.Sum512(source)
+	return str.BinaryFromArray(digest[:])
+}
+
+// This private function returns a content-addressed citation to the document
+// with the specified canonical Bali source form.
+func citeSource(citation abs.CitationLike, source []byte) abs.ComponentLike {
+	var context = com.Context()
+	context.SetValue(str.SymbolFromString("digest"), com.Component(digestSource(source)))
+	return com.ComponentWithContext(citation, context)
+}
@@ -0,0 +1,74 @@
+/*******************************************************************************
+ *   Copyright (c) 2009-2023 Crater Dog Technologies™.  All Rights Reserved.   *
+ *******************************************************************************
+ * DO NOT ALTER OR REMOVE COPYRIGHT NOTICES OR THIS FILE HEADER.               *
+ *                                                                             *
+ * This code is free software; you can redistribute it and/or modify it under  *
+ * the terms of The MIT License (MIT), as published by the Open Source         *
+ * Initiative. (See http://opensource.org/licenses/MIT)                        *
+ *******************************************************************************/
+
+package repositories_test
+
+import (
+	bal "github.com/bali-nebula/go-component-framework/v2/bali"
+	com "github.com/bali-nebula/go-component-framework/v2/components"
+	rep "github.com/bali-nebula/go-component-framework/v2/repositories"
+	str "github.com/bali-nebula/go-component-framework/v2/strings"
+	ass "github.com/stretchr/testify/assert"
+	osx "os"
+	tes "testing"
+)
+
+func TestCommittedCitations(t *tes.T) {
+	var repository = rep.LocalRepository(t.TempDir())
+	var name = str.NameFromString("/acme/orders/Order")
+	var version = str.VersionFromString("1.2")
+	var document = bal.ParseComponent("[$total: 5, $items: [$apple, $banana]]")
+
+	// The citation returned by a commit resolves to the committed document.
+	var citation = repository.CommitDocument(name, version, document)
+	ass.Equal(t, "/acme/orders/Order/v1.2", citation.ExtractCitation().AsString())
+	ass.Equal(t, bal.FormatDocument(rep.CiteDocument(citation.ExtractCitation(), document)), bal.FormatDocument(citation))
+	var retrieved = repository.RetrieveDocument(name, version)
+	ass.True(t, rep.VerifyCitation(citation, retrieved))
+	ass.Equal(t, bal.FormatDocument(document), bal.FormatDocument(rep.ResolveCitation(repository, citation)))
+}
+
+func TestCitationDigests(t *tes.T) {
+	var directory = t.TempDir()
+	var repository = rep.LocalRepository(directory)
+	var name = str.NameFromString("/acme/orders/Order")
+	var document = bal.ParseComponent("[$total: 5]")
+	var citation = repository.CommitDocument(name, str.VersionFromString("1"), document)
+	ass.Equal(t, 64, rep.GetDigest(citation).GetSize())
+	ass.Equal(t, rep.DigestDocument(document).AsString(), rep.GetDigest(citation).AsString())
+	ass.True(t, rep.VerifyCitation(citation, document))
+	ass.False(t, rep.VerifyCitation(citation, bal.ParseComponent("[$total: 6]")))
+
+	// A content-addressed citation survives a round trip through its Bali
+	// source form.
+	citation = bal.ParseDocument(bal.FormatDocument(citation))
+	var resolved = rep.ResolveCitation(repository, citation)
+	ass.Equal(t, bal.FormatDocument(document), bal.FormatDocument(resolved))
+
+	// A citation without a digest matches any version of the document.
+	var plain = com.Component(citation.ExtractCitation())
+	ass.Nil(t, rep.GetDigest(plain))
+	ass.True(t, rep.VerifyCitation(plain, bal.ParseComponent("[$total: 6]")))
+
+	// A committed document that was changed behind the repository's back no
+	// longer matches its citation.
+	var filename = directory + "/documents/acme/orders/Order/v1.bali"
+	var err = osx.WriteFile(filename, bal.FormatDocument(bal.ParseComponent("[$total: 6]")), 0600)
+	ass.Nil(t, err)
+	ass.Equal(t, "[$total: 6]\n", string(bal.FormatDocument(rep.ResolveCitation(repository, plain))))
+	defer func() {
+		if e := recover(); e != nil {
+			ass.Equal(t, "The cited document does not match the digest in its citation: /acme/orders/Order/v1", e)
+		} else {
+			ass.Fail(t, "Test should result in recovered panic.")
+		}
+	}()
+	rep.ResolveCitation(repository, citation) // This should panic.
+}
@@ -0,0 +1,258 @@
+/*******************************************************************************
+ *   Copyright (c) 2009-2023 Crater Dog Technologies™.  All Rights Reserved.   *
+ *******************************************************************************
+ * DO NOT ALTER OR REMOVE COPYRIGHT NOTICES OR THIS FILE HEADER.               *
+ *                                                                             *
+ * This code is free software; you can redistribute it and/or modify it under  *
+ * the terms of The MIT License (MIT), as published by the Open Source         *
+ * Initiative. (See http://opensource.org/licenses/MIT)                        *
+ *******************************************************************************/
+
+package repositories
+
+import (
+	fmt "fmt"
+	abs "github.com/bali-nebula/go-component-framework/v2/abstractions"
+	bal "github.com/bali-nebula/go-component-framework/v2/bali"
+	ele "github.com/bali-nebula/go-component-framework/v2/elements"
+	str "github.com/bali-nebula/go-component-framework/v2/strings"
+	uti "github.com/bali-nebula/go-component-framework/v2/utilities"
+	osx "os"
+	sts "strings"
+	syn "sync"
+)
+
+// CONSTANT DEFINITIONS
+
+const (
+	documentSuffix = ".bali" // The suffix of a file holding a document or draft.
+)
+
+// LOCAL REPOSITORY IMPLEMENTATION
+
+// This constructor creates a new repository that stores its documents in the
+// local filesystem under the specified directory. Each document is stored by
+// its name and version, so the document "/acme/orders/Order" version 1.2 is
+// stored in the file "documents/acme/orders/Order/v1.2.bali" and a draft of it
+// is stored in the file "drafts/acme/orders/Order/v1.2.bali". A committed
+// version of a document can never be changed or removed, and a draft can never
+// be saved with the version of a committed document. Each file is written
+// atomically so that it never holds part of a document.
+func LocalRepository(directory string) abs.RepositoryLike {
+	if len(directory) == 0 {
+		panic("A local repository requires a directory.")
+	}
+	directory = sts.TrimSuffix(directory, "/")
+	var err = osx.MkdirAll(directory, 0700)
+	if err != nil {
+		var message = fmt.Sprintf("Could not create the repository directory: %v.", err)
+		panic(message)
+	}
+	return &localRepository{directory: directory}
+}
+
+// This type defines the structure and methods associated with a local
+// repository.
+type localRepository struct {
+	mutex     syn.Mutex
+	directory string
+}
+
+// ARCHIVAL INTERFACE
+
+// This method returns the latest committed version of the named document, or
+// nil if no version of the document has been committed.
+func (v *localRepository) GetLatestVersion(name abs.NameLike) abs.VersionLike {
+	v.mutex.Lock()
+	defer v.mutex.Unlock()
+	var entries, err = osx.ReadDir(v.getDirectory("documents", name))
+	if err != nil {
+		return nil
+	}
+	var latest abs.VersionLike
+	for _, entry := range entries {
+		var filename = entry.Name()
+		if entry.IsDir() || !sts.HasPrefix(filename, "v") || !sts.HasSuffix(filename, documentSuffix) {
+			continue
+		}
+		var version, ok = v.parseVersion(filename[1 : len(filename)-len(documentSuffix)])
+		if ok && (latest == nil || v.isLater(version, latest)) {
+			latest = version
+		}
+	}
+	return latest
+}
+
+// This method determines whether or not the specified version of the named
+// document has been committed.
+func (v *localRepository) DocumentExists(name abs.NameLike, version abs.VersionLike) bool {
+	v.mutex.Lock()
+	defer v.mutex.Unlock()
+	return v.fileExists(v.getFilename("documents", name, version))
+}
+
+// This method returns the specified committed version of the named document,
+// or nil if that version has not been committed.
+func (v *localRepository) RetrieveDocument(name abs.NameLike, version abs.VersionLike) abs.ComponentLike {
+	v.mutex.Lock()
+	defer v.mutex.Unlock()
+	return v.readFile(v.getFilename("documents", name, version))
+}
+
+// This method commits the specified document as the specified version of the
+// named document and returns a content-addressed citation to it. Any draft
+// with the same version is removed. A version that has already been committed
+// cannot be committed again.
+func (v *localRepository) CommitDocument(
+	name abs.NameLike,
+	version abs.VersionLike,
+	document abs.ComponentLike,
+) abs.ComponentLike {
+	v.mutex.Lock()
+	defer v.mutex.Unlock()
+	var filename = v.getFilename("documents", name, version)
+	v.createDirectory(filename)
+	var source = bal.FormatDocument(document)
+	var err = uti.CreateFile(filename, source, 0600)
+	if err != nil {
+		if osx.IsExist(err) {
+			var message = fmt.Sprintf("The repository already contains a committed version of the document: %v", v.formatCitation(name, version))
+			panic(message)
+		}
+		var message = fmt.Sprintf("Could not create the document file: %v.", err)
+		panic(message)
+	}
+	_ = osx.Remove(v.getFilename("drafts", name, version))
+	return citeSource(ele.Citation().FromString(v.formatCitation(name, version)), source)
+}
+
+// DRAFTING INTERFACE
+
+// This method determines whether or not a draft exists for the specified
+// version of the named document.
+func (v *localRepository) DraftExists(name abs.NameLike, version abs.VersionLike) bool {
+	v.mutex.Lock()
+	defer v.mutex.Unlock()
+	return v.fileExists(v.getFilename("drafts", name, version))
+}
+
+// This method returns the draft of the specified version of the named
+// document, or nil if no such draft exists.
+func (v *localRepository) RetrieveDraft(name abs.NameLike, version abs.VersionLike) abs.ComponentLike {
+	v.mutex.Lock()
+	defer v.mutex.Unlock()
+	return v.readFile(v.getFilename("drafts", name, version))
+}
+
+// This method saves the specified draft as the specified version of the named
+// document, replacing any previous draft of that version, and returns a
+// content-addressed citation to it. A draft cannot be saved with the version
+// of a committed document.
+func (v *localRepository) SaveDraft(
+	name abs.NameLike,
+	version abs.VersionLike,
+	draft abs.ComponentLike,
+) abs.ComponentLike {
+	v.mutex.Lock()
+	defer v.mutex.Unlock()
+	if v.fileExists(v.getFilename("documents", name, version)) {
+		var message = fmt.Sprintf("The repository already contains a committed version of the document: %v", v.formatCitation(name, version))
+		panic(message)
+	}
+	var filename = v.getFilename("drafts", name, version)
+	v.createDirectory(filename)
+	var source = bal.FormatDocument(draft)
+	var err = uti.WriteFile(filename, source, 0600)
+	if err != nil {
+		var message = fmt.Sprintf("Could not write the draft file: %v.", err)
+		panic(message)
+	}
+	return citeSource(ele.Citation().FromString(v.formatCitation(name, version)), source)
+}
+
+// This method deletes the draft of the specified version of the named
+// document. It does nothing if no such draft exists.
+func (v *localRepository) DiscardDraft(name abs.NameLike, version abs.VersionLike) {
+	v.mutex.Lock()
+	defer v.mutex.Unlock()
+	var err = osx.Remove(v.getFilename("drafts", name, version))
+	if err != nil && !osx.IsNotExist(err) {
+		var message = fmt.Sprintf("Could not delete the draft file: %v.", err)
+		panic(message)
+	}
+}
+
+// PRIVATE METHODS
+
+// This private method returns the directory that holds the versions of the
+// named document in the specified area of this repository.
+func (v *localRepository) getDirectory(area string, name abs.NameLike) string {
+	return v.directory + "/" + area + name.AsString()
+}
+
+// This private method returns the name of the file that holds the specified
+// version of the named document in the specified area of this repository.
+func (v *localRepository) getFilename(area string, name abs.NameLike, version abs.VersionLike) string {
+	return v.getDirectory(area, name) + "/v" + version.AsString() + documentSuffix
+}
+
+// This private method returns the Bali source form of a citation to the
+// specified version of the named document.
+func (v *localRepository) formatCitation(name abs.NameLike, version abs.VersionLike) string {
+	return name.AsString() + "/v" + version.AsString()
+}
+
+// This private method creates the directory that will contain the specified
+// file, if necessary.
+func (v *localRepository) createDirectory(filename string) {
+	var directory = filename[:sts.LastIndex(filename, "/")]
+	var err = osx.MkdirAll(directory, 0700)
+	if err != nil {
+		var message = fmt.Sprintf("Could not create the document directory: %v.", err)
+		panic(message)
+	}
+}
+
+// This private method determines whether or not the specified file exists.
+func (v *localRepository) fileExists(filename string) bool {
+	var _, err = osx.Stat(filename)
+	return err == nil
+}
+
+// This private method returns the document stored in the specified file, or
+// nil if the file does not exist.
+func (v *localRepository) readFile(filename string) abs.ComponentLike {
+	var document, err = osx.ReadFile(filename)
+	if err != nil {
+		if osx.IsNotExist(err) {
+			return nil
+		}
+		var message = fmt.Sprintf("Could not read the document file: %v.", err)
+		panic(message)
+	}
+	return bal.ParseDocument(document)
+}
+
+// This private method returns the version string that the specified string
+// contains and whether or not it is a valid version string.
+func (v *localRepository) parseVersion(string_ string) (version abs.VersionLike, ok bool) {
+	defer func() {
+		if e := recover(); e != nil {
+			ok = false
+		}
+	}()
+	return str.VersionFromString(string_), true
+}
+
+// This private method determines whether or not the first version is later
+// than the second version.
+func (v *localRepository) isLater(first, second abs.VersionLike) bool {
+	var firsts = first.AsArray()
+	var seconds = second.AsArray()
+	for index := 0; index < len(firsts) && index < len(seconds); index++ {
+		if firsts[index] != seconds[index] {
+			return firsts[index] > seconds[index]
+		}
+	}
+	return len(firsts) > len(seconds)
+}
@@ -0,0 +1,80 @@
+/*******************************************************************************
+ *   Copyright (c) 2009-2023 Crater Dog Technologies™.  All Rights Reserved.   *
+ *******************************************************************************
+ * DO NOT ALTER OR REMOVE COPYRIGHT NOTICES OR THIS FILE HEADER.               *
+ *                                                                             *
+ * This code is free software; you can redistribute it and/or modify it under  *
+ * the terms of The MIT License (MIT), as published by the Open Source         *
+ * Initiative. (See http://opensource.org/licenses/MIT)                        *
+ *******************************************************************************/
+
+package repositories_test
+
+import (
+	bal "github.com/bali-nebula/go-component-framework/v2/bali"
+	rep "github.com/bali-nebula/go-component-framework/v2/repositories"
+	str "github.com/bali-nebula/go-component-framework/v2/strings"
+	ass "github.com/stretchr/testify/assert"
+	tes "testing"
+)
+
+func TestLocalRepository(t *tes.T) {
+	var repository = rep.LocalRepository(t.TempDir())
+	var name = str.NameFromString("/acme/orders/Order")
+	var v1 = str.VersionFromString("1")
+	var v1_2 = str.VersionFromString("1.2")
+	var v1_10 = str.VersionFromString("1.10")
+	ass.Nil(t, repository.GetLatestVersion(name))
+	ass.Nil(t, repository.RetrieveDocument(name, v1))
+
+	var document = bal.ParseComponent("[$total: 5]")
+	var citation = repository.CommitDocument(name, v1, document)
+	ass.Equal(t, "/acme/orders/Order/v1", citation.ExtractCitation().AsString())
+	repository.CommitDocument(name, v1_10, document)
+	repository.CommitDocument(name, v1_2, document)
+	ass.Equal(t, "1.10", repository.GetLatestVersion(name).AsString())
+	ass.True(t, repository.DocumentExists(name, v1_2))
+	ass.Equal(t, bal.FormatComponent(document), bal.FormatComponent(repository.RetrieveDocument(name, v1)))
+
+	var v2 = str.VersionFromString("2")
+	var draft = bal.ParseComponent("[$total: 7]")
+	citation = repository.SaveDraft(name, v2, draft)
+	ass.Equal(t, "/acme/orders/Order/v2", citation.ExtractCitation().AsString())
+	ass.True(t, rep.VerifyCitation(citation, draft))
+	ass.True(t, repository.DraftExists(name, v2))
+	ass.False(t, repository.DocumentExists(name, v2))
+	ass.Equal(t, bal.FormatComponent(draft), bal.FormatComponent(repository.RetrieveDraft(name, v2)))
+	repository.DiscardDraft(name, v2)
+	ass.False(t, repository.DraftExists(name, v2))
+	ass.Nil(t, repository.RetrieveDraft(name, v2))
+
+	repository.SaveDraft(name, v2, draft)
+	repository.CommitDocument(name, v2, draft)
+	ass.False(t, repository.DraftExists(name, v2))
+	ass.Equal(t, "2", repository.GetLatestVersion(name).AsString())
+
+	defer func() {
+		if e := recover(); e != nil {
+			ass.Equal(t, "The repository already contains a committed version of the document: /acme/orders/Order/v2", e)
+		} else {
+			ass.Fail(t, "Test should result in recovered panic.")
+		}
+	}()
+	repository.SaveDraft(name, v2, draft) // This should panic.
+}
+
+func TestCommittedVersionsAreImmutable(t *tes.T) {
+	var repository = rep.LocalRepository(t.TempDir())
+	var name = str.NameFromString("/acme/orders/Order")
+	var v1 = str.VersionFromString("1")
+	repository.CommitDocument(name, v1, bal.ParseComponent("[$total: 5]"))
+	defer func() {
+		if e := recover(); e != nil {
+			ass.Equal(t, "The repository already contains a committed version of the document: /acme/orders/Order/v1", e)
+			ass.Equal(t, "[$total: 5]", bal.FormatComponent(repository.RetrieveDocument(name, v1)))
+		} else {
+			ass.Fail(t, "Test should result in recovered panic.")
+		}
+	}()
+	repository.CommitDocument(name, v1, bal.ParseComponent("[$total: 6]")) // This should panic.
+}
@@ -0,0 +1,75 @@
+/*******************************************************************************
+ *   Copyright (c) 2009-2023 Crater Dog Technologies™.  All Rights Reserved.   *
+ *******************************************************************************
+ * DO NOT ALTER OR REMOVE COPYRIGHT NOTICES OR THIS FILE HEADER.               *
+ *                                                                             *
+ * This code is free software; you can redistribute it and/or modify it under  *
+ * the terms of The MIT License (MIT), as published by the Open Source         *
+ * Initiative. (See http://opensource.org/licenses/MIT)                        *
+ *******************************************************************************/
+
+package utilities
+
+import (
+	osx "os"
+	pth "path/filepath"
+)
+
+// This function writes the specified bytes to the file with the specified path
+// so that the file either holds all of the bytes or is left unchanged. The
+// bytes are first written to a temporary file in the same directory, which is
+// synchronized to disk and then renamed to replace any existing file.
+func WriteFile(path string, bytes []byte, mode osx.FileMode) error {
+	var temporary, err = writeTemporary(path, bytes, mode)
+	if err != nil {
+		return err
+	}
+	err = osx.Rename(temporary, path)
+	if err != nil {
+		osx.Remove(temporary)
+	}
+	return err
+}
+
+// This function creates a new file with the specified path that holds the
+// specified bytes. The file either holds all of the bytes or does not exist,
+// and an existing file is never replaced. The bytes are first written to a
+// temporary file in the same directory, which is synchronized to disk and then
+// linked into place. An error satisfying os.IsExist() is returned if the file
+// already exists.
+func CreateFile(path string, bytes []byte, mode osx.FileMode) error {
+	var temporary, err = writeTemporary(path, bytes, mode)
+	if err != nil {
+		return err
+	}
+	err = osx.Link(temporary, path)
+	osx.Remove(temporary)
+	return err
+}
+
+// This function writes the specified bytes to a new temporary file in the
+// directory of the specified path and synchronizes it to disk. It returns the
+// path of the temporary file, which is removed again if anything fails.
+func writeTemporary(path string, bytes []byte, mode osx.FileMode) (string, error) {
+	var file, err = osx.CreateTemp(pth.Dir(path), "."+pth.Base(path)+".*.tmp")
+	if err != nil {
+		return "", err
+	}
+	var temporary = file.Name()
+	_, err = file.Write(bytes)
+	if err == nil {
+		err = file.Chmod(mode)
+	}
+	if err == nil {
+		err = file.Sync()
+	}
+	var closing = file.Close()
+	if err == nil {
+		err = closing
+	}
+	if err != nil {
+		osx.Remove(temporary)
+		return "", err
+	}
+	return temporary, nil
+}
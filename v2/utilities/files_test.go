@@ -0,0 +1,48 @@
+/*******************************************************************************
+ *   Copyright (c) 2009-2023 Crater Dog Technologies™.  All Rights Reserved.   *
+ *******************************************************************************
+ * DO NOT ALTER OR REMOVE COPYRIGHT NOTICES OR THIS FILE HEADER.               *
+ *                                                                             *
+ * This code is free software; you can redistribute it and/or modify it under  *
+ * the terms of The MIT License (MIT), as published by the Open Source         *
+ * Initiative. (See http://opensource.org/licenses/MIT)                        *
+ *******************************************************************************/
+
+package utilities_test
+
+import (
+	uti "github.com/bali-nebula/go-component-framework/v2/utilities"
+	ass "github.com/stretchr/testify/assert"
+	osx "os"
+	pth "path/filepath"
+	tes "testing"
+)
+
+func TestWriteFile(t *tes.T) {
+	var directory = t.TempDir()
+	var path = pth.Join(directory, "document.bali")
+	ass.Nil(t, uti.WriteFile(path, []byte("first"), 0600))
+	ass.Nil(t, uti.WriteFile(path, []byte("second"), 0640))
+	var bytes, err = osx.ReadFile(path)
+	ass.Nil(t, err)
+	ass.Equal(t, "second", string(bytes))
+	var info, _ = osx.Stat(path)
+	ass.Equal(t, osx.FileMode(0640), info.Mode().Perm())
+	var entries, _ = osx.ReadDir(directory)
+	ass.Equal(t, 1, len(entries))
+
+	err = uti.WriteFile(pth.Join(directory, "missing", "document.bali"), []byte("third"), 0600)
+	ass.NotNil(t, err)
+}
+
+func TestCreateFile(t *tes.T) {
+	var directory = t.TempDir()
+	var path = pth.Join(directory, "document.bali")
+	ass.Nil(t, uti.CreateFile(path, []byte("first"), 0600))
+	var err = uti.CreateFile(path, []byte("second"), 0600)
+	ass.True(t, osx.IsExist(err))
+	var bytes, _ = osx.ReadFile(path)
+	ass.Equal(t, "first", string(bytes))
+	var entries, _ = osx.ReadDir(directory)
+	ass.Equal(t, 1, len(entries))
+}
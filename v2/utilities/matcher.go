@@ -71,7 +71,7 @@ const (
 	dates       = years + `?` + months + `?` + days + `?`
 	day         = `(?:[012][1-9])|(?:[3][01])`
 	days        = `(` + span + `D)`
-	delimiter   = `≠|~|\}|\||\{|\^|\]|\[|@|\?=|>|=|<-|<|;|:=|:|/=|//|/|\.\.|\.|-=|-|,|\+=|\+|\*=|\*|\)|\(|&`
+	delimiter   = `≠|!=|~|\}|\||\{|\^|\]|\[|@|\?=|>|=|<-|<|;|:=|:|/=|//|/|\.\.|\.|-=|-|,|\+=|\+|\*=|\*|\)|\(|&`
 	digit       = `\pN` // All unicode digits.
 	duration    = `~(` + sign + `?)P(?:` + weeks + `|` + dates + `(?:` + times + `)?)`
 	e           = `e`
@@ -102,7 +102,7 @@ const (
 	name        = `(?:/` + identifier + `)+` // Cannot capture each identifier...
 	narrative   = `">` + eol + `((?:.|` + eol + `)*` + eol + `)` + space + `*<"`
 	note        = `! [^` + control + `]*`
-	number      = imaginary + `|` + real_ + `|` + complex_ + `|` + zero + `|` + infinity + `|` + undefined
+	number      = infinity + `|` + imaginary + `|` + real_ + `|` + complex_ + `|` + zero + `|` + undefined
 	ordinal     = `[1-9][0-9]*`
 	path        = `[^?#>` + control + `]*`
 	pattern     = `none` + `|` + regex + `|` + `any`